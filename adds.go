@@ -0,0 +1,129 @@
+package metar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	addsBaseURL = "https://www.aviationweather.gov/adds/dataserver_current/httpparam"
+)
+
+// ErrInconsistentResults is returned when the API reports a different number of results than it delivered
+var ErrInconsistentResults = errors.New("Got inconsistent number of results")
+
+// ADDS is the Source backed by the Aviation Digital Data Service of
+// aviationweather.gov. It is used by clients if no other Source is set.
+type ADDS struct {
+	// BaseURL of the dataserver endpoint, defaults to the aviationweather.gov API
+	BaseURL string
+}
+
+// Fetch retrieves the observations reported within the time window of the query
+func (a ADDS) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	it, err := a.Stream(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	return it.all()
+}
+
+// Stream retrieves the observations like Fetch but decodes them one at a
+// time while iterating, keeping the memory usage of large area queries
+// flat
+func (a ADDS) Stream(ctx context.Context, q Query) (*ResultIterator, error) {
+	var fields []Field
+	if len(q.Fields) > 0 {
+		fields = withRequiredFields(q.Fields)
+	}
+
+	res, err := get(ctx, a.baseURL()+"?"+a.params(q, fields).Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		res.Body.Close()
+		return nil, fmt.Errorf("ADDS server returned status %d", res.StatusCode)
+	}
+
+	it := NewResultIterator(res.Body)
+	it.body = res.Body
+	it.logger = requestLogger(ctx)
+	it.maxResults = maxResults(ctx)
+	if lenientXML(ctx) {
+		it.setLenient()
+	}
+	it.prepare = func(r *Result) { setResultOrigin(r, res) }
+	return it, nil
+}
+
+func (a ADDS) params(q Query, fields []Field) url.Values {
+	params := url.Values{
+		"dataSource":  {"metars"},
+		"requestType": {"retrieve"},
+		"format":      {"xml"},
+	}
+
+	if len(q.Stations) > 0 {
+		params.Set("stationString", strings.Join(q.Stations, ","))
+	}
+
+	if q.Area != nil {
+		params.Set("minLat", strconv.FormatFloat(q.Area.MinLat, 'f', -1, 64))
+		params.Set("minLon", strconv.FormatFloat(q.Area.MinLon, 'f', -1, 64))
+		params.Set("maxLat", strconv.FormatFloat(q.Area.MaxLat, 'f', -1, 64))
+		params.Set("maxLon", strconv.FormatFloat(q.Area.MaxLon, 'f', -1, 64))
+	}
+
+	switch {
+	case !q.Start.IsZero() || !q.End.IsZero():
+		end := q.End
+		if end.IsZero() {
+			end = time.Now()
+		}
+		params.Set("startTime", q.Start.UTC().Format(time.RFC3339))
+		params.Set("endTime", end.UTC().Format(time.RFC3339))
+	case q.HoursBeforeNow > 0:
+		params.Set("hoursBeforeNow", strconv.FormatFloat(q.HoursBeforeNow, 'f', -1, 64))
+	default:
+		params.Set("hoursBeforeNow", "2")
+	}
+
+	switch q.Selection {
+	case MostRecent:
+		params.Set("mostRecent", "true")
+	case MostRecentForEachStation:
+		params.Set("mostRecentForEachStation", "constraint")
+	}
+
+	if len(fields) > 0 {
+		names := make([]string, len(fields))
+		for i, f := range fields {
+			names[i] = string(f)
+		}
+		params.Set("fields", strings.Join(names, ","))
+	}
+
+	return params
+}
+
+func (a ADDS) baseURL() string {
+	if a.BaseURL != "" {
+		return a.BaseURL
+	}
+	return addsBaseURL
+}
+
+// DecodeXML reads a dataserver XML response (as returned by the ADDS API)
+// and returns the contained results
+func DecodeXML(r io.Reader) ([]Result, error) {
+	return NewResultIterator(r).all()
+}
@@ -0,0 +1,78 @@
+package metar
+
+// Aggregation summarizes a set of results, for example those of an area
+// query. Values whose station is empty were not reported by any result.
+type Aggregation struct {
+	Count int // Number of aggregated results
+
+	MinTemperature, MaxTemperature, MeanTemperature float64 // Air temperature (celsius)
+	MinTemperatureStation, MaxTemperatureStation    string
+
+	StrongestWind        int64 // Highest wind speed or gust (kts)
+	StrongestWindStation string
+
+	LowestVisibility        Visibility
+	LowestVisibilityStation string
+
+	Categories    map[FlightCategory]int // Number of results per flight category, empty for results without category
+	WorstCategory FlightCategory
+	WorstStation  string
+}
+
+// Aggregate returns the minimum, maximum and mean temperature, the
+// strongest wind, the lowest visibility and the number of results per
+// flight category of the results
+func Aggregate(results []Result) Aggregation {
+	a := Aggregation{
+		Count:      len(results),
+		Categories: make(map[FlightCategory]int),
+	}
+
+	var (
+		tempSum   float64
+		tempCount int
+	)
+
+	for _, r := range results {
+		if r.Present.Has(FieldTemperature) {
+			if a.MinTemperatureStation == "" || r.Temperature < a.MinTemperature {
+				a.MinTemperature, a.MinTemperatureStation = r.Temperature, r.StationID
+			}
+			if a.MaxTemperatureStation == "" || r.Temperature > a.MaxTemperature {
+				a.MaxTemperature, a.MaxTemperatureStation = r.Temperature, r.StationID
+			}
+			tempSum += r.Temperature
+			tempCount++
+		}
+
+		if r.Present.Has(FieldWindSpeed) {
+			wind := r.WindSpeed
+			if r.WindGust > wind {
+				wind = r.WindGust
+			}
+			if a.StrongestWindStation == "" || wind > a.StrongestWind {
+				a.StrongestWind, a.StrongestWindStation = wind, r.StationID
+			}
+		}
+
+		if r.Present.Has(FieldVisibilityStatute) {
+			v := r.Visibility()
+			if a.LowestVisibilityStation == "" || v.Meters() < a.LowestVisibility.Meters() {
+				a.LowestVisibility, a.LowestVisibilityStation = v, r.StationID
+			}
+		}
+
+		if r.FlightCategory != "" {
+			a.Categories[r.FlightCategory]++
+		}
+		if r.FlightCategory.WorseThan(a.WorstCategory) {
+			a.WorstCategory, a.WorstStation = r.FlightCategory, r.StationID
+		}
+	}
+
+	if tempCount > 0 {
+		a.MeanTemperature = tempSum / float64(tempCount)
+	}
+
+	return a
+}
@@ -0,0 +1,57 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Aggregate", func() {
+
+	parse := func(raw string, category FlightCategory) Result {
+		r, err := ParseRaw(raw)
+		Expect(err).NotTo(HaveOccurred())
+		r.FlightCategory = category
+		return *r
+	}
+
+	It("should summarize the results", func() {
+		a := Aggregate([]Result{
+			parse("EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018", FlightCategoryVFR),
+			parse("EDDW 211020Z 27015G28KT 4000 BR BKN008 12/09 Q1018", FlightCategoryIFR),
+			parse("EDDV 211020Z 27020KT 1500 BR OVX003 10/09 Q1018", FlightCategoryLIFR),
+			parse("EDHL 211020Z 27005KT 9999 FEW030 20/09 Q1018", FlightCategoryVFR),
+			{StationID: "EDXX"},
+		})
+
+		Expect(a.Count).To(Equal(5))
+		Expect(a.MinTemperature).To(Equal(10.0))
+		Expect(a.MinTemperatureStation).To(Equal("EDDV"))
+		Expect(a.MaxTemperature).To(Equal(20.0))
+		Expect(a.MaxTemperatureStation).To(Equal("EDHL"))
+		Expect(a.MeanTemperature).To(Equal(14.75))
+
+		Expect(a.StrongestWind).To(Equal(int64(28)))
+		Expect(a.StrongestWindStation).To(Equal("EDDW"))
+
+		Expect(a.LowestVisibility.Meters()).To(Equal(1500.0))
+		Expect(a.LowestVisibilityStation).To(Equal("EDDV"))
+
+		Expect(a.Categories).To(Equal(map[FlightCategory]int{
+			FlightCategoryVFR:  2,
+			FlightCategoryIFR:  1,
+			FlightCategoryLIFR: 1,
+		}))
+		Expect(a.WorstCategory).To(Equal(FlightCategoryLIFR))
+		Expect(a.WorstStation).To(Equal("EDDV"))
+	})
+
+	It("should handle empty sets", func() {
+		a := Aggregate(nil)
+		Expect(a.Count).To(Equal(0))
+		Expect(a.MinTemperatureStation).To(BeEmpty())
+		Expect(a.WorstCategory).To(BeEmpty())
+	})
+
+})
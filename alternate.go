@@ -0,0 +1,85 @@
+package metar
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// AlternateMinima are the weather minima an alternate airport has to meet
+type AlternateMinima struct {
+	CeilingFt    int64   // Lowest acceptable ceiling (feet above ground)
+	VisibilitySM float64 // Lowest acceptable visibility (statute miles)
+}
+
+// StandardAlternateMinima are the standard alternate minima for airports
+// with a precision approach (600 ft ceiling, 2 SM visibility)
+var StandardAlternateMinima = AlternateMinima{CeilingFt: 600, VisibilitySM: 2}
+
+// Alternate is a station meeting the alternate minima
+type Alternate struct {
+	Result     Result
+	DistanceKm float64 // Great circle distance to the destination
+}
+
+// MeetsAlternateMinima reports whether the ceiling and the visibility of
+// the result are at or above the minima. A report without ceiling meets
+// every ceiling minimum, a report without visibility meets none.
+func (r Result) MeetsAlternateMinima(m AlternateMinima) bool {
+	if ceiling, ok := r.Ceiling(); ok && ceiling < m.CeilingFt {
+		return false
+	}
+
+	if !r.Present.Has(FieldVisibilityStatute) {
+		return false
+	}
+	v := r.Visibility()
+	return v.StatuteMiles() >= m.VisibilitySM || v.Modifier == VisibilityGreaterThan
+}
+
+// FindAlternates searches the stations within radiusKm around the
+// destination and returns those currently meeting the minima, nearest
+// first. The search uses an area query and therefore requires a Source
+// supporting them (ADDS).
+func (c *Client) FindAlternates(ctx context.Context, lat, lon, radiusKm float64, m AlternateMinima) ([]Alternate, error) {
+	results, err := c.Fetch(ctx, Query{
+		Selection: MostRecentForEachStation,
+		Area:      boundingBoxAround(lat, lon, radiusKm),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Alternate
+	for _, r := range results {
+		if !r.hasPosition() {
+			continue
+		}
+
+		d := greatCircleKm(lat, lon, r.Latitude, r.Longitude)
+		if d > radiusKm || !r.MeetsAlternateMinima(m) {
+			continue
+		}
+		out = append(out, Alternate{Result: r, DistanceKm: d})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].DistanceKm < out[j].DistanceKm })
+	return out, nil
+}
+
+// boundingBoxAround returns the bounding box containing the circle with
+// the radius (km) around the position
+func boundingBoxAround(lat, lon, radiusKm float64) *BoundingBox {
+	const kmPerDegree = 111.2
+
+	dLat := radiusKm / kmPerDegree
+	dLon := 180.0
+	if c := math.Cos(lat * math.Pi / 180); c > 0.01 {
+		dLon = math.Min(180, dLat/c)
+	}
+
+	return &BoundingBox{
+		MinLat: math.Max(-90, lat-dLat), MinLon: math.Max(-180, lon-dLon),
+		MaxLat: math.Min(90, lat+dLat), MaxLon: math.Min(180, lon+dLon),
+	}
+}
@@ -0,0 +1,65 @@
+package metar_test
+
+import (
+	"context"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// areaSource returns all its results for every query
+type areaSource struct {
+	results []Result
+	queries []Query
+}
+
+func (s *areaSource) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	s.queries = append(s.queries, q)
+	return s.results, nil
+}
+
+var _ = Describe("Alternates", func() {
+
+	parse := func(raw string, lat, lon float64) Result {
+		r, err := ParseRaw(raw)
+		Expect(err).NotTo(HaveOccurred())
+		r.Latitude, r.Longitude = lat, lon
+		r.Present.Add(FieldLatitude)
+		r.Present.Add(FieldLongitude)
+		return *r
+	}
+
+	It("should check the alternate minima", func() {
+		Expect(parse("EDDH 211020Z 27008KT 9999 BKN008 17/09 Q1018", 0, 0).MeetsAlternateMinima(StandardAlternateMinima)).To(BeTrue())
+		Expect(parse("EDDH 211020Z 27008KT 9999 BKN005 17/09 Q1018", 0, 0).MeetsAlternateMinima(StandardAlternateMinima)).To(BeFalse())
+		Expect(parse("EDDH 211020Z 27008KT 2000 BR SCT008 17/09 Q1018", 0, 0).MeetsAlternateMinima(StandardAlternateMinima)).To(BeFalse())
+		Expect(parse("EDDH 211020Z 27008KT CAVOK 17/09 Q1018", 0, 0).MeetsAlternateMinima(StandardAlternateMinima)).To(BeTrue())
+		Expect((Result{}).MeetsAlternateMinima(StandardAlternateMinima)).To(BeFalse())
+	})
+
+	It("should return the nearby stations meeting the minima", func() {
+		src := &areaSource{results: []Result{
+			parse("EDDH 211020Z 27008KT 9999 BKN030 17/09 Q1018", 53.63, 9.99),
+			parse("EDHL 211020Z 27008KT 9999 BKN040 17/09 Q1018", 53.81, 10.72),
+			parse("EDDW 211020Z 27008KT 0800 FG VV002 17/09 Q1018", 53.05, 8.79),
+			parse("EDDV 211020Z 27008KT 9999 FEW030 17/09 Q1018", 52.46, 9.69),
+			{StationID: "XXXX"},
+		}}
+		client := NewClient(WithSource(src))
+
+		alternates, err := client.FindAlternates(context.Background(), 53.63, 9.99, 100, StandardAlternateMinima)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(alternates).To(HaveLen(2))
+		Expect(alternates[0].Result.StationID).To(Equal("EDDH"))
+		Expect(alternates[0].DistanceKm).To(BeNumerically("<", 1))
+		Expect(alternates[1].Result.StationID).To(Equal("EDHL"))
+		Expect(alternates[1].DistanceKm).To(BeNumerically("~", 53, 2))
+
+		Expect(src.queries).To(HaveLen(1))
+		Expect(src.queries[0].Area.MinLat).To(BeNumerically("~", 52.73, 0.01))
+		Expect(src.queries[0].Area.MaxLon).To(BeNumerically("~", 11.5, 0.05))
+	})
+
+})
@@ -0,0 +1,195 @@
+package metar
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	phoneticLetters = []string{
+		"Alpha", "Bravo", "Charlie", "Delta", "Echo", "Foxtrot", "Golf", "Hotel", "India",
+		"Juliett", "Kilo", "Lima", "Mike", "November", "Oscar", "Papa", "Quebec", "Romeo",
+		"Sierra", "Tango", "Uniform", "Victor", "Whiskey", "X-ray", "Yankee", "Zulu",
+	}
+	phoneticDigits = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "niner"}
+
+	atisCloudWords = map[string]string{"FEW": "few", "SCT": "scattered", "BKN": "broken", "OVC": "overcast", "VV": "vertical visibility"}
+)
+
+// PhoneticLetter returns the ICAO spelling alphabet word of the letter,
+// an empty string is returned for other characters
+func PhoneticLetter(c rune) string {
+	c = unicode.ToUpper(c)
+	if c < 'A' || c > 'Z' {
+		return ""
+	}
+	return phoneticLetters[c-'A']
+}
+
+// SpeakDigits spells out the digits and letters of s one by one like
+// controllers do ("240" becomes "two four zero", "1.5" becomes "one
+// decimal five"), other characters are dropped
+func SpeakDigits(s string) string {
+	var words []string
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			words = append(words, phoneticDigits[c-'0'])
+		case c == '.':
+			words = append(words, "decimal")
+		case PhoneticLetter(c) != "":
+			words = append(words, PhoneticLetter(c))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// SpeakHeight spells out a height in feet the way cloud bases are read
+// ("3500" becomes "three thousand five hundred")
+func SpeakHeight(ft int64) string {
+	var words []string
+	if ft >= 1000 {
+		words = append(words, SpeakDigits(strconv.FormatInt(ft/1000, 10)), "thousand")
+	}
+	if h := ft % 1000 / 100; h > 0 || ft < 100 {
+		words = append(words, phoneticDigits[h], "hundred")
+	}
+	return strings.Join(words, " ")
+}
+
+// ATIS renders the result as a speech-oriented readout modelled after the
+// automatic terminal information service, numbers are spelled out for
+// text-to-speech engines:
+//
+//	Echo Delta Delta Hotel information Alpha. Time one zero two zero zulu.
+//	Wind two seven zero degrees, eight knots. ...
+func (r Result) ATIS(information rune) string {
+	info := PhoneticLetter(information)
+	parts := []string{
+		fmt.Sprintf("%s information %s", SpeakDigits(r.StationID), info),
+		fmt.Sprintf("Time %s zulu", SpeakDigits(r.ObservationTime.UTC().Format("1504"))),
+		r.atisWind(),
+	}
+
+	if r.SkyCondition.SkyCover == SkyCoverCAVOK || strings.Contains(r.RawText, " CAVOK") {
+		parts = append(parts, "CAVOK")
+	} else {
+		parts = append(parts, r.atisVisibility())
+		for _, w := range r.Weather() {
+			parts = append(parts, atisWeather(w))
+		}
+		parts = append(parts, r.atisClouds())
+	}
+
+	if r.Present.Has(FieldTemperature) {
+		parts = append(parts, fmt.Sprintf("Temperature %s, dewpoint %s", atisTemperature(r.Temperature), atisTemperature(r.Dewpoint)))
+	}
+
+	if qnh, ok := r.QNH(); ok {
+		if r.Visibility().Unit == VisibilityUnitStatuteMiles {
+			parts = append(parts, "Altimeter "+SpeakDigits(fmt.Sprintf("%04.0f", r.Altimeter*100)))
+		} else {
+			parts = append(parts, "QNH "+SpeakDigits(strconv.Itoa(int(qnh))))
+		}
+	}
+
+	parts = append(parts, fmt.Sprintf("Advise on initial contact you have information %s", info))
+
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ". ") + "."
+}
+
+func (r Result) atisWind() string {
+	switch {
+	case !r.Present.Has(FieldWindSpeed):
+		return ""
+	case r.WindSpeed == 0:
+		return "Wind calm"
+	}
+
+	s := fmt.Sprintf("Wind %s degrees, %s knots", SpeakDigits(fmt.Sprintf("%03d", r.WindDirDegrees)), SpeakDigits(strconv.FormatInt(r.WindSpeed, 10)))
+	if r.WindDirDegrees == 0 {
+		s = fmt.Sprintf("Wind variable, %s knots", SpeakDigits(strconv.FormatInt(r.WindSpeed, 10)))
+	}
+	if r.WindGust > r.WindSpeed {
+		s += fmt.Sprintf(", gusts %s knots", SpeakDigits(strconv.FormatInt(r.WindGust, 10)))
+	}
+	return s
+}
+
+func (r Result) atisVisibility() string {
+	v := r.Visibility()
+	if v.Value == 0 && v.Modifier == VisibilityExact {
+		return ""
+	}
+
+	if v.Unit == VisibilityUnitStatuteMiles {
+		s := "Visibility " + SpeakDigits(strconv.FormatFloat(v.Value, 'f', -1, 64)) + " statute miles"
+		if v.Modifier == VisibilityGreaterThan {
+			s += " or more"
+		}
+		return s
+	}
+
+	switch {
+	case v.Value >= 9999:
+		return "Visibility one zero kilometers or more"
+	case v.Value >= 5000:
+		return "Visibility " + SpeakDigits(strconv.Itoa(int(v.Value/1000))) + " kilometers"
+	default:
+		return "Visibility " + SpeakDigits(strconv.Itoa(int(v.Value))) + " meters"
+	}
+}
+
+// atisWeather names the weather group using the English weather
+// vocabulary of DefaultCatalog
+func atisWeather(w WeatherPhenomenon) string {
+	s := Localizer{Lang: "en"}.Weather(w)
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func (r Result) atisClouds() string {
+	var layers []string
+	for _, l := range r.CloudLayers() {
+		layer := atisCloudWords[l.group()]
+		if l.BaseFt >= 0 {
+			layer += " " + SpeakHeight(l.BaseFt)
+		}
+		switch l.Type {
+		case CloudTypeCumulonimbus:
+			layer += " cumulonimbus"
+		case CloudTypeToweringCumulus:
+			layer += " towering cumulus"
+		}
+		layers = append(layers, layer)
+	}
+
+	if len(layers) == 0 {
+		switch r.SkyCondition.SkyCover {
+		case SkyCoverSKC, SkyCoverCLR:
+			return "Sky clear"
+		case SkyCoverNSC:
+			return "No significant cloud"
+		}
+		return ""
+	}
+
+	s := strings.Join(layers, ", ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func atisTemperature(c float64) string {
+	t := int(math.Round(c))
+	if t < 0 {
+		return "minus " + SpeakDigits(strconv.Itoa(-t))
+	}
+	return SpeakDigits(strconv.Itoa(t))
+}
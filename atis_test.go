@@ -0,0 +1,55 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ATIS", func() {
+
+	DescribeTable("should spell out numbers",
+		func(fn func() string, expected string) {
+			Expect(fn()).To(Equal(expected))
+		},
+		Entry("digits", func() string { return SpeakDigits("240") }, "two four zero"),
+		Entry("decimals", func() string { return SpeakDigits("29.92") }, "two niner decimal niner two"),
+		Entry("letters", func() string { return SpeakDigits("EDDH") }, "Echo Delta Delta Hotel"),
+		Entry("thousands", func() string { return SpeakHeight(3500) }, "three thousand five hundred"),
+		Entry("ten thousands", func() string { return SpeakHeight(12000) }, "one two thousand"),
+		Entry("hundreds", func() string { return SpeakHeight(800) }, "eight hundred"),
+		Entry("letter", func() string { return PhoneticLetter('c') }, "Charlie"),
+	)
+
+	It("should render an international report", func() {
+		r, err := ParseRaw("EDDH 211020Z 27008G20KT 9999 -TSRA FEW030CB BKN080 17/M02 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.ATIS('a')).To(Equal("Echo Delta Delta Hotel information Alpha. " +
+			"Time one zero two zero zulu. " +
+			"Wind two seven zero degrees, eight knots, gusts two zero knots. " +
+			"Visibility one zero kilometers or more. " +
+			"Light thunderstorm rain. " +
+			"Few three thousand cumulonimbus, broken eight thousand. " +
+			"Temperature one seven, dewpoint minus two. " +
+			"QNH one zero one eight. " +
+			"Advise on initial contact you have information Alpha."))
+	})
+
+	It("should render a north american report", func() {
+		r, err := ParseRaw("KJFK 211051Z VRB03KT 10SM CLR 22/12 A2992")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.ATIS('B')).To(Equal("Kilo Juliett Foxtrot Kilo information Bravo. " +
+			"Time one zero five one zulu. " +
+			"Wind variable, three knots. " +
+			"Visibility one zero statute miles. " +
+			"Sky clear. " +
+			"Temperature two two, dewpoint one two. " +
+			"Altimeter two niner niner two. " +
+			"Advise on initial contact you have information Bravo."))
+	})
+
+})
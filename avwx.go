@@ -0,0 +1,225 @@
+package metar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	avwxBaseURL = "https://avwx.rest"
+)
+
+// AVWX is a Source backed by the REST API of avwx.rest. Values are
+// converted into the units of Result using the units metadata of the
+// response and the decimal temperature and dewpoint from the parsed
+// remarks are preferred if available. It only provides the latest report
+// of the requested stations, the time window of queries is ignored.
+type AVWX struct {
+	// Token to authenticate against the API
+	Token string
+	// BaseURL of the API, defaults to avwx.rest
+	BaseURL string
+}
+
+// WithAVWX makes the client use the AVWX API using the given token
+func WithAVWX(token string) ClientOption {
+	return WithSource(AVWX{Token: token})
+}
+
+type avwxNumber struct {
+	Repr  string   `json:"repr"`
+	Value *float64 `json:"value"`
+}
+
+type avwxReport struct {
+	Raw     string `json:"raw"`
+	Station string `json:"station"`
+	Time    struct {
+		DT time.Time `json:"dt"`
+	} `json:"time"`
+	Altimeter     *avwxNumber `json:"altimeter"`
+	Temperature   *avwxNumber `json:"temperature"`
+	Dewpoint      *avwxNumber `json:"dewpoint"`
+	Visibility    *avwxNumber `json:"visibility"`
+	WindDirection *avwxNumber `json:"wind_direction"`
+	WindSpeed     *avwxNumber `json:"wind_speed"`
+	WindGust      *avwxNumber `json:"wind_gust"`
+	FlightRules   string      `json:"flight_rules"`
+	Clouds        []struct {
+		Type string `json:"type"`
+	} `json:"clouds"`
+	WXCodes []struct {
+		Repr string `json:"repr"`
+	} `json:"wx_codes"`
+	RemarksInfo struct {
+		TemperatureDecimal *avwxNumber `json:"temperature_decimal"`
+		DewpointDecimal    *avwxNumber `json:"dewpoint_decimal"`
+		SeaLevelPressure   *avwxNumber `json:"sea_level_pressure"`
+	} `json:"remarks_info"`
+	Units struct {
+		Altimeter   string `json:"altimeter"`
+		Temperature string `json:"temperature"`
+		Visibility  string `json:"visibility"`
+		WindSpeed   string `json:"wind_speed"`
+	} `json:"units"`
+	Info *struct {
+		Latitude   float64 `json:"latitude"`
+		Longitude  float64 `json:"longitude"`
+		ElevationM float64 `json:"elevation_m"`
+	} `json:"info"`
+}
+
+// Fetch retrieves the latest report of each requested station
+func (a AVWX) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	base := a.BaseURL
+	if base == "" {
+		base = avwxBaseURL
+	}
+
+	var results []Result
+	for _, station := range q.Stations {
+		u := fmt.Sprintf("%s/api/metar/%s?options=info&format=json", base, url.PathEscape(station))
+		res, err := get(ctx, u, http.Header{"Authorization": {"BEARER " + a.Token}})
+		if err != nil {
+			return nil, err
+		}
+
+		rep := avwxReport{}
+		switch res.StatusCode {
+		case http.StatusOK:
+			err = json.NewDecoder(res.Body).Decode(&rep)
+		case http.StatusNoContent, http.StatusNotFound:
+			res.Body.Close()
+			continue
+		default:
+			err = fmt.Errorf("AVWX API returned status %d", res.StatusCode)
+		}
+		res.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+		r := []Result{rep.result()}
+		setOrigin(r, res)
+		results = append(results, r...)
+	}
+
+	return selectReports(results, q.Selection), nil
+}
+
+func (a avwxReport) result() Result {
+	r := Result{
+		RawText:         a.Raw,
+		StationID:       a.Station,
+		ObservationTime: a.Time.DT,
+		FlightCategory:  FlightCategory(a.FlightRules),
+		MetarType:       "METAR",
+	}
+	for _, f := range []Field{FieldRawText, FieldStationID, FieldObservationTime, FieldMetarType} {
+		r.Present.Add(f)
+	}
+
+	if a.FlightRules != "" {
+		r.Present.Add(FieldFlightCategory)
+	}
+
+	if a.Info != nil {
+		r.Latitude, r.Longitude, r.Elevation = a.Info.Latitude, a.Info.Longitude, a.Info.ElevationM
+		r.Present.Add(FieldLatitude)
+		r.Present.Add(FieldLongitude)
+		r.Present.Add(FieldElevation)
+	}
+
+	temperature := func(n *avwxNumber) float64 {
+		if strings.EqualFold(a.Units.Temperature, "F") {
+			return FahrenheitToCelsius(*n.Value)
+		}
+		return *n.Value
+	}
+
+	switch {
+	case a.RemarksInfo.TemperatureDecimal.valid():
+		r.Temperature = temperature(a.RemarksInfo.TemperatureDecimal)
+		r.Present.Add(FieldTemperature)
+	case a.Temperature.valid():
+		r.Temperature = temperature(a.Temperature)
+		r.Present.Add(FieldTemperature)
+	}
+
+	switch {
+	case a.RemarksInfo.DewpointDecimal.valid():
+		r.Dewpoint = temperature(a.RemarksInfo.DewpointDecimal)
+		r.Present.Add(FieldDewpoint)
+	case a.Dewpoint.valid():
+		r.Dewpoint = temperature(a.Dewpoint)
+		r.Present.Add(FieldDewpoint)
+	}
+
+	if a.Altimeter.valid() {
+		r.Altimeter = *a.Altimeter.Value
+		if strings.EqualFold(a.Units.Altimeter, "hPa") {
+			r.Altimeter = HPaToInHg(r.Altimeter)
+		}
+		r.Present.Add(FieldAltimeter)
+	}
+
+	if a.RemarksInfo.SeaLevelPressure.valid() {
+		r.SeaLevelPressure = *a.RemarksInfo.SeaLevelPressure.Value
+		r.Present.Add(FieldSeaLevelPressure)
+	}
+
+	if a.Visibility.valid() {
+		r.VisibilityStatute = *a.Visibility.Value
+		if a.Units.Visibility == "m" {
+			r.VisibilityStatute = r.VisibilityStatute / 1000 / StatMileToKm(1)
+		}
+		r.Present.Add(FieldVisibilityStatute)
+	}
+
+	wind := func(n *avwxNumber) int64 {
+		v := *n.Value
+		if a.Units.WindSpeed == "m/s" {
+			v = v / KtsToMs(1)
+		}
+		return int64(v + 0.5)
+	}
+
+	if a.WindDirection.valid() {
+		r.WindDirDegrees = int64(*a.WindDirection.Value)
+		r.Present.Add(FieldWindDirDegrees)
+	}
+	if a.WindSpeed.valid() {
+		r.WindSpeed = wind(a.WindSpeed)
+		r.Present.Add(FieldWindSpeed)
+	}
+	if a.WindGust.valid() {
+		r.WindGust = wind(a.WindGust)
+		r.Present.Add(FieldWindGust)
+	}
+
+	if len(a.Clouds) > 0 {
+		r.SkyCondition.SkyCover = SkyCover(a.Clouds[len(a.Clouds)-1].Type)
+		r.Present.Add(FieldSkyCondition)
+	}
+
+	if len(a.WXCodes) > 0 {
+		codes := make([]string, len(a.WXCodes))
+		for i, c := range a.WXCodes {
+			codes[i] = c.Repr
+		}
+		r.WXString = strings.Join(codes, " ")
+		r.Present.Add(FieldWXString)
+	}
+
+	setReportMarkers(&r)
+	return r
+}
+
+func (n *avwxNumber) valid() bool {
+	return n != nil && n.Value != nil
+}
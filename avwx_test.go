@@ -0,0 +1,68 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AVWX", func() {
+	var (
+		auth   string
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			auth = r.Header.Get("Authorization")
+			if r.URL.Path != "/api/metar/EDDH" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			http.ServeFile(w, r, filepath.Join("testdata", "avwx.json"))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should convert the report into the units of Result", func() {
+		client := NewClient(WithSource(AVWX{Token: "secret", BaseURL: server.URL}))
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth).To(Equal("BEARER secret"))
+
+		Expect(r.StationID).To(Equal("EDDH"))
+		Expect(r.ObservationTime).To(Equal(time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)))
+		Expect(r.Latitude).To(Equal(53.63))
+		Expect(r.Elevation).To(Equal(15.0))
+		Expect(r.Temperature).To(Equal(17.2))
+		Expect(r.Dewpoint).To(Equal(8.8))
+		Expect(r.Altimeter).To(BeNumerically("~", 30.06, 0.01))
+		Expect(r.VisibilityStatute).To(BeNumerically("~", 6.21, 0.01))
+		Expect(r.WindSpeed).To(Equal(int64(8)))
+		Expect(r.WindDirDegrees).To(Equal(int64(270)))
+		Expect(r.Present.Has(FieldWindGust)).To(BeFalse())
+		Expect(r.WXString).To(Equal("-RA"))
+		Expect(r.SkyCondition.SkyCover).To(Equal(SkyCoverFEW))
+		Expect(r.FlightCategory).To(Equal(FlightCategoryVFR))
+	})
+
+	It("should skip stations without reports", func() {
+		results, err := AVWX{BaseURL: server.URL}.Fetch(context.Background(), Query{
+			Stations:  []string{"XXXX", "EDDH"},
+			Selection: MostRecentForEachStation,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+	})
+
+})
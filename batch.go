@@ -0,0 +1,115 @@
+package metar
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// batchConcurrency limits the concurrent single station requests made
+// when a batch request failed
+const batchConcurrency = 4
+
+// BatchResult is the outcome of a multi-station fetch which may have
+// partially failed
+type BatchResult struct {
+	Results []Result
+	Errors  map[string]error // Errors by upper-case station identifier
+}
+
+// Failed returns the stations which could not be fetched, sorted
+func (b *BatchResult) Failed() []string {
+	out := make([]string, 0, len(b.Errors))
+	for st := range b.Errors {
+		out = append(out, st)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// FetchStationsBatch fetches the last result of every station like
+// FetchStationsWeather but degrades gracefully: stations without a
+// report get ErrNoResults and if the combined request fails every
+// station is fetched on its own so one failing station does not fail
+// the others.
+func (c *Client) FetchStationsBatch(ctx context.Context, stations []string) *BatchResult {
+	b := &BatchResult{Errors: make(map[string]error)}
+
+	results, err := c.FetchStationsWeather(ctx, stations)
+	switch {
+	case err == nil:
+		b.Results = results
+		found := make(map[string]bool, len(results))
+		for _, r := range results {
+			found[strings.ToUpper(r.StationID)] = true
+		}
+		for _, st := range stations {
+			if !found[c.stationKey(st)] {
+				b.Errors[strings.ToUpper(st)] = ErrNoResults
+			}
+		}
+
+	case len(stations) == 1 || ctx.Err() != nil:
+		for _, st := range stations {
+			b.Errors[strings.ToUpper(st)] = err
+		}
+
+	default:
+		c.logger.Warn("batch request failed, fetching stations one by one", "stations", stations, "error", err)
+		c.fetchEach(ctx, stations, b)
+	}
+
+	return b
+}
+
+// RetryFailed fetches the failed stations of the batch again and returns
+// the batch with the results of the retry merged in
+func (c *Client) RetryFailed(ctx context.Context, b *BatchResult) *BatchResult {
+	if len(b.Errors) == 0 {
+		return b
+	}
+
+	retry := c.FetchStationsBatch(ctx, b.Failed())
+	return &BatchResult{
+		Results: append(append([]Result(nil), b.Results...), retry.Results...),
+		Errors:  retry.Errors,
+	}
+}
+
+// fetchEach fetches every station with a request of its own
+func (c *Client) fetchEach(ctx context.Context, stations []string, b *BatchResult) {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, batchConcurrency)
+	)
+
+	for _, st := range stations {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(st string) {
+			defer func() { <-sem; wg.Done() }()
+
+			r, err := c.FetchCurrentStationWeather(ctx, st)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				b.Errors[strings.ToUpper(st)] = err
+				return
+			}
+			b.Results = append(b.Results, *r)
+		}(st)
+	}
+	wg.Wait()
+
+	// Keep the order of the station list
+	index := make(map[string]int, len(stations))
+	for i, st := range stations {
+		index[c.stationKey(st)] = i
+	}
+	sort.SliceStable(b.Results, func(i, j int) bool {
+		return index[strings.ToUpper(b.Results[i].StationID)] < index[strings.ToUpper(b.Results[j].StationID)]
+	})
+}
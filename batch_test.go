@@ -0,0 +1,78 @@
+package metar_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// flakySource fails all requests containing a failing station
+type flakySource struct {
+	staticSource
+	failing map[string]bool
+}
+
+func (s *flakySource) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	for _, st := range q.Stations {
+		if s.failing[strings.ToUpper(st)] {
+			s.queries = append(s.queries, q)
+			return nil, errors.New("Upstream error")
+		}
+	}
+	return s.staticSource.Fetch(ctx, q)
+}
+
+var _ = Describe("Batch fetches", func() {
+
+	var src *flakySource
+
+	BeforeEach(func() {
+		src = &flakySource{
+			staticSource: staticSource{name: "flaky", results: []Result{
+				{StationID: "EDDH"}, {StationID: "EDDW"}, {StationID: "EDDF"},
+			}},
+			failing: map[string]bool{},
+		}
+	})
+
+	It("should report stations without results", func() {
+		b := NewClient(WithSource(src)).FetchStationsBatch(context.Background(), []string{"EDDH", "EDDW", "EDDX"})
+		Expect(b.Results).To(HaveLen(2))
+		Expect(b.Failed()).To(Equal([]string{"EDDX"}))
+		Expect(b.Errors["EDDX"]).To(Equal(ErrNoResults))
+		Expect(src.queries).To(HaveLen(1))
+	})
+
+	It("should isolate failing stations", func() {
+		src.failing["EDDW"] = true
+		b := NewClient(WithSource(src)).FetchStationsBatch(context.Background(), []string{"EDDH", "EDDW", "EDDF"})
+
+		Expect(b.Results).To(HaveLen(2))
+		Expect(b.Results[0].StationID).To(Equal("EDDH"))
+		Expect(b.Results[1].StationID).To(Equal("EDDF"))
+		Expect(b.Failed()).To(Equal([]string{"EDDW"}))
+		Expect(b.Errors["EDDW"]).To(MatchError("Upstream error"))
+	})
+
+	It("should retry only the failed stations", func() {
+		src.failing["EDDW"] = true
+		client := NewClient(WithSource(src))
+		b := client.FetchStationsBatch(context.Background(), []string{"EDDH", "EDDW"})
+		Expect(b.Failed()).To(Equal([]string{"EDDW"}))
+
+		src.failing["EDDW"] = false
+		src.queries = nil
+		b = client.RetryFailed(context.Background(), b)
+
+		Expect(b.Failed()).To(BeEmpty())
+		Expect(b.Results).To(HaveLen(2))
+		Expect(src.queries).To(HaveLen(1))
+		Expect(src.queries[0].Stations).To(Equal([]string{"EDDW"}))
+	})
+
+})
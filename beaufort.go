@@ -0,0 +1,38 @@
+package metar
+
+var bftDescriptions = map[string][13]string{
+	"en": {
+		"Calm", "Light air", "Light breeze", "Gentle breeze", "Moderate breeze",
+		"Fresh breeze", "Strong breeze", "Near gale", "Gale", "Strong gale",
+		"Storm", "Violent storm", "Hurricane force",
+	},
+	"de": {
+		"Windstille", "Leiser Zug", "Leichte Brise", "Schwache Brise", "Mäßige Brise",
+		"Frische Brise", "Starker Wind", "Steifer Wind", "Stürmischer Wind", "Sturm",
+		"Schwerer Sturm", "Orkanartiger Sturm", "Orkan",
+	},
+	"fr": {
+		"Calme", "Très légère brise", "Légère brise", "Petite brise", "Jolie brise",
+		"Bonne brise", "Vent frais", "Grand frais", "Coup de vent", "Fort coup de vent",
+		"Tempête", "Violente tempête", "Ouragan",
+	},
+	"es": {
+		"Calma", "Ventolina", "Flojito", "Flojo", "Bonancible",
+		"Fresquito", "Fresco", "Frescachón", "Temporal", "Temporal fuerte",
+		"Temporal duro", "Temporal muy duro", "Temporal huracanado",
+	},
+}
+
+// BftDescription returns the standard descriptive term for a Beaufort
+// force or an empty string for values outside the scale
+func BftDescription(bft int) string {
+	return LocalizedBftDescription(bft, "en")
+}
+
+// LocalizedBftDescription returns the descriptive term for a Beaufort
+// force in the given language ("en", "de", "fr", "es"), unknown languages
+// fall back to English. Use a Localizer to read the terms from a custom
+// Catalog.
+func LocalizedBftDescription(bft int, lang string) string {
+	return Localizer{Lang: lang}.Bft(bft)
+}
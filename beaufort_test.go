@@ -0,0 +1,28 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Beaufort", func() {
+
+	It("should describe Beaufort forces", func() {
+		Expect(BftDescription(0)).To(Equal("Calm"))
+		Expect(BftDescription(KtsToBft(18))).To(Equal("Fresh breeze"))
+		Expect(BftDescription(8)).To(Equal("Gale"))
+		Expect(BftDescription(12)).To(Equal("Hurricane force"))
+		Expect(BftDescription(13)).To(BeEmpty())
+		Expect(BftDescription(-1)).To(BeEmpty())
+	})
+
+	It("should describe Beaufort forces localized", func() {
+		Expect(LocalizedBftDescription(8, "de")).To(Equal("Stürmischer Wind"))
+		Expect(LocalizedBftDescription(10, "fr")).To(Equal("Tempête"))
+		Expect(LocalizedBftDescription(6, "es")).To(Equal("Fresco"))
+		Expect(LocalizedBftDescription(8, "xx")).To(Equal("Gale"))
+	})
+
+})
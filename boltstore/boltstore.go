@@ -0,0 +1,168 @@
+// Package boltstore persists observations into a bbolt database file so
+// long running collectors can keep a history without an external database.
+//
+//	store, err := boltstore.Open("metar.db")
+//	if err != nil { ... }
+//	defer store.Close()
+//
+//	results, _ := client.FetchStationsWeather(ctx, stations)
+//	err = store.Write(ctx, results)
+package boltstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+var rootBucket = []byte("observations")
+
+// Store keeps observations in one bucket per station keyed by their
+// observation time
+type Store struct {
+	db *bolt.DB
+}
+
+var _ metar.Sink = (*Store)(nil)
+
+// Open opens or creates the database file at path
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// New creates a Store using an already opened database
+func New(db *bolt.DB) (*Store, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(rootBucket)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Write stores the results, replacing stored observations of the same
+// station and observation time
+func (s *Store) Write(ctx context.Context, results []metar.Result) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(rootBucket)
+		for _, r := range results {
+			b, err := root.CreateBucketIfNotExists([]byte(strings.ToUpper(r.StationID)))
+			if err != nil {
+				return err
+			}
+
+			v, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+
+			if err := b.Put(timeKey(r.ObservationTime), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Query returns the observations of the station within start (inclusive)
+// and end (exclusive) ordered by observation time. A zero start returns
+// all observations before end, a zero end all observations after start.
+func (s *Store) Query(ctx context.Context, station string, start, end time.Time) ([]metar.Result, error) {
+	var results []metar.Result
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(rootBucket).Bucket([]byte(strings.ToUpper(station)))
+		if b == nil {
+			return nil
+		}
+
+		var (
+			c    = b.Cursor()
+			k, v []byte
+		)
+		if start.IsZero() {
+			k, v = c.First()
+		} else {
+			k, v = c.Seek(timeKey(start))
+		}
+
+		for ; k != nil; k, v = c.Next() {
+			if !end.IsZero() && bytes.Compare(k, timeKey(end)) >= 0 {
+				break
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			r := metar.Result{}
+			if err := json.Unmarshal(v, &r); err != nil {
+				return err
+			}
+			results = append(results, r)
+		}
+		return nil
+	})
+	return results, err
+}
+
+// Latest returns the newest stored observation of the station or
+// metar.ErrNoResults if none is stored
+func (s *Store) Latest(ctx context.Context, station string) (*metar.Result, error) {
+	var r *metar.Result
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(rootBucket).Bucket([]byte(strings.ToUpper(station)))
+		if b == nil {
+			return nil
+		}
+
+		_, v := b.Cursor().Last()
+		if v == nil {
+			return nil
+		}
+
+		r = &metar.Result{}
+		return json.Unmarshal(v, r)
+	})
+
+	switch {
+	case err != nil:
+		return nil, err
+	case r == nil:
+		return nil, metar.ErrNoResults
+	}
+	return r, nil
+}
+
+// timeKey encodes the time as big endian unix seconds with flipped sign
+// bit followed by the nanoseconds to keep the keys ordered by time, also
+// for times before 1970 and outside the range of UnixNano
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 12)
+	binary.BigEndian.PutUint64(k, uint64(t.Unix())^(1<<63))
+	binary.BigEndian.PutUint32(k[8:], uint32(t.Nanosecond()))
+	return k
+}
@@ -0,0 +1,99 @@
+package boltstore_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	metar "github.com/Luzifer/go-metar"
+	. "github.com/Luzifer/go-metar/boltstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		ctx   = context.Background()
+		dir   string
+		store *Store
+		base  = time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "boltstore")
+		Expect(err).NotTo(HaveOccurred())
+
+		store, err = Open(filepath.Join(dir, "metar.db"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(store.Write(ctx, []metar.Result{
+			{StationID: "EDDH", ObservationTime: base.Add(time.Hour), Temperature: 17},
+			{StationID: "EDDH", ObservationTime: base, Temperature: 16},
+			{StationID: "eddh", ObservationTime: base.Add(30 * time.Minute), Temperature: 16.5},
+			{StationID: "EDDW", ObservationTime: base, Temperature: 12},
+			{StationID: "EDDH", ObservationTime: time.Date(1969, 7, 20, 20, 17, 0, 0, time.UTC), Temperature: 14},
+		})).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(store.Close()).To(Succeed())
+		os.RemoveAll(dir)
+	})
+
+	temperatures := func(results []metar.Result) []float64 {
+		var out []float64
+		for _, r := range results {
+			out = append(out, r.Temperature)
+		}
+		return out
+	}
+
+	It("should return all observations in time order for a zero range", func() {
+		results, err := store.Query(ctx, "eddh", time.Time{}, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(temperatures(results)).To(Equal([]float64{14, 16, 16.5, 17}))
+	})
+
+	It("should include start and exclude end", func() {
+		results, err := store.Query(ctx, "EDDH", base, base.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(temperatures(results)).To(Equal([]float64{16, 16.5}))
+	})
+
+	It("should query open ranges", func() {
+		results, err := store.Query(ctx, "EDDH", base.Add(time.Minute), time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(temperatures(results)).To(Equal([]float64{16.5, 17}))
+
+		results, err = store.Query(ctx, "EDDH", time.Time{}, base)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(temperatures(results)).To(Equal([]float64{14}))
+	})
+
+	It("should replace observations of the same time", func() {
+		Expect(store.Write(ctx, []metar.Result{{StationID: "EDDW", ObservationTime: base, Temperature: 13}})).To(Succeed())
+
+		results, err := store.Query(ctx, "EDDW", time.Time{}, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(temperatures(results)).To(Equal([]float64{13}))
+	})
+
+	It("should return no results for unknown stations", func() {
+		results, err := store.Query(ctx, "EDDF", time.Time{}, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+
+		_, err = store.Latest(ctx, "EDDF")
+		Expect(err).To(Equal(metar.ErrNoResults))
+	})
+
+	It("should return the latest observation", func() {
+		r, err := store.Latest(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Temperature).To(Equal(17.0))
+	})
+
+})
@@ -0,0 +1,103 @@
+package metar
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DefaultBriefingMaxAge is the age after which the observation of a
+// briefing is considered stale if the Client has no maximum age set
+// (hourly reports plus a grace period for delayed reports)
+const DefaultBriefingMaxAge = 90 * time.Minute
+
+// StationBriefing combines everything known about the current conditions
+// at a station. It contains no TAF period: the package neither fetches
+// nor decodes TAFs, the trend forecasts of the METAR are the only
+// forecast part.
+type StationBriefing struct {
+	Station string
+	Current Result
+
+	Trends []string // Trend forecasts of the report ("NOSIG", "TEMPO 3000 SHRA")
+
+	PressureTendency    PressureTendency // Three hour pressure tendency reported in the remarks
+	HasPressureTendency bool
+
+	Quality BriefingQuality
+}
+
+// BriefingQuality assesses how far the observation of a briefing can be
+// relied on
+type BriefingQuality struct {
+	Age                 time.Duration
+	Stale               bool // Age exceeds the maximum age
+	Automated           bool // Report generated without human intervention
+	SensorType          SensorType
+	Corrected           bool
+	MaintenanceRequired bool    // Station reported the maintenance indicator ($)
+	Issues              []Issue // Problems found validating the raw report
+}
+
+// FetchStationBriefing fetches the current observation of the station and
+// assesses it. Unlike FetchCurrentStationWeather a stale observation is
+// not an error but reported in the quality assessment. The active TAF
+// period is not part of the briefing as TAFs are not supported, combine
+// it with a TAF source of your own if needed.
+func (c *Client) FetchStationBriefing(ctx context.Context, station string) (*StationBriefing, error) {
+	r, err := c.fetchCurrentStationWeather(ctx, station)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAge := c.maxObservationAge
+	if maxAge <= 0 {
+		maxAge = DefaultBriefingMaxAge
+	}
+
+	b := &StationBriefing{
+		Station: r.StationID,
+		Current: *r,
+		Trends:  r.Trends(),
+		Quality: BriefingQuality{
+			Age:                 r.Age(),
+			Stale:               r.IsStale(maxAge),
+			Automated:           r.Automated || r.QualityControlFlags.Auto,
+			SensorType:          r.SensorType,
+			Corrected:           r.Correction != "" || r.QualityControlFlags.Corrected,
+			MaintenanceRequired: r.QualityControlFlags.MaintenanceIndicator || strings.HasSuffix(strings.TrimSpace(r.RawText), "$"),
+			Issues:              Validate(r.RawText),
+		},
+	}
+
+	if change, _, ok := r.PressureChange(); ok {
+		b.PressureTendency = ClassifyPressureTendency(change)
+		b.HasPressureTendency = true
+	}
+
+	return b, nil
+}
+
+// Trends returns the trend forecasts of the raw report, every BECMG or
+// TEMPO group starts a new trend
+func (r Result) Trends() []string {
+	var trends []string
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind != TokenTrend {
+			continue
+		}
+
+		var current []string
+		for _, g := range strings.Fields(t.Text) {
+			if (g == "BECMG" || g == "TEMPO" || g == "NOSIG") && len(current) > 0 {
+				trends = append(trends, strings.Join(current, " "))
+				current = nil
+			}
+			current = append(current, g)
+		}
+		if len(current) > 0 {
+			trends = append(trends, strings.Join(current, " "))
+		}
+	}
+	return trends
+}
@@ -0,0 +1,50 @@
+package metar_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Station briefing", func() {
+
+	It("should split the trend forecasts", func() {
+		r := Result{RawText: "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 BECMG 25015KT TEMPO 3000 SHRA RMK TEST"}
+		Expect(r.Trends()).To(Equal([]string{"BECMG 25015KT", "TEMPO 3000 SHRA"}))
+		Expect((Result{RawText: "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG"}).Trends()).To(Equal([]string{"NOSIG"}))
+	})
+
+	It("should combine the observation and its assessment", func() {
+		r, err := ParseRaw("KJFK 211051Z AUTO 27008KT 10SM FEW030 22/12 A2992 RMK AO2 52015 $")
+		Expect(err).NotTo(HaveOccurred())
+		r.ObservationTime = time.Now().Add(-2 * time.Hour)
+
+		client := NewClient(WithSource(&staticSource{name: "static", results: []Result{*r}}))
+		b, err := client.FetchStationBriefing(context.Background(), "KJFK")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(b.Station).To(Equal("KJFK"))
+		Expect(b.Current.WindSpeed).To(Equal(int64(8)))
+		Expect(b.Trends).To(BeEmpty())
+		Expect(b.HasPressureTendency).To(BeTrue())
+		Expect(b.PressureTendency).To(Equal(PressureRisingSlowly))
+
+		Expect(b.Quality.Stale).To(BeTrue())
+		Expect(b.Quality.Age).To(BeNumerically(">=", 2*time.Hour))
+		Expect(b.Quality.Automated).To(BeTrue())
+		Expect(b.Quality.SensorType).To(Equal(SensorTypeAO2))
+		Expect(b.Quality.Corrected).To(BeFalse())
+		Expect(b.Quality.MaintenanceRequired).To(BeTrue())
+	})
+
+	It("should fail for stations without report", func() {
+		client := NewClient(WithSource(&staticSource{name: "static"}))
+		_, err := client.FetchStationBriefing(context.Background(), "KJFK")
+		Expect(err).To(Equal(ErrNoResults))
+	})
+
+})
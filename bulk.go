@@ -0,0 +1,120 @@
+package metar
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// noaaTimeLayout is the format of the issue time preceding the reports in
+// NOAA station and cycle files
+const noaaTimeLayout = "2006/01/02 15:04"
+
+// ParsedReport is a raw report decoded by ParseBulk
+type ParsedReport struct {
+	Line   int     // Line number of the report within the input
+	Raw    string  // Raw report as read from the input
+	Result *Result // Decoded report, nil if Err is set
+	Err    error   // Parse error of the report or read error of the input
+}
+
+type bulkJob struct {
+	line int
+	raw  string
+	ref  time.Time
+}
+
+// ParseBulk reads newline separated raw METARs and decodes them using the
+// given number of workers (defaults to the number of CPUs). Lines holding
+// an issue time as used by NOAA files ("2016/05/21 10:20") are used as
+// Reference for the following report. Reports are delivered in no
+// particular order, a read error of the input is delivered as a last
+// ParsedReport without Raw. The channel is closed when the input is
+// consumed or the context is cancelled.
+func (p Parser) ParseBulk(ctx context.Context, r io.Reader, workers int) <-chan ParsedReport {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	var (
+		jobs = make(chan bulkJob, workers*4)
+		out  = make(chan ParsedReport, workers*4)
+		wg   sync.WaitGroup
+	)
+
+	send := func(pr ParsedReport) bool {
+		select {
+		case out <- pr:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				parser := p
+				if !j.ref.IsZero() {
+					parser.Reference = j.ref
+				}
+
+				res, err := parser.Parse(j.raw)
+				if !send(ParsedReport{Line: j.line, Raw: j.raw, Result: res, Err: err}) {
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		line, err := feedBulk(ctx, r, jobs)
+		close(jobs)
+		wg.Wait()
+
+		if err != nil {
+			send(ParsedReport{Line: line, Err: err})
+		}
+		close(out)
+	}()
+
+	return out
+}
+
+// feedBulk reads the reports from the input into the jobs and returns the
+// line a read error occurred at
+func feedBulk(ctx context.Context, r io.Reader, jobs chan<- bulkJob) (int, error) {
+	var (
+		s    = bufio.NewScanner(r)
+		line int
+		ref  time.Time
+	)
+
+	for s.Scan() {
+		line++
+
+		raw := strings.TrimSpace(s.Text())
+		if raw == "" {
+			continue
+		}
+		if t, err := time.Parse(noaaTimeLayout, raw); err == nil {
+			ref = t
+			continue
+		}
+
+		select {
+		case jobs <- bulkJob{line: line, raw: raw, ref: ref}:
+		case <-ctx.Done():
+			return line, nil
+		}
+		ref = time.Time{}
+	}
+
+	return line + 1, s.Err()
+}
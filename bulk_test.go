@@ -0,0 +1,108 @@
+package metar_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const bulkInput = `2016/05/21 10:20
+EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG
+
+EDDW 211020Z 26010KT CAVOK 18/08 Q1017
+KBOS 2107Z
+KBOS 210754Z 19012KT 10SM BKN040 12/10 A2999 RMK AO2 SLP155
+`
+
+var _ = Describe("Bulk parsing", func() {
+
+	It("should parse all reports of the input", func() {
+		var reports []ParsedReport
+		for pr := range (Parser{Reference: time.Date(2016, 5, 22, 0, 0, 0, 0, time.UTC)}).ParseBulk(context.Background(), strings.NewReader(bulkInput), 2) {
+			reports = append(reports, pr)
+		}
+		sort.Slice(reports, func(i, j int) bool { return reports[i].Line < reports[j].Line })
+
+		Expect(reports).To(HaveLen(4))
+
+		Expect(reports[0].Line).To(Equal(2))
+		Expect(reports[0].Err).NotTo(HaveOccurred())
+		Expect(reports[0].Result.StationID).To(Equal("EDDH"))
+		Expect(reports[0].Result.ObservationTime).To(Equal(time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)))
+
+		Expect(reports[1].Result.StationID).To(Equal("EDDW"))
+
+		Expect(reports[2].Line).To(Equal(5))
+		Expect(reports[2].Err).To(HaveOccurred())
+		Expect(reports[2].Raw).To(Equal("KBOS 2107Z"))
+
+		Expect(reports[3].Result.StationID).To(Equal("KBOS"))
+	})
+
+	It("should stop when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		input := strings.Repeat("EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018\n", 1000)
+		var n int
+		for range (Parser{}).ParseBulk(ctx, strings.NewReader(input), 2) {
+			n++
+		}
+		Expect(n).To(BeNumerically("<", 1000))
+	})
+
+})
+
+func BenchmarkParseBulk(b *testing.B) {
+	input := strings.Repeat("EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG\nKBOS 210754Z 19012KT 10SM BKN040 12/10 A2999 RMK AO2 SLP155\n", 500)
+	p := Parser{Reference: time.Date(2016, 5, 22, 0, 0, 0, 0, time.UTC)}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for pr := range p.ParseBulk(context.Background(), strings.NewReader(input), 0) {
+			if pr.Err != nil {
+				b.Fatal(pr.Err)
+			}
+		}
+	}
+}
+
+// benchCycle returns a cycle file with n reports
+func benchCycle(n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "2016/05/21 10:%02d\n%s\n\n", i%60, benchReports[i%len(benchReports)])
+	}
+	return sb.String()
+}
+
+func BenchmarkParseBulk10k(b *testing.B) {
+	cycle := benchCycle(10000)
+	b.SetBytes(int64(len(cycle)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for pr := range (Parser{}).ParseBulk(context.Background(), strings.NewReader(cycle), 0) {
+			if pr.Err != nil {
+				b.Fatal(pr.Err)
+			}
+			n++
+		}
+		if n != 10000 {
+			b.Fatalf("parsed %d reports", n)
+		}
+	}
+}
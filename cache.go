@@ -0,0 +1,75 @@
+package metar
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cache stores the last known results of the stations. Values are the
+// JSON representation of the results (see MarshalJSON) so caches shared
+// by multiple instances (Redis, memcached) can hold them. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for the key, ok is false if the key is
+	// unknown or expired
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set stores the value for the key, a ttl of zero keeps it until it
+	// is replaced
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// WithCache sets the Cache holding the last known results used for stale
+// while revalidate and the circuit breaker fallback (defaults to a
+// MemoryCache). Results expire after ttl, zero keeps them until replaced.
+func WithCache(cache Cache, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.store.cache = cache
+		c.store.ttl = ttl
+	}
+}
+
+// MemoryCache is a Cache keeping the values in process memory
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache creates an empty MemoryCache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+// Get implements Cache
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+// Set implements Cache
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := memoryCacheEntry{value: value}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+	m.entries[key] = e
+	return nil
+}
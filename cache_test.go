@@ -0,0 +1,92 @@
+package metar_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingCache wraps a MemoryCache recording the used TTLs
+type recordingCache struct {
+	*MemoryCache
+	ttls []time.Duration
+	err  error
+}
+
+func (c *recordingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if c.err != nil {
+		return nil, false, c.err
+	}
+	return c.MemoryCache.Get(ctx, key)
+}
+
+func (c *recordingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.ttls = append(c.ttls, ttl)
+	return c.MemoryCache.Set(ctx, key, value, ttl)
+}
+
+var _ = Describe("Cache", func() {
+
+	It("should expire values of the MemoryCache", func() {
+		c := NewMemoryCache()
+		Expect(c.Set(context.Background(), "a", []byte("1"), 20*time.Millisecond)).To(Succeed())
+		Expect(c.Set(context.Background(), "b", []byte("2"), 0)).To(Succeed())
+
+		v, ok, err := c.Get(context.Background(), "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal([]byte("1")))
+
+		time.Sleep(30 * time.Millisecond)
+		_, ok, _ = c.Get(context.Background(), "a")
+		Expect(ok).To(BeFalse())
+		_, ok, _ = c.Get(context.Background(), "b")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should keep the results in the configured cache", func() {
+		cache := &recordingCache{MemoryCache: NewMemoryCache()}
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithStaleWhileRevalidate(time.Minute),
+			WithCache(cache, time.Hour),
+		)
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cache.ttls).To(Equal([]time.Duration{time.Hour}))
+
+		v, ok, err := cache.Get(context.Background(), "metar:EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(string(v)).To(ContainSubstring(`"station_id":"EDDH"`))
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.FromCache).To(BeTrue())
+	})
+
+	It("should fetch from upstream when the cache fails", func() {
+		cache := &recordingCache{MemoryCache: NewMemoryCache(), err: errors.New("connection refused")}
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithStaleWhileRevalidate(time.Minute),
+			WithCache(cache, 0),
+		)
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.FromCache).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,17 @@
+package metar
+
+// Ceiling returns the height in feet above ground of the lowest broken or
+// overcast layer or of the vertical visibility into an obscured sky (OVX).
+// Layers are taken from the raw report, VerticalVisibilityFt is used if
+// the sky is obscured and the raw report does not contain the layer.
+func (r Result) Ceiling() (int64, bool) {
+	ceiling := lowestBase(r.CloudLayers(), func(l CloudLayer) bool {
+		return l.Cover == SkyCoverBKN || l.Cover == SkyCoverOVC || l.Cover == SkyCoverOVX
+	})
+
+	if ceiling < 0 && r.SkyCondition.SkyCover == SkyCoverOVX && r.Present.Has(FieldVerticalVisibility) {
+		ceiling = r.VerticalVisibilityFt
+	}
+
+	return ceiling, ceiling >= 0
+}
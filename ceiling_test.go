@@ -0,0 +1,45 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ceiling", func() {
+
+	table.DescribeTable("from raw reports",
+		func(raw string, ceiling int64, found bool) {
+			r, err := ParseRaw(raw)
+			Expect(err).NotTo(HaveOccurred())
+
+			c, ok := r.Ceiling()
+			Expect(ok).To(Equal(found))
+			Expect(c).To(Equal(ceiling))
+		},
+		table.Entry("lowest broken layer", "EDDH 211020Z 27008KT 9999 FEW010 BKN030 OVC020 17/09 Q1018", int64(2000), true),
+		table.Entry("vertical visibility", "EGLL 020520Z 00000KT 0100 FG VV002 06/06 Q1025", int64(200), true),
+		table.Entry("no ceiling", "EDDH 211020Z 27008KT 9999 FEW030 SCT040 17/09 Q1018", int64(-1), false),
+	)
+
+	It("should decode the vertical visibility", func() {
+		r, err := ParseRaw("EGLL 020520Z 00000KT 0100 FG VV002 06/06 Q1025")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.VerticalVisibilityFt).To(Equal(int64(200)))
+		Expect(r.Present.Has(FieldVerticalVisibility)).To(BeTrue())
+		Expect(r.SkyCondition.SkyCover).To(Equal(SkyCoverOVX))
+	})
+
+	It("should fall back to the vertical visibility field for obscured skies", func() {
+		r := Result{VerticalVisibilityFt: 300}
+		r.SkyCondition.SkyCover = SkyCoverOVX
+		r.Present.Add(FieldVerticalVisibility)
+
+		c, ok := r.Ceiling()
+		Expect(ok).To(BeTrue())
+		Expect(c).To(Equal(int64(300)))
+	})
+
+})
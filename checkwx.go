@@ -0,0 +1,199 @@
+package metar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	checkWXBaseURL = "https://api.checkwx.com"
+)
+
+// CheckWX is a Source backed by the decoded METAR API of api.checkwx.com.
+// It only provides the latest report of the requested stations, the time
+// window of queries is ignored.
+type CheckWX struct {
+	// APIKey to authenticate against the API
+	APIKey string
+	// BaseURL of the API, defaults to the api.checkwx.com
+	BaseURL string
+}
+
+// WithCheckWX makes the client use the CheckWX API using the given key
+func WithCheckWX(apiKey string) ClientOption {
+	return WithSource(CheckWX{APIKey: apiKey})
+}
+
+type checkWXResponse struct {
+	Results int             `json:"results"`
+	Data    []checkWXReport `json:"data"`
+}
+
+type checkWXReport struct {
+	ICAO     string `json:"icao"`
+	Observed string `json:"observed"`
+	RawText  string `json:"raw_text"`
+	Station  *struct {
+		Geometry struct {
+			Coordinates []float64 `json:"coordinates"`
+		} `json:"geometry"`
+	} `json:"station"`
+	Barometer *struct {
+		Hg float64 `json:"hg"`
+		MB float64 `json:"mb"`
+	} `json:"barometer"`
+	Clouds []struct {
+		Code string `json:"code"`
+	} `json:"clouds"`
+	Conditions []struct {
+		Code string `json:"code"`
+	} `json:"conditions"`
+	Dewpoint *struct {
+		Celsius float64 `json:"celsius"`
+	} `json:"dewpoint"`
+	Elevation *struct {
+		Meters float64 `json:"meters"`
+	} `json:"elevation"`
+	FlightCategory string `json:"flight_category"`
+	Temperature    *struct {
+		Celsius float64 `json:"celsius"`
+	} `json:"temperature"`
+	Visibility *struct {
+		MilesFloat float64 `json:"miles_float"`
+	} `json:"visibility"`
+	Wind *struct {
+		Degrees  *int64 `json:"degrees"`
+		SpeedKts *int64 `json:"speed_kts"`
+		GustKts  *int64 `json:"gust_kts"`
+	} `json:"wind"`
+}
+
+// Fetch retrieves the latest decoded reports of the requested stations
+func (c CheckWX) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = checkWXBaseURL
+	}
+
+	u := fmt.Sprintf("%s/metar/%s/decoded", base, url.PathEscape(strings.Join(q.Stations, ",")))
+	res, err := get(ctx, u, http.Header{"X-API-Key": {c.APIKey}})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CheckWX API returned status %d", res.StatusCode)
+	}
+
+	r := checkWXResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(r.Data))
+	for _, d := range r.Data {
+		result, err := d.result()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	setOrigin(results, res)
+
+	return selectReports(results, q.Selection), nil
+}
+
+func (d checkWXReport) result() (Result, error) {
+	r := Result{
+		StationID:      d.ICAO,
+		RawText:        d.RawText,
+		FlightCategory: FlightCategory(d.FlightCategory),
+		MetarType:      "METAR",
+	}
+	r.Present.Add(FieldStationID)
+	r.Present.Add(FieldRawText)
+
+	if d.Observed != "" {
+		t, err := time.Parse("2006-01-02T15:04:05", strings.TrimSuffix(d.Observed, "Z"))
+		if err != nil {
+			return r, fmt.Errorf("Invalid observation time %q: %s", d.Observed, err)
+		}
+		r.ObservationTime = t
+		r.Present.Add(FieldObservationTime)
+	}
+
+	if d.Station != nil && len(d.Station.Geometry.Coordinates) == 2 {
+		r.Longitude, r.Latitude = d.Station.Geometry.Coordinates[0], d.Station.Geometry.Coordinates[1]
+		r.Present.Add(FieldLongitude)
+		r.Present.Add(FieldLatitude)
+	}
+
+	if d.Barometer != nil {
+		r.Altimeter = d.Barometer.Hg
+		r.Present.Add(FieldAltimeter)
+	}
+
+	if len(d.Clouds) > 0 {
+		r.SkyCondition.SkyCover = SkyCover(d.Clouds[len(d.Clouds)-1].Code)
+		r.Present.Add(FieldSkyCondition)
+	}
+
+	if len(d.Conditions) > 0 {
+		codes := make([]string, len(d.Conditions))
+		for i, c := range d.Conditions {
+			codes[i] = c.Code
+		}
+		r.WXString = strings.Join(codes, " ")
+		r.Present.Add(FieldWXString)
+	}
+
+	if d.Temperature != nil {
+		r.Temperature = d.Temperature.Celsius
+		r.Present.Add(FieldTemperature)
+	}
+
+	if d.Dewpoint != nil {
+		r.Dewpoint = d.Dewpoint.Celsius
+		r.Present.Add(FieldDewpoint)
+	}
+
+	if d.Elevation != nil {
+		r.Elevation = d.Elevation.Meters
+		r.Present.Add(FieldElevation)
+	}
+
+	setReportMarkers(&r)
+
+	if d.FlightCategory != "" {
+		r.Present.Add(FieldFlightCategory)
+	}
+
+	if d.Visibility != nil {
+		r.VisibilityStatute = d.Visibility.MilesFloat
+		r.Present.Add(FieldVisibilityStatute)
+	}
+
+	if d.Wind != nil {
+		if d.Wind.Degrees != nil {
+			r.WindDirDegrees = *d.Wind.Degrees
+			r.Present.Add(FieldWindDirDegrees)
+		}
+		if d.Wind.SpeedKts != nil {
+			r.WindSpeed = *d.Wind.SpeedKts
+			r.Present.Add(FieldWindSpeed)
+		}
+		if d.Wind.GustKts != nil {
+			r.WindGust = *d.Wind.GustKts
+			r.Present.Add(FieldWindGust)
+		}
+	}
+
+	r.Present.Add(FieldMetarType)
+	return r, nil
+}
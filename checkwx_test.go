@@ -0,0 +1,63 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckWX", func() {
+	var (
+		path, apiKey string
+		server       *httptest.Server
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, apiKey = r.URL.Path, r.Header.Get("X-API-Key")
+			if apiKey != "secret" {
+				http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+				return
+			}
+			http.ServeFile(w, r, filepath.Join("testdata", "checkwx.json"))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should map the decoded report", func() {
+		client := NewClient(WithSource(CheckWX{APIKey: "secret", BaseURL: server.URL}))
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/metar/EDDH/decoded"))
+
+		Expect(r.StationID).To(Equal("EDDH"))
+		Expect(r.ObservationTime).To(Equal(time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)))
+		Expect(r.Latitude).To(Equal(53.63))
+		Expect(r.Longitude).To(Equal(10.0))
+		Expect(r.Temperature).To(Equal(17.0))
+		Expect(r.Dewpoint).To(Equal(9.0))
+		Expect(r.WindDirDegrees).To(Equal(int64(270)))
+		Expect(r.WindSpeed).To(Equal(int64(8)))
+		Expect(r.Altimeter).To(Equal(30.06))
+		Expect(r.SkyCondition.SkyCover).To(Equal(SkyCoverFEW))
+		Expect(r.FlightCategory).To(Equal(FlightCategoryVFR))
+		Expect(r.Present.Has(FieldWindGust)).To(BeFalse())
+		Expect(r.Present.Has(FieldWXString)).To(BeFalse())
+	})
+
+	It("should report authentication errors", func() {
+		_, err := CheckWX{APIKey: "wrong", BaseURL: server.URL}.Fetch(context.Background(), Query{Stations: []string{"EDDH"}})
+		Expect(err).To(MatchError("CheckWX API returned status 401"))
+	})
+
+})
@@ -0,0 +1,75 @@
+package metar
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned while the circuit breaker is open and no
+// previous result for the station is available
+var ErrCircuitOpen = errors.New("Upstream API is unavailable (circuit open)")
+
+// WithCircuitBreaker stops requests to the upstream API after threshold
+// consecutive failures. While the circuit is open the last known result of
+// the station is returned with Stale set. After cooldown a single request
+// is let through to probe whether the upstream has recovered.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		c.breaker = &circuitBreaker{
+			threshold: threshold,
+			cooldown:  cooldown,
+		}
+	}
+}
+
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// allow reports whether a request may be sent upstream. Once the cooldown
+// has passed one probing request is allowed and the circuit is held open
+// for the other callers until the probe has finished.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+
+	if time.Now().Before(b.openUntil) {
+		return false
+	}
+
+	b.openUntil = time.Now().Add(b.cooldown)
+	return true
+}
+
+// record tracks the outcome of an upstream request. Requests cancelled by
+// the caller or exceeding its deadline say nothing about the upstream and
+// are ignored.
+func (b *circuitBreaker) record(err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err != nil && !errors.Is(err, ErrNoResults) {
+		b.failures++
+		if b.failures >= b.threshold {
+			b.openUntil = time.Now().Add(b.cooldown)
+		}
+		return
+	}
+
+	b.failures = 0
+}
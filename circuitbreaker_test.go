@@ -0,0 +1,111 @@
+package metar_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func xmlResponse(req *http.Request, body string) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+var _ = Describe("CircuitBreaker", func() {
+	var (
+		client   *Client
+		failing  bool
+		requests int
+	)
+
+	BeforeEach(func() {
+		failing = false
+		requests = 0
+		client = NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				requests++
+				if err := req.Context().Err(); err != nil {
+					return nil, err
+				}
+				if failing {
+					return nil, errors.New("upstream down")
+				}
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithCircuitBreaker(2, 50*time.Millisecond),
+		)
+	})
+
+	It("should serve the last known result while open", func() {
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Stale).To(BeFalse())
+
+		failing = true
+		for i := 0; i < 2; i++ {
+			_, err = client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			Expect(err).To(HaveOccurred())
+		}
+
+		r, err = client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Stale).To(BeTrue())
+		Expect(r.StationID).To(Equal("EDDH"))
+		Expect(requests).To(Equal(3))
+	})
+
+	It("should fail fast without a known result", func() {
+		failing = true
+		for i := 0; i < 2; i++ {
+			client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		}
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).To(Equal(ErrCircuitOpen))
+		Expect(requests).To(Equal(2))
+	})
+
+	It("should close again when the upstream recovers", func() {
+		failing = true
+		for i := 0; i < 2; i++ {
+			client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		}
+
+		failing = false
+		time.Sleep(60 * time.Millisecond)
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Stale).To(BeFalse())
+		Expect(requests).To(Equal(3))
+	})
+
+	It("should not count cancelled requests as failures", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for i := 0; i < 3; i++ {
+			_, err := client.FetchCurrentStationWeather(ctx, "EDDH")
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		}
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Stale).To(BeFalse())
+		Expect(requests).To(Equal(4))
+	})
+})
@@ -0,0 +1,347 @@
+package metar
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultClient is used by the package level fetch functions
+var DefaultClient = NewClient()
+
+// Client fetches METAR data and holds the configuration applied to all of
+// its requests. A Client is safe for concurrent use.
+type Client struct {
+	source      Source
+	httpClient  *http.Client
+	header      http.Header
+	rateLimiter *RateLimiter
+	breaker     *circuitBreaker
+	revalidate  time.Duration
+	proxy       func(*http.Request) (*url.URL, error)
+	conditional *conditionalCache
+	aliases     map[string]string
+
+	maxResponseSize int64
+	maxResults      int
+	lenientXML      bool
+	rawPayload      bool
+
+	maxObservationAge time.Duration
+	instrumentation   Instrumentation
+	logger            *slog.Logger
+
+	inFlight flightGroup
+	store    *resultStore
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// NewClient creates a new Client configured by the given options
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		source: ADDS{},
+		header: make(http.Header),
+		logger: discardLogger,
+		store:  newResultStore(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.proxy != nil {
+		c.httpClient = withProxy(c.http(), c.proxy)
+	}
+	return c
+}
+
+// WithHTTPClient sets the http.Client used for upstream requests. If not
+// set the package level HTTPClient is used.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithBaseURL points the default ADDS source to another dataserver
+// endpoint like a corporate mirror or a local stub. It is a shorthand for
+// WithSource(ADDS{BaseURL: baseURL}), the other sources have a BaseURL
+// field of their own.
+func WithBaseURL(baseURL string) ClientOption {
+	return WithSource(ADDS{BaseURL: baseURL})
+}
+
+// WithProxy sends all upstream requests through the given proxy. A nil
+// proxy explicitly uses the proxy configured by the HTTP_PROXY, HTTPS_PROXY
+// and NO_PROXY environment variables, which is useful in combination with
+// WithHTTPClient for clients whose transport does not honor them.
+func WithProxy(proxy *url.URL) ClientOption {
+	return func(c *Client) {
+		c.proxy = http.ProxyFromEnvironment
+		if proxy != nil {
+			c.proxy = http.ProxyURL(proxy)
+		}
+	}
+}
+
+// withProxy returns a copy of the http.Client using the proxy function
+func withProxy(hc *http.Client, proxy func(*http.Request) (*url.URL, error)) *http.Client {
+	t, ok := hc.Transport.(*http.Transport)
+	if !ok || t == nil {
+		t = http.DefaultTransport.(*http.Transport)
+	}
+	t = t.Clone()
+	t.Proxy = proxy
+
+	out := *hc
+	out.Transport = t
+	return &out
+}
+
+// WithUserAgent sets the User-Agent sent with every upstream request.
+// aviationweather.gov asks clients to identify themselves, so consider
+// including a contact address.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) { c.header.Set("User-Agent", ua) }
+}
+
+// WithHeader adds a header sent with every upstream request, for example
+// authentication for a corporate proxy. It may be given multiple times.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) { c.header.Add(key, value) }
+}
+
+// WithRateLimiter throttles all upstream requests of the client using the
+// given RateLimiter. The same RateLimiter may be shared by multiple clients.
+func WithRateLimiter(l *RateLimiter) ClientOption {
+	return func(c *Client) { c.rateLimiter = l }
+}
+
+// WithStaleWhileRevalidate makes the client answer from the last known
+// result of a station instead of waiting for the upstream. Results fetched
+// less than refreshAfter ago are returned as they are, older ones are
+// returned with Stale set while a refresh is started in the background.
+// Use FetchedAt on the Result to determine its age.
+func WithStaleWhileRevalidate(refreshAfter time.Duration) ClientOption {
+	return func(c *Client) { c.revalidate = refreshAfter }
+}
+
+// FetchCurrentStationWeather fetches the last result from the specified station if it was reported during last 2 hours
+//
+// Concurrent calls for the same station share a single upstream request.
+func (c *Client) FetchCurrentStationWeather(ctx context.Context, station string) (*Result, error) {
+	r, err := c.fetchCurrentStationWeather(ctx, station)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.maxObservationAge > 0 && r.IsStale(c.maxObservationAge) {
+		c.logger.Warn("observation exceeds maximum age", "station", r.StationID, "age", r.Age())
+		return nil, ErrStaleObservation
+	}
+
+	return r, nil
+}
+
+func (c *Client) fetchCurrentStationWeather(ctx context.Context, station string) (*Result, error) {
+	station = c.resolve(station)
+	key := strings.ToUpper(station)
+
+	if c.revalidate > 0 {
+		r, ok, err := c.store.get(ctx, key)
+		if err != nil {
+			c.logger.Warn("reading cached result failed", "station", key, "error", err)
+		}
+		if ok {
+			r.FromCache = true
+			if time.Since(r.FetchedAt) >= c.revalidate {
+				r.Stale = true
+				c.logger.Warn("serving stale result", "station", key, "fetched_at", r.FetchedAt)
+				c.refresh(key, station)
+				return r, nil
+			}
+			c.logger.Debug("serving stored result", "station", key, "fetched_at", r.FetchedAt)
+			return r, nil
+		}
+	}
+
+	results, err := c.query(ctx, Query{Stations: []string{station}})
+	if err != nil {
+		return nil, err
+	}
+
+	return pickStation(results, station)
+}
+
+// Fetch executes the query against the configured Source
+func (c *Client) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	results, err := c.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.maxObservationAge > 0 && len(results) > 0 {
+		newest := results[0]
+		for _, r := range results[1:] {
+			if r.ObservationTime.After(newest.ObservationTime) {
+				newest = r
+			}
+		}
+		if newest.IsStale(c.maxObservationAge) {
+			c.logger.Warn("observation exceeds maximum age", "station", newest.StationID, "age", newest.Age())
+			return nil, ErrStaleObservation
+		}
+	}
+
+	return results, nil
+}
+
+// FetchStationsWeather fetches the last result of every given station
+// reported during the last 2 hours using a single upstream request.
+// Stations without a report are missing from the returned results.
+func (c *Client) FetchStationsWeather(ctx context.Context, stations []string) ([]Result, error) {
+	return c.query(ctx, Query{Stations: stations, Selection: MostRecentForEachStation})
+}
+
+// pickStation returns the result of the given station or the first result
+// if the source reported another identifier
+func pickStation(results []Result, station string) (*Result, error) {
+	if len(results) == 0 {
+		return nil, ErrNoResults
+	}
+
+	for i := range results {
+		if strings.EqualFold(results[i].StationID, station) {
+			return &results[i], nil
+		}
+	}
+	return &results[0], nil
+}
+
+// query executes the query against the source, passing it through the
+// configured deduplication, circuit breaker, rate limiting and
+// instrumentation
+func (c *Client) query(ctx context.Context, q Query) ([]Result, error) {
+	q = c.resolveQuery(q)
+	return c.inFlight.do(q.key(), func() ([]Result, error) {
+		if c.breaker != nil && !c.breaker.allow() {
+			c.logger.Warn("circuit open, not contacting upstream", "stations", q.Stations)
+			return c.fallback(ctx, q)
+		}
+
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		results, err := c.instrumentedFetch(ctx, q)
+		if c.breaker != nil {
+			c.breaker.record(err)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// UTC without monotonic reading survives the round trip through
+		// the Cache unchanged
+		now := time.Now().UTC().Round(0)
+		for i := range results {
+			results[i].FetchedAt = now
+			if results[i].Source == "" {
+				results[i].Source = SourceName(c.source)
+			}
+			if len(q.Fields) == 0 {
+				if err := c.store.set(ctx, strings.ToUpper(results[i].StationID), results[i]); err != nil {
+					c.logger.Warn("caching result failed", "station", results[i].StationID, "error", err)
+				}
+			}
+		}
+		return results, nil
+	})
+}
+
+func (c *Client) instrumentedFetch(ctx context.Context, q Query) ([]Result, error) {
+	stations := strings.Join(q.Stations, ",")
+
+	info := FetchInfo{Station: stations}
+	if c.instrumentation != nil {
+		ctx = c.instrumentation.FetchStarted(ctx, stations)
+	}
+
+	start := time.Now()
+	results, err := c.source.Fetch(withRequestContext(ctx, c, &info), q)
+	info.Results = len(results)
+	if err != nil {
+		c.logger.Warn("upstream request failed", "stations", stations, "error", err)
+	} else {
+		c.logger.Debug("upstream request finished", "stations", stations,
+			"status", info.StatusCode, "results", info.Results, "duration", time.Since(start))
+	}
+
+	if c.instrumentation != nil {
+		info.Duration = time.Since(start)
+		info.Err = err
+		c.instrumentation.FetchFinished(ctx, info)
+	}
+	return results, err
+}
+
+// refresh updates the stored result of the station in the background
+func (c *Client) refresh(key, station string) {
+	if !c.store.startRefresh(key) {
+		return
+	}
+
+	c.logger.Debug("refreshing result in background", "station", key)
+	go func() {
+		defer c.store.endRefresh(key)
+		c.query(context.Background(), Query{Stations: []string{station}})
+	}()
+}
+
+// fallback returns the last known results for the queried stations marked
+// as stale
+func (c *Client) fallback(ctx context.Context, q Query) ([]Result, error) {
+	var results []Result
+	for _, station := range q.Stations {
+		r, ok, err := c.store.get(ctx, strings.ToUpper(station))
+		if err != nil {
+			c.logger.Warn("reading cached result failed", "station", station, "error", err)
+		}
+		if ok {
+			r.FromCache = true
+			r.Stale = true
+			results = append(results, *r)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, ErrCircuitOpen
+	}
+	return results, nil
+}
+
+// newRequest creates a GET request carrying the configured headers
+func (c *Client) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range c.header {
+		req.Header[k] = append([]string(nil), v...)
+	}
+
+	c.logger.Debug("requesting upstream", "url", url)
+	return req, nil
+}
+
+func (c *Client) http() *http.Client {
+	if c.httpClient != nil {
+		return c.httpClient
+	}
+	return HTTPClient
+}
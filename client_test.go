@@ -0,0 +1,210 @@
+package metar_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Client", func() {
+
+	It("should fetch multiple stations with one request", func() {
+		var query string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			http.ServeFile(w, r, filepath.Join("testdata", "multi.xml"))
+		}))
+		defer server.Close()
+
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}))
+		results, err := client.FetchStationsWeather(context.Background(), []string{"EDDH", "EDDF"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(ContainSubstring("mostRecentForEachStation=constraint"))
+		Expect(query).NotTo(ContainSubstring("mostRecent=true"))
+		Expect(results).To(HaveLen(2))
+		Expect(results[1].StationID).To(Equal("EDDF"))
+		Expect(results[1].FetchedAt.IsZero()).To(BeFalse())
+		Expect(results[1].Source).To(Equal("adds"))
+		Expect(results[1].SourceURL).To(HavePrefix(server.URL + "?"))
+		Expect(results[1].StatusCode).To(Equal(http.StatusOK))
+		Expect(results[1].FromCache).To(BeFalse())
+	})
+
+	It("should fail on error responses", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, singleResultXML)
+		}))
+		defer server.Close()
+
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}))
+		_, err := client.FetchStationsWeather(context.Background(), []string{"EDDH", "EDDF"})
+		Expect(err).To(MatchError("ADDS server returned status 503"))
+	})
+
+	It("should request explicit time windows", func() {
+		var query url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			http.ServeFile(w, r, filepath.Join("testdata", "eddh.xml"))
+		}))
+		defer server.Close()
+
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}))
+		_, err := client.Fetch(context.Background(), Query{
+			Stations:  []string{"EDDH"},
+			Selection: AllReports,
+			Start:     time.Date(2016, 5, 20, 6, 0, 0, 0, time.UTC),
+			End:       time.Date(2016, 5, 20, 12, 0, 0, 0, time.UTC),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query.Get("startTime")).To(Equal("2016-05-20T06:00:00Z"))
+		Expect(query.Get("endTime")).To(Equal("2016-05-20T12:00:00Z"))
+		Expect(query.Get("hoursBeforeNow")).To(Equal(""))
+		Expect(query.Get("mostRecent")).To(Equal(""))
+
+		_, err = client.Fetch(context.Background(), Query{Stations: []string{"EDDH"}, HoursBeforeNow: 6})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query.Get("hoursBeforeNow")).To(Equal("6"))
+	})
+
+	It("should limit the requested fields", func() {
+		var query string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query().Get("fields")
+			fmt.Fprint(w, `<response><data num_results="1"><METAR><station_id>EDDH</station_id>`+
+				`<observation_time>2016-05-21T10:20:00Z</observation_time><temp_c>17.0</temp_c>`+
+				`<flight_category>VFR</flight_category></METAR></data></response>`)
+		}))
+		defer server.Close()
+
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}))
+		results, err := client.Fetch(context.Background(), Query{
+			Stations:  []string{"EDDH"},
+			Selection: MostRecentForEachStation,
+			Fields:    []Field{FieldTemperature, FieldFlightCategory},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(Equal("temp_c,flight_category,station_id,observation_time"))
+		Expect(results[0].HasField(FieldTemperature)).To(BeTrue())
+		Expect(results[0].HasField(FieldStationID)).To(BeTrue())
+		Expect(results[0].HasField(FieldWindSpeed)).To(BeFalse())
+		Expect(results[0].Present.Has(FieldWindSpeed)).To(BeFalse())
+	})
+
+	It("should send the configured headers", func() {
+		var header http.Header
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				header = req.Header
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithUserAgent("weather-bot/1.0 (ops@example.com)"),
+			WithHeader("Proxy-Authorization", "Basic Zm9vOmJhcg=="),
+			WithHeader("X-Trace", "a"),
+			WithHeader("X-Trace", "b"),
+		)
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(header.Get("User-Agent")).To(Equal("weather-bot/1.0 (ops@example.com)"))
+		Expect(header.Get("Proxy-Authorization")).To(Equal("Basic Zm9vOmJhcg=="))
+		Expect(header["X-Trace"]).To(Equal([]string{"a", "b"}))
+	})
+
+	It("should use the configured base URL", func() {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			http.ServeFile(w, r, filepath.Join("testdata", "eddh.xml"))
+		}))
+		defer server.Close()
+
+		client := NewClient(WithBaseURL(server.URL + "/mirror/httpparam"))
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/mirror/httpparam"))
+		Expect(r.StationID).To(Equal("EDDH"))
+	})
+
+	It("should send requests through the configured proxy", func() {
+		var requested string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = r.URL.String()
+			http.ServeFile(w, r, filepath.Join("testdata", "eddh.xml"))
+		}))
+		defer proxy.Close()
+
+		proxyURL, _ := url.Parse(proxy.URL)
+		client := NewClient(
+			WithHTTPClient(&http.Client{Timeout: time.Second}),
+			WithBaseURL("http://mirror.example.com/httpparam"),
+			WithProxy(proxyURL),
+		)
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requested).To(HavePrefix("http://mirror.example.com/httpparam?"))
+	})
+
+	Context("with stale-while-revalidate", func() {
+		var (
+			client   *Client
+			requests int32
+		)
+
+		BeforeEach(func() {
+			atomic.StoreInt32(&requests, 0)
+			client = NewClient(
+				WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					atomic.AddInt32(&requests, 1)
+					return xmlResponse(req, singleResultXML)
+				})}),
+				WithStaleWhileRevalidate(50*time.Millisecond),
+			)
+		})
+
+		It("should fetch synchronously without a known result", func() {
+			r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Stale).To(BeFalse())
+			Expect(r.FetchedAt).To(BeTemporally("~", time.Now(), time.Second))
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+
+		It("should serve fresh results from memory", func() {
+			client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			r, err := client.FetchCurrentStationWeather(context.Background(), "eddh")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Stale).To(BeFalse())
+			Expect(r.FromCache).To(BeTrue())
+			Expect(atomic.LoadInt32(&requests)).To(Equal(int32(1)))
+		})
+
+		It("should serve old results as stale and refresh them in the background", func() {
+			first, _ := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			time.Sleep(60 * time.Millisecond)
+
+			r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Stale).To(BeTrue())
+			Expect(r.FetchedAt).To(Equal(first.FetchedAt))
+
+			Eventually(func() int32 { return atomic.LoadInt32(&requests) }).Should(Equal(int32(2)))
+			Eventually(func() bool {
+				r, _ := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+				return r.Stale
+			}).Should(BeFalse())
+		})
+	})
+
+})
@@ -0,0 +1,26 @@
+package metar
+
+// cumulusBaseFtPerDegree is the height gain in feet per degree celsius of
+// temperature/dewpoint spread: the air cools by about 2.5 °C per 1,000 ft
+// more than its dewpoint until the two meet at the condensation level
+const cumulusBaseFtPerDegree = 400
+
+// CumulusBaseFt estimates the base of convective clouds in feet above
+// ground from the temperature/dewpoint spread at the surface (celsius)
+func CumulusBaseFt(tempC, dewpointC float64) float64 {
+	spread := tempC - dewpointC
+	if spread < 0 {
+		return 0
+	}
+	return spread * cumulusBaseFtPerDegree
+}
+
+// EstimatedCumulusBaseFt estimates the base of convective clouds in feet
+// above ground using CumulusBaseFt. The estimate only applies to clouds
+// formed by thermals and requires temperature and dewpoint to be reported.
+func (r Result) EstimatedCumulusBaseFt() (float64, bool) {
+	if !r.Present.Has(FieldTemperature) || !r.Present.Has(FieldDewpoint) {
+		return 0, false
+	}
+	return CumulusBaseFt(r.Temperature, r.Dewpoint), true
+}
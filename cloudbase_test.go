@@ -0,0 +1,30 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cumulus base", func() {
+
+	It("should estimate the base from the spread", func() {
+		Expect(CumulusBaseFt(17, 9)).To(Equal(3200.0))
+		Expect(CumulusBaseFt(6, 6)).To(Equal(0.0))
+		Expect(CumulusBaseFt(5, 6)).To(Equal(0.0))
+	})
+
+	It("should require temperature and dewpoint", func() {
+		r, err := ParseRaw("EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+
+		base, ok := r.EstimatedCumulusBaseFt()
+		Expect(ok).To(BeTrue())
+		Expect(base).To(Equal(3200.0))
+
+		_, ok = Result{Temperature: 17}.EstimatedCumulusBaseFt()
+		Expect(ok).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,150 @@
+package metar
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CloudType is the convective cloud type annotated to a cloud layer
+type CloudType string
+
+// Cloud types reported in METARs, CloudTypeNone for layers without or
+// with an unknown (///) annotation
+const (
+	CloudTypeNone            CloudType = ""
+	CloudTypeCumulonimbus    CloudType = "CB"
+	CloudTypeToweringCumulus CloudType = "TCU"
+)
+
+// CloudLayer is a cloud layer or the vertical visibility (SkyCoverOVX)
+// reported in the raw report
+type CloudLayer struct {
+	Cover  SkyCover
+	BaseFt int64 // Height of the base above ground in feet, -1 if not reported (///)
+	Type   CloudType
+}
+
+// RemarkCloud is a convective cloud reported in the remarks of North
+// American reports (CB DSNT W MOV E). These clouds are not necessarily
+// part of any reported layer.
+type RemarkCloud struct {
+	Type CloudType
+	// Distance to the station as reported (OHD, VC, DSNT), empty if not
+	// reported
+	Distance string
+	// Directions the cloud was observed in as reported (SW, N-NE),
+	// ALQDS for all quadrants
+	Directions []string
+	Moving     string // Direction the cloud is moving towards, empty if not reported
+}
+
+// String returns the cloud group of the layer as reported (BKN040CB)
+func (l CloudLayer) String() string {
+	s := l.group()
+	if l.BaseFt < 0 {
+		s += "///"
+	} else {
+		s += fmt.Sprintf("%03d", l.BaseFt/100)
+	}
+	return s + string(l.Type)
+}
+
+// group returns the cover as written in the cloud group (VV for OVX)
+func (l CloudLayer) group() string {
+	if l.Cover == SkyCoverOVX {
+		return "VV"
+	}
+	return string(l.Cover)
+}
+
+// CloudLayers returns the cloud layers of the raw report in the order
+// they were reported. Convective clouds noted in the remarks are not
+// assigned to any layer, see RemarkClouds.
+func (r Result) CloudLayers() []CloudLayer {
+	var layers []CloudLayer
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind != TokenCloud {
+			continue
+		}
+		if l, ok := parseCloudLayer(t.Text); ok {
+			layers = append(layers, l)
+		}
+	}
+	return layers
+}
+
+// RemarkClouds returns the convective clouds reported in the remarks
+func (r Result) RemarkClouds() []RemarkCloud {
+	var (
+		rmk    = r.Remarks()
+		clouds []RemarkCloud
+	)
+
+	for i, g := range rmk {
+		if t := CloudType(g); t != CloudTypeCumulonimbus && t != CloudTypeToweringCumulus {
+			continue
+		}
+
+		c := RemarkCloud{Type: CloudType(g)}
+	location:
+		for j := i + 1; j < len(rmk); j++ {
+			switch loc := rmk[j]; {
+			case loc == "OHD", loc == "VC", loc == "DSNT":
+				c.Distance = loc
+			case loc == "AND":
+			case loc == "MOV" && j+1 < len(rmk) && lightningDirectionRegex.MatchString(rmk[j+1]):
+				c.Moving = rmk[j+1]
+				break location
+			case lightningDirectionRegex.MatchString(loc):
+				c.Directions = append(c.Directions, loc)
+			default:
+				break location
+			}
+		}
+		clouds = append(clouds, c)
+	}
+	return clouds
+}
+
+// HasConvectiveClouds reports whether cumulonimbus or towering cumulus
+// clouds are reported in the cloud groups or the remarks
+func (r Result) HasConvectiveClouds() bool {
+	for _, l := range r.CloudLayers() {
+		if l.Type != CloudTypeNone {
+			return true
+		}
+	}
+	return len(r.RemarkClouds()) > 0
+}
+
+// parseCloudLayer decodes a single cloud group (BKN040CB, VV///)
+func parseCloudLayer(group string) (CloudLayer, bool) {
+	m := cloudRegex.FindStringSubmatch(group)
+	if m == nil {
+		return CloudLayer{}, false
+	}
+
+	l := CloudLayer{Cover: SkyCover(m[1]), BaseFt: -1}
+	if m[1] == "VV" {
+		l.Cover = SkyCoverOVX
+	}
+	if base, err := strconv.ParseInt(m[2], 10, 64); err == nil {
+		l.BaseFt = base * 100
+	}
+	if m[3] == "CB" || m[3] == "TCU" {
+		l.Type = CloudType(m[3])
+	}
+	return l, true
+}
+
+// lowestBase returns the lowest reported base of the layers matching the
+// filter, -1 if there is none
+func lowestBase(layers []CloudLayer, filter func(CloudLayer) bool) int64 {
+	lowest := int64(-1)
+	for _, l := range layers {
+		if l.BaseFt >= 0 && filter(l) && (lowest < 0 || l.BaseFt < lowest) {
+			lowest = l.BaseFt
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,69 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cloud layers", func() {
+
+	It("should decode the layers with their cloud type", func() {
+		r := Result{RawText: "KMIA 151853Z 09015G28KT 2SM +TSRA BR SCT015 BKN025CB OVC050 24/22 A2990 RMK AO2"}
+
+		Expect(r.CloudLayers()).To(Equal([]CloudLayer{
+			{Cover: SkyCoverSCT, BaseFt: 1500},
+			{Cover: SkyCoverBKN, BaseFt: 2500, Type: CloudTypeCumulonimbus},
+			{Cover: SkyCoverOVC, BaseFt: 5000},
+		}))
+		Expect(r.HasConvectiveClouds()).To(BeTrue())
+	})
+
+	It("should decode unknown bases and vertical visibility", func() {
+		r := Result{RawText: "EDDH 211020Z AUTO 27008KT 0300 FG BKN///TCU VV001 07/07 Q1018"}
+
+		Expect(r.CloudLayers()).To(Equal([]CloudLayer{
+			{Cover: SkyCoverBKN, BaseFt: -1, Type: CloudTypeToweringCumulus},
+			{Cover: SkyCoverOVX, BaseFt: 100},
+		}))
+	})
+
+	It("should not assign cloud types from the remarks to a layer", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 10SM FEW050 BKN040 24/18 A2990 RMK AO2 TCU SW CB DSNT W"}
+
+		Expect(r.CloudLayers()).To(Equal([]CloudLayer{
+			{Cover: SkyCoverFEW, BaseFt: 5000},
+			{Cover: SkyCoverBKN, BaseFt: 4000},
+		}))
+		Expect(r.HasConvectiveClouds()).To(BeTrue())
+	})
+
+	It("should decode convective clouds from the remarks", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 10SM FEW050 BKN040 24/18 A2990 RMK AO2 TCU SW CB DSNT W MOV E TCU OHD AND NE-E SLP132"}
+
+		Expect(r.RemarkClouds()).To(Equal([]RemarkCloud{
+			{Type: CloudTypeToweringCumulus, Directions: []string{"SW"}},
+			{Type: CloudTypeCumulonimbus, Distance: "DSNT", Directions: []string{"W"}, Moving: "E"},
+			{Type: CloudTypeToweringCumulus, Distance: "OHD", Directions: []string{"NE-E"}},
+		}))
+	})
+
+	It("should format the layers as cloud groups", func() {
+		Expect(CloudLayer{Cover: SkyCoverBKN, BaseFt: 400, Type: CloudTypeCumulonimbus}.String()).To(Equal("BKN004CB"))
+		Expect(CloudLayer{Cover: SkyCoverOVX, BaseFt: -1}.String()).To(Equal("VV///"))
+	})
+
+	It("should report convective clouds from the remarks without layers", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 10SM CLR 24/18 A2990 RMK AO2 TCU DSNT W"}
+
+		Expect(r.CloudLayers()).To(BeEmpty())
+		Expect(r.HasConvectiveClouds()).To(BeTrue())
+	})
+
+	It("should report missing convective clouds", func() {
+		r := Result{RawText: "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG"}
+		Expect(r.HasConvectiveClouds()).To(BeFalse())
+	})
+
+})
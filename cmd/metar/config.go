@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+// config is the YAML configuration of the serve-collector mode
+//
+//	stations: [EDDH, EDDW]
+//	interval: 10m
+//	schedule: jitter
+//	listen: ":8080"
+//	sources:
+//	  - type: adds
+//	  - type: checkwx
+//	    api_key: ...
+//	sinks:
+//	  - type: bolt
+//	    path: metar.db
+//	  - type: webhook
+//	    url: https://example.com/hook
+//	    secret: s3cr3t
+//	rules:
+//	  - name: strong gusts
+//	    wind_gust_above: 25
+type config struct {
+	Stations  []string      `yaml:"stations"`
+	Interval  time.Duration `yaml:"interval"`
+	Schedule  string        `yaml:"schedule"` // fixed (default), jitter or adaptive
+	UserAgent string        `yaml:"user_agent"`
+	LogLevel  string        `yaml:"log_level"`
+	Listen    string        `yaml:"listen"` // Address to serve /healthz, /readyz, /stations and /metrics (prometheus sink) on
+	Proxy     string        `yaml:"proxy"`  // Proxy URL for upstream requests, defaults to HTTP_PROXY / HTTPS_PROXY
+
+	Sources []sourceConfig `yaml:"sources"`
+	Sinks   []sinkConfig   `yaml:"sinks"`
+	Rules   []ruleConfig   `yaml:"rules"`
+}
+
+// sourceConfig selects a Source, multiple sources are used as failover chain
+type sourceConfig struct {
+	Type    string `yaml:"type"` // adds, noaa, noaa-cycles, checkwx, avwx
+	APIKey  string `yaml:"api_key"`
+	BaseURL string `yaml:"base_url"` // Mirror of the upstream API, defaults to the public endpoint
+}
+
+// sinkConfig configures a destination of the collected observations
+type sinkConfig struct {
+	Type   string `yaml:"type"` // bolt, postgres, webhook, prometheus, log
+	Path   string `yaml:"path"`
+	DSN    string `yaml:"dsn"`
+	URL    string `yaml:"url"`
+	Secret string `yaml:"secret"`
+}
+
+// ruleConfig defines an alert rule, all given conditions must match
+type ruleConfig struct {
+	Name     string   `yaml:"name"`
+	Stations []string `yaml:"stations"`
+
+	WindSpeedAbove          *int64   `yaml:"wind_speed_above"`
+	WindGustAbove           *int64   `yaml:"wind_gust_above"`
+	TemperatureBelow        *float64 `yaml:"temperature_below"`
+	TemperatureAbove        *float64 `yaml:"temperature_above"`
+	FlightCategoryWorseThan string   `yaml:"flight_category_worse_than"`
+	WeatherContains         string   `yaml:"weather_contains"`
+}
+
+func loadConfig(path string) (*config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg := &config{Interval: 10 * time.Minute}
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("Unable to parse %s: %s", path, err)
+	}
+
+	if len(cfg.Stations) == 0 {
+		return nil, fmt.Errorf("No stations configured")
+	}
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("Interval must be positive, got %s", cfg.Interval)
+	}
+	return cfg, nil
+}
+
+func (c *config) logLevel() slog.Level {
+	l := slog.LevelInfo
+	l.UnmarshalText([]byte(c.LogLevel))
+	return l
+}
+
+func (s sourceConfig) source() (metar.Source, error) {
+	switch s.Type {
+	case "adds":
+		return metar.ADDS{BaseURL: s.BaseURL}, nil
+	case "noaa":
+		return metar.NOAAText{BaseURL: s.BaseURL}, nil
+	case "noaa-cycles":
+		return metar.NOAACycles{BaseURL: s.BaseURL}, nil
+	case "checkwx":
+		return metar.CheckWX{APIKey: s.APIKey, BaseURL: s.BaseURL}, nil
+	case "avwx":
+		return metar.AVWX{Token: s.APIKey, BaseURL: s.BaseURL}, nil
+	}
+	return nil, fmt.Errorf("Unknown source type %q", s.Type)
+}
+
+func (r ruleConfig) rule() (metar.Rule, error) {
+	var conds []metar.Condition
+	if r.WindSpeedAbove != nil {
+		conds = append(conds, metar.WindSpeedAbove(*r.WindSpeedAbove))
+	}
+	if r.WindGustAbove != nil {
+		conds = append(conds, metar.WindGustAbove(*r.WindGustAbove))
+	}
+	if r.TemperatureBelow != nil {
+		conds = append(conds, metar.TemperatureBelow(*r.TemperatureBelow))
+	}
+	if r.TemperatureAbove != nil {
+		conds = append(conds, metar.TemperatureAbove(*r.TemperatureAbove))
+	}
+	if r.FlightCategoryWorseThan != "" {
+		conds = append(conds, metar.FlightCategoryWorseThan(metar.FlightCategory(r.FlightCategoryWorseThan)))
+	}
+	if r.WeatherContains != "" {
+		conds = append(conds, metar.WeatherContains(r.WeatherContains))
+	}
+
+	if r.Name == "" || len(conds) == 0 {
+		return metar.Rule{}, fmt.Errorf("Rule %q needs a name and at least one condition", r.Name)
+	}
+	return metar.Rule{Name: r.Name, Stations: r.Stations, Condition: metar.All(conds...)}, nil
+}
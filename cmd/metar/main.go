@@ -0,0 +1,107 @@
+// Command metar prints the current observations of stations or runs a
+// collector daemon configured by a YAML file.
+//
+//	metar EDDH EDDW
+//	metar serve-collector -config collector.yml
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve-collector" {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		err := serveCollector(ctx, os.Args[2:])
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "metar: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := printCurrent(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "metar: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// printCurrent prints the raw report of every given station
+func printCurrent(stations []string) error {
+	if len(stations) == 0 {
+		return fmt.Errorf("usage: metar <station>... | metar serve-collector -config <file>")
+	}
+
+	results, err := metar.DefaultClient.FetchStationsWeather(context.Background(), stations)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		fmt.Println(r.RawText)
+	}
+	return nil
+}
+
+// serveCollector runs the collector configured by the flags until the
+// context is cancelled
+func serveCollector(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("serve-collector", flag.ContinueOnError)
+	configFile := fs.String("config", "collector.yml", "Configuration file")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("Unexpected arguments: %v", fs.Args())
+	}
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		return err
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.logLevel()}))
+
+	p, err := newPipeline(cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if cfg.Listen != "" {
+		srv := &http.Server{Addr: cfg.Listen, Handler: p.handler()}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("status server failed", "error", err)
+				cancel()
+			}
+		}()
+		defer func() {
+			sctx, scancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer scancel()
+			srv.Shutdown(sctx)
+		}()
+	}
+
+	logger.Info("collector started", "stations", cfg.Stations, "interval", cfg.Interval)
+	p.collector.Run(ctx)
+	logger.Info("collector stopped")
+	return nil
+}
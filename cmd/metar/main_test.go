@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("serve-collector", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "metar")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	writeConfig := func(content string) string {
+		path := filepath.Join(dir, "collector.yml")
+		Expect(ioutil.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return path
+	}
+
+	It("should reject unknown flags", func() {
+		Expect(serveCollector(context.Background(), []string{"-unknown"})).NotTo(Succeed())
+	})
+
+	It("should reject positional arguments", func() {
+		path := writeConfig("stations: [EDDH]\n")
+		err := serveCollector(context.Background(), []string{"-config", path, "EDDH"})
+		Expect(err).To(MatchError(ContainSubstring("Unexpected arguments")))
+	})
+
+	It("should fail for a missing configuration file", func() {
+		Expect(serveCollector(context.Background(), []string{"-config", filepath.Join(dir, "missing.yml")})).NotTo(Succeed())
+	})
+
+	It("should collect until the context is cancelled", func() {
+		fetched := make(chan string, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fetched <- r.URL.Path
+			fmt.Fprint(w, "2016/12/27 12:50\nEDDH 271250Z 27012KT 9999 FEW030 03/M01 Q1012\n")
+		}))
+		defer server.Close()
+
+		path := writeConfig(fmt.Sprintf("stations: [EDDH]\ninterval: 1h\nsources:\n  - type: noaa\n    base_url: %s\nsinks:\n  - type: prometheus\n", server.URL))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- serveCollector(ctx, []string{"-config", path}) }()
+
+		Eventually(fetched).Should(Receive(Equal("/EDDH.TXT")))
+		cancel()
+		Eventually(done, 5*time.Second).Should(Receive(BeNil()))
+	})
+})
+
+var _ = Describe("config", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "metar")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	load := func(content string) (*config, error) {
+		path := filepath.Join(dir, "collector.yml")
+		Expect(ioutil.WriteFile(path, []byte(content), 0o600)).To(Succeed())
+		return loadConfig(path)
+	}
+
+	It("should parse the configuration", func() {
+		cfg, err := load("stations: [EDDH, EDDW]\ninterval: 5m\nsinks:\n  - type: bolt\n    path: metar.db\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Stations).To(Equal([]string{"EDDH", "EDDW"}))
+		Expect(cfg.Interval).To(Equal(5 * time.Minute))
+		Expect(cfg.Sinks).To(Equal([]sinkConfig{{Type: "bolt", Path: "metar.db"}}))
+	})
+
+	It("should default the interval", func() {
+		cfg, err := load("stations: [EDDH]\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Interval).To(Equal(10 * time.Minute))
+	})
+
+	It("should require stations", func() {
+		_, err := load("interval: 5m\n")
+		Expect(err).To(MatchError("No stations configured"))
+	})
+
+	It("should require a positive interval", func() {
+		_, err := load("stations: [EDDH]\ninterval: 0s\n")
+		Expect(err).To(MatchError("Interval must be positive, got 0s"))
+
+		_, err = load("stations: [EDDH]\ninterval: -5m\n")
+		Expect(err).To(MatchError("Interval must be positive, got -5m0s"))
+	})
+
+	It("should reject unknown keys", func() {
+		_, err := load("stations: [EDDH]\nstation: EDDW\n")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("pipeline", func() {
+	logger := slog.New(slog.NewTextHandler(ioutil.Discard, nil))
+
+	It("should reject unknown sinks", func() {
+		_, err := newPipeline(&config{Stations: []string{"EDDH"}, Sinks: []sinkConfig{{Type: "carrier-pigeon"}}}, logger)
+		Expect(err).To(MatchError(`Unknown sink type "carrier-pigeon"`))
+	})
+
+	It("should reject unknown schedules", func() {
+		_, err := newPipeline(&config{Stations: []string{"EDDH"}, Schedule: "hourly"}, logger)
+		Expect(err).To(MatchError(`Unknown schedule "hourly"`))
+	})
+
+	It("should not keep the database if the migration fails", func() {
+		p := &pipeline{}
+		_, err := p.sink(sinkConfig{Type: "postgres", DSN: "postgres://metar@127.0.0.1:1/metar?connect_timeout=1"}, logger)
+		Expect(err).To(HaveOccurred())
+		Expect(p.closers).To(BeEmpty())
+	})
+
+	It("should serve the metrics of the prometheus sink", func() {
+		p, err := newPipeline(&config{Stations: []string{"EDDH"}, Interval: time.Hour, Sinks: []sinkConfig{{Type: "prometheus"}}}, logger)
+		Expect(err).NotTo(HaveOccurred())
+		defer p.Close()
+
+		rec := httptest.NewRecorder()
+		p.handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(ContainSubstring("# TYPE metar_temperature_celsius gauge"))
+	})
+})
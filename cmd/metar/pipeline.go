@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	metar "github.com/Luzifer/go-metar"
+	"github.com/Luzifer/go-metar/boltstore"
+	"github.com/Luzifer/go-metar/pgstore"
+)
+
+// pipeline wires the collector, the rules engine and the sinks described
+// by the configuration
+type pipeline struct {
+	collector *metar.Collector
+	watcher   *metar.Watcher
+	metrics   *metar.PrometheusSink // Served at /metrics if configured
+	closers   []io.Closer
+}
+
+func newPipeline(cfg *config, logger *slog.Logger) (*pipeline, error) {
+	p := &pipeline{}
+
+	src, err := cfg.source()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []metar.ClientOption{metar.WithSource(src), metar.WithLogger(logger)}
+	if cfg.UserAgent != "" {
+		opts = append(opts, metar.WithUserAgent(cfg.UserAgent))
+	}
+	if cfg.Proxy != "" {
+		proxy, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy URL: %s", err)
+		}
+		opts = append(opts, metar.WithProxy(proxy))
+	}
+	client := metar.NewClient(opts...)
+
+	// The watcher is fed by the collector instead of polling on its own
+	p.watcher = metar.NewWatcher(client, cfg.Stations, cfg.Interval)
+	for _, rc := range cfg.Rules {
+		r, err := rc.rule()
+		if err != nil {
+			return nil, err
+		}
+		p.watcher.AddRule(r)
+	}
+	p.watcher.OnRuleEvent(func(ev metar.RuleEvent) {
+		logger.Info("rule changed", "rule", ev.Rule, "station", ev.Station, "tripped", ev.Tripped)
+	})
+
+	sinks := []metar.Sink{p.watcher}
+	for _, sc := range cfg.Sinks {
+		s, err := p.sink(sc, logger)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		if s != nil {
+			sinks = append(sinks, s)
+		}
+	}
+
+	p.collector = metar.NewCollector(client, cfg.Stations, cfg.Interval, sinks...)
+	switch cfg.Schedule {
+	case "", "fixed":
+	case "jitter":
+		p.collector.SetSchedule(metar.JitterSchedule(cfg.Interval))
+	case "adaptive":
+		// Due reports are fetched every minute, the interval is used to
+		// check for special reports in between
+		p.collector.SetSchedule(metar.NewAdaptiveSchedule(time.Minute, cfg.Interval))
+	default:
+		p.Close()
+		return nil, fmt.Errorf("Unknown schedule %q", cfg.Schedule)
+	}
+	return p, nil
+}
+
+func (c *config) source() (metar.Source, error) {
+	if len(c.Sources) == 0 {
+		return metar.ADDS{}, nil
+	}
+
+	var chain metar.MultiSource
+	for _, sc := range c.Sources {
+		s, err := sc.source()
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, s)
+	}
+
+	if len(chain) == 1 {
+		return chain[0], nil
+	}
+	return chain, nil
+}
+
+// sink creates the configured sink, nil is returned for sinks attached
+// to the watcher
+func (p *pipeline) sink(sc sinkConfig, logger *slog.Logger) (metar.Sink, error) {
+	switch sc.Type {
+	case "bolt":
+		s, err := boltstore.Open(sc.Path)
+		if err != nil {
+			return nil, err
+		}
+		p.closers = append(p.closers, s)
+		return s, nil
+
+	case "postgres":
+		db, err := sql.Open("pgx", sc.DSN)
+		if err != nil {
+			return nil, err
+		}
+		if err := pgstore.Migrate(context.Background(), db); err != nil {
+			db.Close()
+			return nil, err
+		}
+		p.closers = append(p.closers, db)
+		return pgstore.New(db), nil
+
+	case "webhook":
+		d := metar.NewWebhookDispatcher([]metar.Webhook{{URL: sc.URL, Secret: sc.Secret}}, metar.WithWebhookLogger(logger))
+		// The watcher delivers both the new observations and the rule events
+		d.Attach(p.watcher)
+		return nil, nil
+
+	case "prometheus":
+		if p.metrics == nil {
+			p.metrics = metar.NewPrometheusSink()
+			return p.metrics, nil
+		}
+		return nil, nil
+
+	case "log":
+		enc := json.NewEncoder(os.Stdout)
+		return metar.SinkFunc(func(_ context.Context, results []metar.Result) error {
+			for _, r := range results {
+				if err := enc.Encode(r); err != nil {
+					return err
+				}
+			}
+			return nil
+		}), nil
+	}
+
+	return nil, fmt.Errorf("Unknown sink type %q", sc.Type)
+}
+
+// handler serves the status of the collector and the metrics of the
+// prometheus sink
+func (p *pipeline) handler() http.Handler {
+	if p.metrics == nil {
+		return p.collector.Handler()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", p.metrics)
+	mux.Handle("/", p.collector.Handler())
+	return mux
+}
+
+// Close releases the resources of the sinks
+func (p *pipeline) Close() {
+	for _, c := range p.closers {
+		c.Close()
+	}
+}
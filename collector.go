@@ -0,0 +1,279 @@
+package metar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink receives the new observations collected by a Collector. The
+// storage packages (boltstore, pgstore) and the WebhookDispatcher
+// implement it.
+type Sink interface {
+	Write(ctx context.Context, results []Result) error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(ctx context.Context, results []Result) error
+
+// Write calls the function
+func (f SinkFunc) Write(ctx context.Context, results []Result) error { return f(ctx, results) }
+
+// Collector periodically fetches the observations of a list of stations
+// and writes those not seen before to its sinks
+type Collector struct {
+	client   *Client
+	stations []string
+	interval time.Duration
+	sinks    []Sink
+	schedule Schedule
+
+	mu     sync.Mutex
+	last   []map[string]time.Time // Newest observation written per sink and station
+	status map[string]*StationStatus
+	ready  bool
+}
+
+// StationStatus describes the collection state of a station
+type StationStatus struct {
+	Station         string    `json:"station"`
+	LastFetch       time.Time `json:"last_fetch"`       // Last time an observation of the station was fetched
+	LastObservation time.Time `json:"last_observation"` // Observation time of the newest observation
+	LastError       string    `json:"last_error,omitempty"`
+}
+
+// NewCollector creates a Collector fetching the stations every interval
+// using the client, which also defines the Source to use. A nil client
+// uses the DefaultClient.
+func NewCollector(c *Client, stations []string, interval time.Duration, sinks ...Sink) *Collector {
+	if c == nil {
+		c = DefaultClient
+	}
+
+	last := make([]map[string]time.Time, len(sinks))
+	for i := range last {
+		last[i] = make(map[string]time.Time)
+	}
+
+	return &Collector{
+		client:   c,
+		stations: stations,
+		interval: interval,
+		sinks:    sinks,
+		schedule: FixedSchedule(interval),
+		last:     last,
+		status:   make(map[string]*StationStatus),
+	}
+}
+
+// SetSchedule sets when the stations are fetched by Run (defaults to a
+// FixedSchedule of the interval). It must not be called while running.
+func (c *Collector) SetSchedule(s Schedule) {
+	c.schedule = s
+}
+
+// Run collects until the context is cancelled, the stations due at the
+// same time are fetched using a single request. A collection in progress
+// when the context is cancelled still delivers its results to the sinks
+// before Run returns. Failed collections are logged and retried when the
+// stations are due next.
+func (c *Collector) Run(ctx context.Context) error {
+	next := make(map[string]time.Time, len(c.stations))
+	for _, st := range c.stations {
+		key := c.client.stationKey(st)
+		next[key] = c.schedule.Next(key, time.Time{})
+	}
+
+	for {
+		now := time.Now()
+
+		var due []string
+		for _, st := range c.stations {
+			if !next[c.client.stationKey(st)].After(now) {
+				due = append(due, st)
+			}
+		}
+
+		if len(due) > 0 {
+			if err := c.collect(ctx, due); err != nil && ctx.Err() == nil {
+				c.client.logger.Warn("collecting observations failed", "stations", due, "error", err)
+			}
+			for _, st := range due {
+				key := c.client.stationKey(st)
+				next[key] = c.schedule.Next(key, now)
+			}
+		}
+
+		wake := now.Add(c.interval)
+		for _, t := range next {
+			if t.Before(wake) {
+				wake = t
+			}
+		}
+
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Collect fetches the stations once and writes the new observations to
+// all sinks. A failing sink does not prevent the others from receiving
+// the observations and receives them again with the next collection.
+func (c *Collector) Collect(ctx context.Context) error {
+	return c.collect(ctx, c.stations)
+}
+
+func (c *Collector) collect(ctx context.Context, stations []string) error {
+	results, err := c.client.FetchStationsWeather(ctx, stations)
+	c.updateStatus(stations, results, err)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		c.schedule.Observed(r)
+	}
+
+	// Sinks are written without cancellation to not lose fetched
+	// observations during shutdown
+	wctx := context.WithoutCancel(ctx)
+
+	var errs []string
+	for i, s := range c.sinks {
+		fresh := c.unwritten(i, results)
+		if len(fresh) == 0 {
+			continue
+		}
+
+		if err := s.Write(wctx, fresh); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		c.markWritten(i, fresh)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Writing to sinks failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// unwritten returns the results newer than the last observation of their
+// station written to the sink
+func (c *Collector) unwritten(sink int, results []Result) []Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var fresh []Result
+	for _, r := range results {
+		if last, ok := c.last[sink][strings.ToUpper(r.StationID)]; ok && !r.ObservationTime.After(last) {
+			continue
+		}
+		fresh = append(fresh, r)
+	}
+	return fresh
+}
+
+// markWritten records the results as successfully written to the sink
+func (c *Collector) markWritten(sink int, results []Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, r := range results {
+		key := strings.ToUpper(r.StationID)
+		if r.ObservationTime.After(c.last[sink][key]) {
+			c.last[sink][key] = r.ObservationTime
+		}
+	}
+}
+
+// updateStatus records the outcome of a fetch on the station status
+func (c *Collector) updateStatus(stations []string, results []Result, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for _, st := range stations {
+		key := c.client.stationKey(st)
+		if c.status[key] == nil {
+			c.status[key] = &StationStatus{Station: key}
+		}
+		if err != nil {
+			c.status[key].LastError = err.Error()
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	c.ready = true
+	for _, r := range results {
+		st, ok := c.status[strings.ToUpper(r.StationID)]
+		if !ok {
+			continue
+		}
+		st.LastFetch = now
+		st.LastError = ""
+		if r.ObservationTime.After(st.LastObservation) {
+			st.LastObservation = r.ObservationTime
+		}
+	}
+}
+
+// Ready reports whether a collection has succeeded
+func (c *Collector) Ready() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ready
+}
+
+// Status returns the collection state of all stations in the order of
+// the station list
+func (c *Collector) Status() []StationStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]StationStatus, 0, len(c.stations))
+	for _, st := range c.stations {
+		key := c.client.stationKey(st)
+		if s, ok := c.status[key]; ok {
+			out = append(out, *s)
+			continue
+		}
+		out = append(out, StationStatus{Station: key})
+	}
+	return out
+}
+
+// Handler serves the endpoints used by orchestration systems:
+// /healthz always reports the process as alive, /readyz succeeds once a
+// collection succeeded and /stations lists the Status as JSON.
+func (c *Collector) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !c.Ready() {
+			http.Error(w, "no successful collection yet", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/stations", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c.Status())
+	})
+	return mux
+}
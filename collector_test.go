@@ -0,0 +1,160 @@
+package metar_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Collector", func() {
+	var (
+		src     *staticSource
+		written [][]Result
+		sink    = SinkFunc(func(ctx context.Context, results []Result) error {
+			written = append(written, results)
+			return nil
+		})
+		base = time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		written = nil
+		src = &staticSource{name: "static", results: []Result{
+			{StationID: "EDDH", ObservationTime: base},
+			{StationID: "EDDW", ObservationTime: base},
+		}}
+	})
+
+	It("should write only new observations to the sinks", func() {
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH", "EDDW"}, time.Hour, sink)
+
+		Expect(c.Collect(context.Background())).To(Succeed())
+		Expect(written).To(HaveLen(1))
+		Expect(written[0]).To(HaveLen(2))
+
+		src.results[1].ObservationTime = base.Add(30 * time.Minute)
+		Expect(c.Collect(context.Background())).To(Succeed())
+		Expect(written).To(HaveLen(2))
+		Expect(written[1]).To(HaveLen(1))
+		Expect(written[1][0].StationID).To(Equal("EDDW"))
+
+		Expect(c.Collect(context.Background())).To(Succeed())
+		Expect(written).To(HaveLen(2))
+	})
+
+	It("should write to all sinks even if one fails", func() {
+		failing := SinkFunc(func(context.Context, []Result) error { return errors.New("Disk full") })
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour, failing, sink)
+
+		Expect(c.Collect(context.Background())).To(MatchError("Writing to sinks failed: Disk full"))
+		Expect(written).To(HaveLen(1))
+	})
+
+	It("should retry the observations a sink failed to write", func() {
+		var (
+			fail     = true
+			attempts int
+		)
+		flaky := SinkFunc(func(context.Context, []Result) error {
+			attempts++
+			if fail {
+				return errors.New("Disk full")
+			}
+			return nil
+		})
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour, flaky, sink)
+
+		Expect(c.Collect(context.Background())).NotTo(Succeed())
+		fail = false
+		Expect(c.Collect(context.Background())).To(Succeed())
+		Expect(attempts).To(Equal(2))
+		Expect(written).To(HaveLen(1))
+
+		Expect(c.Collect(context.Background())).To(Succeed())
+		Expect(attempts).To(Equal(2))
+	})
+
+	It("should serve its status", func() {
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH", "edfm"}, time.Hour, sink)
+		server := httptest.NewServer(c.Handler())
+		defer server.Close()
+
+		get := func(path string) (int, string) {
+			res, err := http.Get(server.URL + path)
+			Expect(err).NotTo(HaveOccurred())
+			defer res.Body.Close()
+			body, _ := ioutil.ReadAll(res.Body)
+			return res.StatusCode, string(body)
+		}
+
+		code, _ := get("/healthz")
+		Expect(code).To(Equal(http.StatusOK))
+		code, _ = get("/readyz")
+		Expect(code).To(Equal(http.StatusServiceUnavailable))
+
+		Expect(c.Collect(context.Background())).To(Succeed())
+		code, _ = get("/readyz")
+		Expect(code).To(Equal(http.StatusOK))
+
+		status := c.Status()
+		Expect(status).To(HaveLen(2))
+		Expect(status[0].Station).To(Equal("EDDH"))
+		Expect(status[0].LastFetch).To(BeTemporally("~", time.Now(), time.Second))
+		Expect(status[0].LastObservation).To(Equal(base))
+		Expect(status[1].Station).To(Equal("EDFM"))
+		Expect(status[1].LastFetch.IsZero()).To(BeTrue())
+
+		code, body := get("/stations")
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(`"station":"EDFM"`))
+	})
+
+	It("should report the status of aliased stations", func() {
+		c := NewCollector(NewClient(WithStationAliases(nil), WithSource(src)), []string{"HAM"}, time.Hour, sink)
+		Expect(c.Collect(context.Background())).To(Succeed())
+
+		status := c.Status()
+		Expect(status).To(HaveLen(1))
+		Expect(status[0].Station).To(Equal("EDDH"))
+		Expect(status[0].LastObservation).To(Equal(base))
+	})
+
+	It("should record fetch errors on the status", func() {
+		src.err = errors.New("Upstream down")
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour, sink)
+		Expect(c.Collect(context.Background())).NotTo(Succeed())
+		Expect(c.Ready()).To(BeFalse())
+		Expect(c.Status()[0].LastError).To(Equal("Upstream down"))
+	})
+
+	It("should wait for the first slot of the schedule", func() {
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH", "EDDW"}, time.Hour, sink)
+		c.SetSchedule(JitterSchedule(24 * time.Hour))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		Expect(c.Run(ctx)).To(Equal(context.DeadlineExceeded))
+		Expect(src.queries).To(BeEmpty())
+	})
+
+	It("should stop running when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour, SinkFunc(func(ctx context.Context, results []Result) error {
+			cancel()
+			Expect(ctx.Err()).NotTo(HaveOccurred())
+			return nil
+		}))
+
+		Expect(c.Run(ctx)).To(Equal(context.Canceled))
+	})
+
+})
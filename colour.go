@@ -0,0 +1,83 @@
+package metar
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ColourState is a military (NATO / UK MIL) aerodrome colour state
+// derived from visibility and cloud base
+type ColourState string
+
+// Colour states from best to worst conditions
+const (
+	ColourStateUnknown ColourState = ""
+	ColourStateBLU     ColourState = "BLU" // Visibility 8000m or more, cloud base 2500ft or more
+	ColourStateWHT     ColourState = "WHT" // Visibility 5000m or more, cloud base 1500ft or more
+	ColourStateGRN     ColourState = "GRN" // Visibility 3700m or more, cloud base 700ft or more
+	ColourStateYLO     ColourState = "YLO" // Visibility 1600m or more, cloud base 300ft or more
+	ColourStateAMB     ColourState = "AMB" // Visibility 800m or more, cloud base 200ft or more
+	ColourStateRED     ColourState = "RED" // Visibility below 800m or cloud base below 200ft
+)
+
+var (
+	colourStateRegex = regexp.MustCompile(`^(BLACK)?(BLU\+?|WHT|GRN|YLO[12]?|AMB|RED)$`)
+
+	colourStateLimits = []struct {
+		state       ColourState
+		visibilityM float64
+		cloudBaseFt int64
+	}{
+		{ColourStateBLU, 8000, 2500},
+		{ColourStateWHT, 5000, 1500},
+		{ColourStateGRN, 3700, 700},
+		{ColourStateYLO, 1600, 300},
+		{ColourStateAMB, 800, 200},
+	}
+)
+
+// ColourStateFor computes the colour state from the visibility in meters
+// and the base of the lowest cloud layer covering 3/8 or more of the sky
+// in feet, a negative cloud base means no such layer is reported
+func ColourStateFor(visibilityM float64, cloudBaseFt int64) ColourState {
+	for _, l := range colourStateLimits {
+		if visibilityM >= l.visibilityM && (cloudBaseFt < 0 || cloudBaseFt >= l.cloudBaseFt) {
+			return l.state
+		}
+	}
+	return ColourStateRED
+}
+
+// ColourState computes the colour state from the visibility and the cloud
+// layers of the report. If no visibility is reported the colour state is
+// unknown.
+func (r Result) ColourState() ColourState {
+	hasVis := r.Present.Has(FieldVisibilityStatute)
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind == TokenVisibility {
+			hasVis = true
+		}
+	}
+	if !hasVis {
+		return ColourStateUnknown
+	}
+
+	cloudBase := lowestBase(r.CloudLayers(), func(l CloudLayer) bool { return l.Cover != SkyCoverFEW })
+	return ColourStateFor(r.Visibility().Meters(), cloudBase)
+}
+
+// ReportedColourState returns the colour state reported in the raw text of
+// military stations. Black is set if the aerodrome is reported unusable
+// for other reasons than weather (BLACKWHT). UK sub-states are reported
+// as their NATO state (YLO1 and YLO2 as YLO, BLU+ as BLU).
+func (r Result) ReportedColourState() (state ColourState, black, ok bool) {
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind != TokenColourState {
+			continue
+		}
+
+		m := colourStateRegex.FindStringSubmatch(t.Text)
+		return ColourState(strings.TrimRight(m[2], "+12")), m[1] != "", true
+	}
+	return ColourStateUnknown, false, false
+}
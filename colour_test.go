@@ -0,0 +1,50 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Colour state", func() {
+
+	table.DescribeTable("colour states from visibility and cloud base",
+		func(vis float64, base int64, expected ColourState) {
+			Expect(ColourStateFor(vis, base)).To(Equal(expected))
+		},
+		table.Entry("no cloud", 10000.0, int64(-1), ColourStateBLU),
+		table.Entry("limited by cloud", 10000.0, int64(1200), ColourStateGRN),
+		table.Entry("limited by visibility", 4000.0, int64(3000), ColourStateGRN),
+		table.Entry("yellow", 2000.0, int64(400), ColourStateYLO),
+		table.Entry("amber", 800.0, int64(200), ColourStateAMB),
+		table.Entry("red", 600.0, int64(-1), ColourStateRED),
+	)
+
+	It("should compute the colour state of a report", func() {
+		r := Result{RawText: "METAR EGXC 211020Z 27012KT 6000 FEW004 SCT012 BKN030 12/09 Q1012 GRN"}
+		Expect(r.ColourState()).To(Equal(ColourStateGRN))
+
+		r.RawText = "METAR EGXC 211020Z 27012KT CAVOK 12/09 Q1012"
+		Expect(r.ColourState()).To(Equal(ColourStateBLU))
+
+		r.RawText = ""
+		Expect(r.ColourState()).To(Equal(ColourStateUnknown))
+	})
+
+	It("should parse the reported colour state", func() {
+		state, black, ok := Result{RawText: "METAR EGXC 211020Z 27012KT 6000 SCT012 12/09 Q1012 BLACKGRN BECMG WHT"}.ReportedColourState()
+		Expect(ok).To(BeTrue())
+		Expect(black).To(BeTrue())
+		Expect(state).To(Equal(ColourStateGRN))
+
+		state, _, ok = Result{RawText: "METAR EGVN 211020Z 27012KT 2000 BR BKN004 12/11 Q1012 YLO2"}.ReportedColourState()
+		Expect(ok).To(BeTrue())
+		Expect(state).To(Equal(ColourStateYLO))
+
+		_, _, ok = Result{RawText: "METAR EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018"}.ReportedColourState()
+		Expect(ok).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,98 @@
+package metar
+
+import "math"
+
+// FeelsLikeModel selects the regional convention used by Result.FeelsLike
+type FeelsLikeModel int
+
+// Supported regional conventions
+const (
+	// FeelsLikeNOAA uses the wind chill below 10 °C and the heat index above 26.7 °C (US)
+	FeelsLikeNOAA FeelsLikeModel = iota
+	// FeelsLikeCanada uses the wind chill below 10 °C and the humidex above 20 °C
+	FeelsLikeCanada
+	// FeelsLikeAustralia uses the apparent temperature of the Bureau of Meteorology
+	FeelsLikeAustralia
+)
+
+// RelativeHumidity computes the relative humidity in percent from
+// temperature and dewpoint (celsius) using the Magnus formula
+func RelativeHumidity(tempC, dewpointC float64) float64 {
+	return 100 * vaporPressure(dewpointC) / vaporPressure(tempC)
+}
+
+// vaporPressure returns the saturation vapor pressure (hPa) at the temperature
+func vaporPressure(tempC float64) float64 {
+	return 6.112 * math.Exp(17.62*tempC/(243.12+tempC))
+}
+
+// WindChill computes the wind chill temperature (celsius) using the
+// formula of Environment Canada and the NWS. The temperature is returned
+// unchanged outside of the defined range (at most 10 °C, wind above 4.8 km/h).
+func WindChill(tempC, windKmh float64) float64 {
+	if tempC > 10 || windKmh <= 4.8 {
+		return tempC
+	}
+	v := math.Pow(windKmh, 0.16)
+	return 13.12 + 0.6215*tempC - 11.37*v + 0.3965*tempC*v
+}
+
+// HeatIndex computes the heat index (celsius) using the Rothfusz regression
+// of the NWS. The temperature is returned unchanged below 26.7 °C (80 °F).
+func HeatIndex(tempC, relativeHumidity float64) float64 {
+	if tempC < 26.7 {
+		return tempC
+	}
+
+	t, rh := tempC*9/5+32, relativeHumidity
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+		6.83783e-3*t*t - 5.481717e-2*rh*rh + 1.22874e-3*t*t*rh +
+		8.5282e-4*t*rh*rh - 1.99e-6*t*t*rh*rh
+	return FahrenheitToCelsius(hi)
+}
+
+// Humidex computes the humidex of Environment Canada from temperature and
+// dewpoint (celsius)
+func Humidex(tempC, dewpointC float64) float64 {
+	e := 6.11 * math.Exp(5417.7530*(1/273.16-1/(273.15+dewpointC)))
+	return tempC + 0.5555*(e-10)
+}
+
+// ApparentTemperature computes the apparent temperature (celsius) of the
+// Australian Bureau of Meteorology (Steadman) from temperature (celsius),
+// relative humidity (percent) and wind speed (meters per second)
+func ApparentTemperature(tempC, relativeHumidity, windMs float64) float64 {
+	e := relativeHumidity / 100 * 6.105 * math.Exp(17.27*tempC/(237.7+tempC))
+	return tempC + 0.33*e - 0.70*windMs - 4.00
+}
+
+// FeelsLike returns the perceived temperature (celsius) using the regional
+// convention. It requires temperature and dewpoint to be reported.
+func (r Result) FeelsLike(model FeelsLikeModel) (float64, bool) {
+	if !r.Present.Has(FieldTemperature) || !r.Present.Has(FieldDewpoint) {
+		return 0, false
+	}
+
+	var (
+		t    = r.Temperature
+		rh   = RelativeHumidity(r.Temperature, r.Dewpoint)
+		wind = KtsToMs(float64(r.WindSpeed))
+	)
+
+	switch model {
+	case FeelsLikeAustralia:
+		return ApparentTemperature(t, rh, wind), true
+
+	case FeelsLikeCanada:
+		if t > 20 {
+			return math.Max(t, Humidex(t, r.Dewpoint)), true
+		}
+		return WindChill(t, wind*3.6), true
+
+	default:
+		if t >= 26.7 {
+			return HeatIndex(t, rh), true
+		}
+		return WindChill(t, wind*3.6), true
+	}
+}
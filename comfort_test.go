@@ -0,0 +1,55 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Comfort indices", func() {
+
+	It("should compute the relative humidity", func() {
+		Expect(RelativeHumidity(20, 20)).To(BeNumerically("~", 100, 1e-9))
+		Expect(RelativeHumidity(20, 10)).To(BeNumerically("~", 52.5, 0.1))
+	})
+
+	It("should compute the wind chill", func() {
+		Expect(WindChill(-10, 30)).To(BeNumerically("~", -19.5, 0.1))
+		Expect(WindChill(15, 30)).To(Equal(15.0))
+		Expect(WindChill(-10, 3)).To(Equal(-10.0))
+	})
+
+	It("should compute the heat index", func() {
+		Expect(HeatIndex(32, 60)).To(BeNumerically("~", 37.1, 0.1))
+		Expect(HeatIndex(20, 60)).To(Equal(20.0))
+	})
+
+	It("should compute the humidex", func() {
+		Expect(Humidex(30, 15)).To(BeNumerically("~", 34.0, 0.1))
+	})
+
+	It("should compute the apparent temperature", func() {
+		Expect(ApparentTemperature(30, 50, 5)).To(BeNumerically("~", 29.5, 0.1))
+	})
+
+	It("should select the regional convention", func() {
+		r := Result{Temperature: 30, Dewpoint: 15, WindSpeed: 10}
+		r.Present.Add(FieldTemperature)
+		r.Present.Add(FieldDewpoint)
+
+		us, ok := r.FeelsLike(FeelsLikeNOAA)
+		Expect(ok).To(BeTrue())
+		Expect(us).To(BeNumerically("~", 29.7, 0.1))
+
+		ca, _ := r.FeelsLike(FeelsLikeCanada)
+		Expect(ca).To(BeNumerically("~", 34.0, 0.1))
+
+		au, _ := r.FeelsLike(FeelsLikeAustralia)
+		Expect(au).To(BeNumerically("~", 28.0, 0.1))
+
+		_, ok = Result{Temperature: 30}.FeelsLike(FeelsLikeNOAA)
+		Expect(ok).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,31 @@
+package metar
+
+var compassPoints = [16]string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// DegreesToCompass converts a direction in degrees into a label of the
+// 16-point compass rose
+func DegreesToCompass(deg int) string {
+	deg = (deg%360 + 360) % 360
+	return compassPoints[(deg*100+1125)/2250%16]
+}
+
+// WindDirectionCompass returns the wind direction as 16-point compass
+// label, "VRB" for variable wind or an empty string if the wind is calm
+// or not reported
+func (r Result) WindDirectionCompass() string {
+	if !r.Present.Has(FieldWindDirDegrees) {
+		return ""
+	}
+
+	if r.WindDirDegrees == 0 {
+		if r.WindSpeed > 0 {
+			return "VRB"
+		}
+		return ""
+	}
+
+	return DegreesToCompass(int(r.WindDirDegrees))
+}
@@ -0,0 +1,42 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compass", func() {
+
+	table.DescribeTable("degrees to compass points",
+		func(deg int, expected string) {
+			Expect(DegreesToCompass(deg)).To(Equal(expected))
+		},
+		table.Entry("north", 0, "N"),
+		table.Entry("north as 360", 360, "N"),
+		table.Entry("just below NNE", 11, "N"),
+		table.Entry("NNE boundary", 12, "NNE"),
+		table.Entry("east", 90, "E"),
+		table.Entry("south south west", 200, "SSW"),
+		table.Entry("north north west", 340, "NNW"),
+		table.Entry("wrapping to north", 350, "N"),
+		table.Entry("negative", -90, "W"),
+	)
+
+	It("should label the wind direction of a result", func() {
+		r := Result{WindDirDegrees: 240, WindSpeed: 12}
+		Expect(r.WindDirectionCompass()).To(BeEmpty())
+
+		r.Present.Add(FieldWindDirDegrees)
+		Expect(r.WindDirectionCompass()).To(Equal("WSW"))
+
+		r.WindDirDegrees = 0
+		Expect(r.WindDirectionCompass()).To(Equal("VRB"))
+
+		r.WindSpeed = 0
+		Expect(r.WindDirectionCompass()).To(BeEmpty())
+	})
+
+})
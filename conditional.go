@@ -0,0 +1,103 @@
+package metar
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxConditionalEntries limits the number of responses kept for
+// conditional requests, an arbitrary entry is dropped when exceeded
+const maxConditionalEntries = 256
+
+// WithConditionalRequests keeps the last response of every requested URL
+// carrying an ETag or Last-Modified header and revalidates it using
+// If-None-Match / If-Modified-Since. A 304 Not Modified answer is served
+// from the kept response so polling unchanged data does not download it
+// again: The results carry the status code of the kept response while
+// FetchInfo reports the 304 and sets NotModified. Upstreams not sending
+// validators are requested as usual.
+func WithConditionalRequests() ClientOption {
+	return func(c *Client) {
+		c.conditional = &conditionalCache{entries: make(map[string]conditionalEntry)}
+	}
+}
+
+// conditionalCache keeps the validated responses by URL
+type conditionalCache struct {
+	mu      sync.Mutex
+	entries map[string]conditionalEntry
+}
+
+type conditionalEntry struct {
+	header http.Header
+	body   []byte
+}
+
+// prepare adds the validators of the kept response to the request
+func (cc *conditionalCache) prepare(req *http.Request) {
+	cc.mu.Lock()
+	e, ok := cc.entries[req.URL.String()]
+	cc.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if etag := e.header.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lm := e.header.Get("Last-Modified"); lm != "" {
+		req.Header.Set("If-Modified-Since", lm)
+	}
+}
+
+// handle replaces a 304 response by the kept response and keeps
+// successful responses carrying validators
+func (cc *conditionalCache) handle(req *http.Request, res *http.Response) (*http.Response, error) {
+	key := req.URL.String()
+
+	switch {
+	case res.StatusCode == http.StatusNotModified:
+		cc.mu.Lock()
+		e, ok := cc.entries[key]
+		cc.mu.Unlock()
+		if !ok {
+			return res, nil
+		}
+
+		res.Body.Close()
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         res.Proto,
+			ProtoMajor:    res.ProtoMajor,
+			ProtoMinor:    res.ProtoMinor,
+			Header:        e.header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(e.body)),
+			ContentLength: int64(len(e.body)),
+			Request:       req,
+		}, nil
+
+	case res.StatusCode == http.StatusOK && (res.Header.Get("ETag") != "" || res.Header.Get("Last-Modified") != ""):
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		cc.mu.Lock()
+		if _, known := cc.entries[key]; !known && len(cc.entries) >= maxConditionalEntries {
+			for k := range cc.entries {
+				delete(cc.entries, k)
+				break
+			}
+		}
+		cc.entries[key] = conditionalEntry{header: res.Header.Clone(), body: body}
+		cc.mu.Unlock()
+
+		res.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return res, nil
+}
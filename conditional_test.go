@@ -0,0 +1,88 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conditional requests", func() {
+
+	var (
+		ifNoneMatch []string
+		etag        string
+		server      *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ifNoneMatch = nil
+		etag = `"v1"`
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+			if etag != "" {
+				w.Header().Set("ETag", etag)
+				if r.Header.Get("If-None-Match") == etag {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+			}
+			w.Write([]byte(singleResultXML))
+		}))
+	})
+
+	AfterEach(func() { server.Close() })
+
+	It("should revalidate unchanged responses", func() {
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}), WithConditionalRequests())
+
+		for i := 0; i < 2; i++ {
+			r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.StationID).To(Equal("EDDH"))
+			Expect(r.StatusCode).To(Equal(http.StatusOK))
+		}
+		Expect(ifNoneMatch).To(Equal([]string{"", `"v1"`}))
+	})
+
+	It("should report revalidated responses to the instrumentation", func() {
+		inst := &recordingInstrumentation{}
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}), WithConditionalRequests(), WithInstrumentation(inst))
+
+		for i := 0; i < 2; i++ {
+			_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(inst.infos).To(HaveLen(2))
+		Expect(inst.infos[0].StatusCode).To(Equal(http.StatusOK))
+		Expect(inst.infos[0].NotModified).To(BeFalse())
+		Expect(inst.infos[1].StatusCode).To(Equal(http.StatusNotModified))
+		Expect(inst.infos[1].NotModified).To(BeTrue())
+	})
+
+	It("should request as usual without validators", func() {
+		etag = ""
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}), WithConditionalRequests())
+
+		for i := 0; i < 2; i++ {
+			_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(ifNoneMatch).To(Equal([]string{"", ""}))
+	})
+
+	It("should not send validators by default", func() {
+		client := NewClient(WithSource(ADDS{BaseURL: server.URL}))
+
+		for i := 0; i < 2; i++ {
+			_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(ifNoneMatch).To(Equal([]string{"", ""}))
+	})
+
+})
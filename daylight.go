@@ -0,0 +1,77 @@
+package metar
+
+import (
+	"math"
+	"time"
+)
+
+// Daylight describes the light conditions defined by the sun's elevation
+type Daylight int
+
+// Light conditions
+const (
+	DaylightUnknown       Daylight = iota // Station position not reported
+	DaylightDay                           // Sun above the horizon
+	DaylightCivilTwilight                 // Sun less than 6° below the horizon
+	DaylightNight                         // Sun 6° or more below the horizon
+)
+
+var daylightNames = []string{"unknown", "day", "civil twilight", "night"}
+
+func (d Daylight) String() string {
+	if d < 0 || int(d) >= len(daylightNames) {
+		return "unknown"
+	}
+	return daylightNames[d]
+}
+
+// Elevations of the sun's center bounding the light conditions, sunrise
+// and sunset account for refraction and the sun's radius
+const (
+	sunriseElevation       = -0.833
+	civilTwilightElevation = -6
+)
+
+// SolarElevation returns the elevation of the sun's center above the
+// horizon in degrees at the given position and time (accurate to about
+// 0.01°, not corrected for refraction)
+func SolarElevation(lat, lon float64, t time.Time) float64 {
+	rad := math.Pi / 180
+
+	// Days since J2000.0
+	n := float64(t.UTC().UnixNano())/float64(24*time.Hour) + 2440587.5 - 2451545.0
+
+	meanLon := math.Mod(280.460+0.9856474*n, 360)
+	anomaly := (357.528 + 0.9856003*n) * rad
+	eclLon := (meanLon + 1.915*math.Sin(anomaly) + 0.020*math.Sin(2*anomaly)) * rad
+	obliquity := (23.439 - 0.0000004*n) * rad
+
+	ra := math.Atan2(math.Cos(obliquity)*math.Sin(eclLon), math.Cos(eclLon))
+	dec := math.Asin(math.Sin(obliquity) * math.Sin(eclLon))
+
+	gmst := math.Mod(18.697374558+24.06570982441908*n, 24)
+	hourAngle := gmst*15*rad + lon*rad - ra
+
+	return math.Asin(math.Sin(lat*rad)*math.Sin(dec)+math.Cos(lat*rad)*math.Cos(dec)*math.Cos(hourAngle)) / rad
+}
+
+// DaylightAt classifies the light conditions at the position and time
+func DaylightAt(lat, lon float64, t time.Time) Daylight {
+	switch e := SolarElevation(lat, lon, t); {
+	case e >= sunriseElevation:
+		return DaylightDay
+	case e >= civilTwilightElevation:
+		return DaylightCivilTwilight
+	default:
+		return DaylightNight
+	}
+}
+
+// Daylight classifies the light conditions at the station when the
+// observation was taken. It requires the station position to be reported.
+func (r Result) Daylight() Daylight {
+	if !r.hasPosition() {
+		return DaylightUnknown
+	}
+	return DaylightAt(r.Latitude, r.Longitude, r.ObservationTime)
+}
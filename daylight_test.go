@@ -0,0 +1,39 @@
+package metar_test
+
+import (
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Daylight", func() {
+
+	It("should compute the solar elevation", func() {
+		// Hamburg at solar noon on the summer solstice: 90° - 53.63° + 23.44°
+		noon := time.Date(2016, 6, 20, 11, 13, 0, 0, time.UTC)
+		Expect(SolarElevation(53.63, 10.0, noon)).To(BeNumerically("~", 59.8, 0.2))
+	})
+
+	table.DescribeTable("light conditions in Hamburg",
+		func(t time.Time, expected Daylight) {
+			r := Result{Latitude: 53.63, Longitude: 10.0, ObservationTime: t}
+			r.Present.Add(FieldLatitude)
+			r.Present.Add(FieldLongitude)
+			Expect(r.Daylight()).To(Equal(expected))
+		},
+		// Sunset on 2016-12-21 is at 14:59 UTC, civil dusk at 15:43 UTC
+		table.Entry("afternoon", time.Date(2016, 12, 21, 14, 30, 0, 0, time.UTC), DaylightDay),
+		table.Entry("dusk", time.Date(2016, 12, 21, 15, 20, 0, 0, time.UTC), DaylightCivilTwilight),
+		table.Entry("evening", time.Date(2016, 12, 21, 16, 30, 0, 0, time.UTC), DaylightNight),
+	)
+
+	It("should require the station position", func() {
+		Expect(Result{}.Daylight()).To(Equal(DaylightUnknown))
+		Expect(DaylightCivilTwilight.String()).To(Equal("civil twilight"))
+	})
+
+})
@@ -0,0 +1,65 @@
+package metar
+
+import "reflect"
+
+// Change describes a field differing between two observations
+type Change struct {
+	Field  Field
+	Before interface{}
+	After  interface{}
+	// Delta is After minus Before for numeric fields. For the wind
+	// direction it is the shortest rotation in degrees (positive when
+	// veering clockwise) and zero if one of the directions is variable.
+	Delta float64
+}
+
+// diffFields are the weather fields compared by Diff
+var diffFields = []Field{
+	FieldTemperature, FieldDewpoint, FieldWindDirDegrees, FieldWindSpeed, FieldWindGust,
+	FieldVisibilityStatute, FieldAltimeter, FieldSeaLevelPressure, FieldWXString,
+	FieldSkyCondition, FieldFlightCategory,
+}
+
+// Diff returns the weather fields changed between the previous and the next
+// observation. The sky condition is compared by its SkyCover. Nil is
+// returned if one of the results is nil.
+func Diff(prev, next *Result) []Change {
+	if prev == nil || next == nil {
+		return nil
+	}
+
+	var (
+		changes []Change
+		pv      = reflect.ValueOf(*prev)
+		nv      = reflect.ValueOf(*next)
+	)
+
+	for _, f := range diffFields {
+		before, after := pv.Field(resultFieldIndex[f]), nv.Field(resultFieldIndex[f])
+		if f == FieldSkyCondition {
+			before, after = before.FieldByName("SkyCover"), after.FieldByName("SkyCover")
+		}
+
+		if before.Interface() == after.Interface() {
+			continue
+		}
+
+		c := Change{Field: f, Before: before.Interface(), After: after.Interface()}
+		switch before.Kind() {
+		case reflect.Int64:
+			c.Delta = float64(after.Int() - before.Int())
+		case reflect.Float64:
+			c.Delta = after.Float() - before.Float()
+		}
+
+		if f == FieldWindDirDegrees {
+			c.Delta = 0
+			if prev.WindDirDegrees != 0 && next.WindDirDegrees != 0 {
+				c.Delta = float64((next.WindDirDegrees-prev.WindDirDegrees+540)%360 - 180)
+			}
+		}
+
+		changes = append(changes, c)
+	}
+	return changes
+}
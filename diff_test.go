@@ -0,0 +1,47 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+
+	It("should report changed fields with deltas", func() {
+		old := &Result{Temperature: 12, WindDirDegrees: 350, WindSpeed: 8, Altimeter: 30.01, FlightCategory: FlightCategoryVFR}
+		old.SkyCondition.SkyCover = SkyCoverFEW
+		cur := &Result{Temperature: 9.5, WindDirDegrees: 20, WindSpeed: 8, Altimeter: 29.95, FlightCategory: FlightCategoryMVFR}
+		cur.SkyCondition.SkyCover = SkyCoverBKN
+
+		changes := Diff(old, cur)
+		Expect(changes).To(HaveLen(5))
+
+		byField := map[Field]Change{}
+		for _, c := range changes {
+			byField[c.Field] = c
+		}
+
+		Expect(byField[FieldTemperature].Before).To(Equal(12.0))
+		Expect(byField[FieldTemperature].After).To(Equal(9.5))
+		Expect(byField[FieldTemperature].Delta).To(Equal(-2.5))
+		Expect(byField[FieldWindDirDegrees].Delta).To(Equal(30.0))
+		Expect(byField[FieldAltimeter].Delta).To(BeNumerically("~", -0.06, 0.0001))
+		Expect(byField[FieldSkyCondition].After).To(Equal(SkyCoverBKN))
+		Expect(byField[FieldFlightCategory].Before).To(Equal(FlightCategoryVFR))
+		Expect(byField).NotTo(HaveKey(FieldWindSpeed))
+	})
+
+	It("should not compute a wind shift for variable winds", func() {
+		changes := Diff(&Result{WindDirDegrees: 0}, &Result{WindDirDegrees: 270})
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Delta).To(Equal(0.0))
+	})
+
+	It("should return nothing for identical or missing results", func() {
+		Expect(Diff(&Result{Temperature: 3}, &Result{Temperature: 3})).To(BeEmpty())
+		Expect(Diff(nil, &Result{})).To(BeNil())
+	})
+
+})
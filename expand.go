@@ -0,0 +1,119 @@
+package metar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// abbreviations contains the plain language meaning of the abbreviations
+// used in METARs
+var abbreviations = map[string]string{
+	// Report types and markers
+	"METAR": "routine weather report", "SPECI": "special weather report",
+	"AUTO": "automated report", "COR": "corrected report", "NIL": "missing report",
+	"CCA": "first correction", "CCB": "second correction", "CCC": "third correction",
+	"RMK": "remarks", "AO1": "automated station without precipitation discriminator",
+	"AO2": "automated station with precipitation discriminator", "$": "maintenance required",
+
+	// Trends
+	"NOSIG": "no significant change expected", "BECMG": "becoming", "TEMPO": "temporarily",
+	"FM": "from", "TL": "until", "AT": "at", "PROB30": "probability 30 percent", "PROB40": "probability 40 percent",
+
+	// Wind and visibility
+	"VRB": "variable", "KT": "knots", "MPS": "meters per second", "KMH": "kilometers per hour",
+	"SM": "statute miles", "CAVOK": "ceiling and visibility OK", "NDV": "no directional variation",
+	"P6SM": "more than 6 statute miles", "9999": "10 kilometers or more",
+	"WS": "wind shear", "RWY": "runway", "PK WND": "peak wind", "WSHFT": "wind shift", "FROPA": "frontal passage",
+
+	// Clouds
+	"SKC": "sky clear", "CLR": "no clouds below 12,000 ft", "NSC": "no significant clouds",
+	"NCD": "no clouds detected", "FEW": "few clouds", "SCT": "scattered clouds", "BKN": "broken clouds",
+	"OVC": "overcast", "VV": "vertical visibility", "CB": "cumulonimbus", "TCU": "towering cumulus",
+
+	// Weather, the phenomena are named by the "wx.*" messages of DefaultCatalog
+	"RE": "recent", "NSW": "no significant weather",
+
+	// Remarks
+	"SLP": "sea level pressure", "SLPNO": "sea level pressure not available",
+	"PRESRR": "pressure rising rapidly", "PRESFR": "pressure falling rapidly",
+	"TSNO": "thunderstorm sensor not available", "PWINO": "precipitation identifier not available",
+	"PNO": "precipitation amount not available", "FZRANO": "freezing rain sensor not available",
+	"RVRNO": "runway visual range not available", "VISNO": "visibility sensor not available",
+	"CHINO": "ceiling sensor not available", "LTG": "lightning", "OCNL": "occasional", "FRQ": "frequent",
+	"CONS": "continuous", "OHD": "overhead", "DSNT": "distant", "MOV": "moving", "ALQDS": "all quadrants",
+	"SNOCLO": "aerodrome closed due to snow", "CLRD": "runway cleared",
+}
+
+// Expand returns the plain language meaning of a METAR abbreviation or
+// group: single abbreviations ("BR" becomes "mist"), present weather
+// groups ("-SHRA" becomes "light rain showers") and cloud layers
+// ("BKN030CB" becomes "broken clouds at 3000 ft, cumulonimbus").
+func Expand(token string) (string, bool) {
+	if s, ok := abbreviations[token]; ok {
+		return s, true
+	}
+
+	if l, ok := parseCloudLayer(token); ok {
+		s := abbreviations[l.group()]
+		if l.BaseFt >= 0 {
+			s += fmt.Sprintf(" at %d ft", l.BaseFt)
+		}
+		if l.Type != CloudTypeNone {
+			s += ", " + abbreviations[string(l.Type)]
+		}
+		return s, true
+	}
+
+	if strings.HasPrefix(token, "RE") {
+		if s, ok := expandWeather(token[2:]); ok {
+			return abbreviations["RE"] + " " + s, true
+		}
+	}
+
+	switch token {
+	case "-", "+", "VC":
+		return expandLocalizer.Message("wx." + token), true
+	}
+	return expandWeather(token)
+}
+
+// expandLocalizer reads the English weather vocabulary of DefaultCatalog
+var expandLocalizer = Localizer{Lang: "en"}
+
+// expandWeather describes a present weather group in natural word order
+func expandWeather(group string) (string, bool) {
+	w, ok := ParseWeather(group)
+	if !ok {
+		return "", false
+	}
+
+	var phenomena []string
+	for _, p := range w.Phenomena {
+		phenomena = append(phenomena, expandLocalizer.Message("wx."+p))
+	}
+	s := strings.Join(phenomena, " and ")
+
+	switch w.Descriptor {
+	case "":
+	case "SH":
+		s = strings.TrimSpace(s + " showers")
+	case "TS":
+		if s != "" {
+			s = "thunderstorm with " + s
+		} else {
+			s = "thunderstorm"
+		}
+	case "BC":
+		s = "patches of " + s
+	default:
+		s = expandLocalizer.Message("wx."+w.Descriptor) + " " + s
+	}
+
+	if w.Intensity != "" {
+		s = expandLocalizer.Message("wx."+w.Intensity) + " " + s
+	}
+	if w.Vicinity {
+		s += " " + expandLocalizer.Message("wx.VC")
+	}
+	return s, true
+}
@@ -0,0 +1,42 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Expand", func() {
+
+	DescribeTable("should expand abbreviations",
+		func(token, expected string) {
+			s, ok := Expand(token)
+			Expect(ok).To(BeTrue())
+			Expect(s).To(Equal(expected))
+		},
+		Entry("obscuration", "BR", "mist"),
+		Entry("marker", "NOSIG", "no significant change expected"),
+		Entry("showers", "SHRA", "rain showers"),
+		Entry("light showers", "-SHRASN", "light rain and snow showers"),
+		Entry("thunderstorm", "+TSRA", "heavy thunderstorm with rain"),
+		Entry("vicinity thunderstorm", "VCTS", "thunderstorm in the vicinity"),
+		Entry("vicinity showers", "VCSH", "showers in the vicinity"),
+		Entry("freezing fog", "FZFG", "freezing fog"),
+		Entry("patches", "BCFG", "patches of fog"),
+		Entry("recent weather", "RERA", "recent rain"),
+		Entry("descriptor", "SH", "showers"),
+		Entry("intensity", "-", "light"),
+		Entry("cloud layer", "BKN030CB", "broken clouds at 3000 ft, cumulonimbus"),
+		Entry("vertical visibility", "VV001", "vertical visibility at 100 ft"),
+	)
+
+	It("should not expand unknown tokens", func() {
+		_, ok := Expand("XYZ")
+		Expect(ok).To(BeFalse())
+		_, ok = Expand("27008KT")
+		Expect(ok).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,226 @@
+package metar
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+)
+
+// Field names an attribute of a Result using its dataserver element name
+type Field string
+
+// Fields available on a Result
+const (
+	FieldRawText             Field = "raw_text"
+	FieldStationID           Field = "station_id"
+	FieldObservationTime     Field = "observation_time"
+	FieldLatitude            Field = "latitude"
+	FieldLongitude           Field = "longitude"
+	FieldTemperature         Field = "temp_c"
+	FieldDewpoint            Field = "dewpoint_c"
+	FieldWindDirDegrees      Field = "wind_dir_degrees"
+	FieldWindSpeed           Field = "wind_speed_kt"
+	FieldWindGust            Field = "wind_gust_kt"
+	FieldVisibilityStatute   Field = "visibility_statute_mi"
+	FieldAltimeter           Field = "altim_in_hg"
+	FieldSeaLevelPressure    Field = "sea_level_pressure_mb"
+	FieldQualityControlFlags Field = "quality_control_flags"
+	FieldWXString            Field = "wx_string"
+	FieldSkyCondition        Field = "sky_condition"
+	FieldFlightCategory      Field = "flight_category"
+	FieldMetarType           Field = "metar_type"
+	FieldElevation           Field = "elevation_m"
+	FieldVerticalVisibility  Field = "vert_vis_ft"
+	FieldPrecipitation       Field = "precip_in"
+	FieldSnowDepth           Field = "snow_in"
+)
+
+// allFields defines the bit positions of the fields within a FieldSet
+var allFields = []Field{
+	FieldRawText, FieldStationID, FieldObservationTime, FieldLatitude, FieldLongitude,
+	FieldTemperature, FieldDewpoint, FieldWindDirDegrees, FieldWindSpeed, FieldWindGust,
+	FieldVisibilityStatute, FieldAltimeter, FieldSeaLevelPressure, FieldQualityControlFlags,
+	FieldWXString, FieldSkyCondition, FieldFlightCategory, FieldMetarType, FieldElevation,
+	FieldVerticalVisibility, FieldPrecipitation, FieldSnowDepth,
+}
+
+// resultFieldIndex maps the element names to the index of the struct
+// field of Result they are decoded into
+var resultFieldIndex = func() map[Field]int {
+	idx := make(map[Field]int)
+	t := reflect.TypeOf(Result{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("xml"), ",")[0]
+		if name == "" || name == "-" || t.Field(i).Name == "XMLName" {
+			continue
+		}
+		idx[Field(name)] = i
+	}
+	return idx
+}()
+
+// FieldSet is a set of Fields, used to track which values were actually
+// reported and which are zero because they were missing
+type FieldSet uint64
+
+func fieldBit(f Field) FieldSet {
+	for i, af := range allFields {
+		if af == f {
+			return 1 << uint(i)
+		}
+	}
+	return 0
+}
+
+// Has reports whether the field is contained in the set
+func (s FieldSet) Has(f Field) bool {
+	b := fieldBit(f)
+	return b != 0 && s&b == b
+}
+
+// Add puts the field into the set
+func (s *FieldSet) Add(f Field) {
+	*s |= fieldBit(f)
+}
+
+// Fields lists the fields contained in the set
+func (s FieldSet) Fields() []Field {
+	var out []Field
+	for _, f := range allFields {
+		if s.Has(f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// MarshalJSON encodes the set as a list of field names
+func (s FieldSet) MarshalJSON() ([]byte, error) {
+	fields := s.Fields()
+	if fields == nil {
+		fields = []Field{}
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes a list of field names
+func (s *FieldSet) UnmarshalJSON(data []byte) error {
+	var fields []Field
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*s = 0
+	for _, f := range fields {
+		s.Add(f)
+	}
+	return nil
+}
+
+// UnmarshalXML decodes a METAR element and records which of its fields
+// were contained in the element within Present
+func (r *Result) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return r.decodeXML(d, start, false)
+}
+
+// decodeXML decodes the METAR element. Lenient decoding skips fields with
+// values not matching their type instead of failing.
+func (r *Result) decodeXML(d *xml.Decoder, start xml.StartElement, lenient bool) error {
+	r.XMLName = start.Name
+	v := reflect.ValueOf(r).Elem()
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			i, ok := resultFieldIndex[Field(t.Name.Local)]
+			if !ok {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if lenient {
+				ok, err := decodeFieldLenient(d, t, v.Field(i).Addr().Interface())
+				if err != nil {
+					return err
+				}
+				if !ok {
+					continue
+				}
+			} else if err := d.DecodeElement(v.Field(i).Addr().Interface(), &t); err != nil {
+				return err
+			}
+			r.Present.Add(Field(t.Name.Local))
+
+		case xml.EndElement:
+			setReportMarkers(r)
+			return nil
+		}
+	}
+}
+
+// decodeFieldLenient consumes the element and decodes it into dst. If the
+// content does not match the type of dst, dst is left untouched and false
+// is returned. Only errors of the surrounding document are returned.
+func decodeFieldLenient(d *xml.Decoder, start xml.StartElement, dst interface{}) (bool, error) {
+	var raw struct {
+		Inner []byte `xml:",innerxml"`
+	}
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return false, err
+	}
+
+	// Rebuild the element including its attributes (sky_condition carries
+	// its values as attributes only)
+	var doc strings.Builder
+	doc.WriteString("<" + start.Name.Local)
+	for _, a := range start.Attr {
+		doc.WriteString(" " + a.Name.Local + `="`)
+		if err := xml.EscapeText(&doc, []byte(a.Value)); err != nil {
+			return false, err
+		}
+		doc.WriteString(`"`)
+	}
+	doc.WriteString(">")
+	doc.Write(raw.Inner)
+	doc.WriteString("</" + start.Name.Local + ">")
+
+	tmp := reflect.New(reflect.TypeOf(dst).Elem())
+	if err := newLenientDecoder(strings.NewReader(doc.String())).Decode(tmp.Interface()); err != nil {
+		return false, nil
+	}
+	reflect.ValueOf(dst).Elem().Set(tmp.Elem())
+	return true, nil
+}
+
+// requiredFields are always requested to be able to assign results
+var requiredFields = []Field{FieldStationID, FieldObservationTime}
+
+// HasField reports whether the upstream API reported the field, short for
+// Present.Has. Fields not requested by a query limited to a set of fields
+// (see Query.Fields) are never reported.
+func (r Result) HasField(f Field) bool {
+	return r.Present.Has(f)
+}
+
+// withRequiredFields adds the fields needed to process the results
+func withRequiredFields(fields []Field) []Field {
+	out := append([]Field(nil), fields...)
+	for _, req := range requiredFields {
+		var set FieldSet
+		for _, f := range out {
+			set.Add(f)
+		}
+		if !set.Has(req) {
+			out = append(out, req)
+		}
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package metar_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fields", func() {
+
+	It("should distinguish missing values from zero values", func() {
+		results, err := DecodeXML(strings.NewReader(`<response><data num_results="2">
+			<METAR><station_id>EDDH</station_id><temp_c>0.0</temp_c></METAR>
+			<METAR><station_id>EDDF</station_id></METAR>
+		</data></response>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+
+		Expect(results[0].Temperature).To(Equal(0.0))
+		Expect(results[0].Present.Has(FieldTemperature)).To(BeTrue())
+		Expect(results[1].Temperature).To(Equal(0.0))
+		Expect(results[1].Present.Has(FieldTemperature)).To(BeFalse())
+		Expect(results[1].Present.Fields()).To(Equal([]Field{FieldStationID}))
+	})
+
+	It("should encode field sets as JSON lists", func() {
+		var s FieldSet
+		s.Add(FieldWindGust)
+		s.Add(FieldStationID)
+
+		data, err := json.Marshal(s)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`["station_id","wind_gust_kt"]`))
+
+		var decoded FieldSet
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded).To(Equal(s))
+	})
+
+})
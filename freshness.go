@@ -0,0 +1,29 @@
+package metar
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrStaleObservation is returned when the newest observation of a station
+// is older than the maximum age configured using WithMaxObservationAge
+var ErrStaleObservation = errors.New("Newest observation is older than the allowed maximum age")
+
+// WithMaxObservationAge makes the client return ErrStaleObservation when
+// the newest report of a station was observed more than maxAge ago. Fetch
+// fails the same way if the newest of its results is too old.
+// FetchStationsWeather is not affected, use ResultSet.ByMaxAge to drop
+// outdated stations from its results.
+func WithMaxObservationAge(maxAge time.Duration) ClientOption {
+	return func(c *Client) { c.maxObservationAge = maxAge }
+}
+
+// Age returns the time passed since the observation was made
+func (r Result) Age() time.Duration {
+	return time.Since(r.ObservationTime)
+}
+
+// IsStale reports whether the observation is older than maxAge
+func (r Result) IsStale(maxAge time.Duration) bool {
+	return r.Age() > maxAge
+}
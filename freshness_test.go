@@ -0,0 +1,49 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Freshness", func() {
+
+	It("should calculate the age of an observation", func() {
+		r := Result{ObservationTime: time.Now().Add(-90 * time.Minute)}
+		Expect(r.Age()).To(BeNumerically("~", 90*time.Minute, time.Second))
+		Expect(r.IsStale(time.Hour)).To(BeTrue())
+		Expect(r.IsStale(2 * time.Hour)).To(BeFalse())
+	})
+
+	It("should reject observations exceeding the maximum age", func() {
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithMaxObservationAge(time.Hour),
+		)
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).To(Equal(ErrStaleObservation))
+		Expect(r).To(BeNil())
+	})
+
+	It("should reject queries whose newest observation exceeds the maximum age", func() {
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithMaxObservationAge(time.Hour),
+		)
+
+		results, err := client.Fetch(context.Background(), Query{Stations: []string{"EDDH"}})
+		Expect(err).To(Equal(ErrStaleObservation))
+		Expect(results).To(BeNil())
+	})
+
+})
@@ -0,0 +1,106 @@
+package metar_test
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+)
+
+// Run the fuzz targets using "go test -fuzz FuzzParse" and
+// "go test -fuzz FuzzDecodeXML"
+
+var fuzzReference = time.Date(2016, 5, 21, 12, 0, 0, 0, time.UTC)
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"METAR EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG=",
+		"SPECI KJFK 211051Z VRB03G15KT 1 1/2SM R04R/2000V6000FT -TSRA BR SCT008 BKN015CB 17/M01 A2992 RMK AO2 PK WND 28045/1015 SLP132 T01720011",
+		"EDDH 211020Z AUTO 27008KT 240V300 0800 R23/0600U +SHSN VV002 M05/M06 Q0998 R23/290295 TEMPO 2000",
+		"EDDH 211020Z 00000KT CAVOK 17/09 Q1018 WS R23 RERA",
+		"EDDH 211020Z ///////KT //// // ////// ///// Q////",
+		"LIRF 211020Z 27008KT 9999 FEW030 17/09 Q1018 W15/S3 BLU",
+		"EDDH 251020Z NIL=",
+	} {
+		f.Add(seed)
+	}
+
+	if file, err := os.Open(filepath.Join("testdata", "cycle.txt")); err == nil {
+		s := bufio.NewScanner(file)
+		for s.Scan() {
+			f.Add(s.Text())
+		}
+		file.Close()
+	}
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		Tokenize(raw)
+		Validate(raw)
+		for _, field := range strings.Fields(raw) {
+			Expand(field)
+		}
+
+		for _, strict := range []bool{false, true} {
+			r, _, err := Parser{Reference: fuzzReference, Strict: strict}.ParseWithWarnings(raw)
+			if err != nil {
+				continue
+			}
+			exerciseResult(*r)
+		}
+	})
+}
+
+func FuzzDecodeXML(f *testing.F) {
+	for _, name := range []string{"eddh.xml", "multi.xml", "cavok.xml", "thunderstorm.xml"} {
+		if data, err := os.ReadFile(filepath.Join("testdata", name)); err == nil {
+			f.Add(string(data))
+		}
+	}
+	f.Add(`<response><data num_results="1"><METAR><temp_c>N/A</temp_c><sky_condition sky_cover="OVC"/></METAR></data></response>`)
+
+	f.Fuzz(func(t *testing.T, doc string) {
+		for _, decode := range []func(*strings.Reader) ([]Result, error){
+			func(r *strings.Reader) ([]Result, error) { return DecodeXML(r) },
+			func(r *strings.Reader) ([]Result, error) { return DecodeXMLLenient(r) },
+		} {
+			results, err := decode(strings.NewReader(doc))
+			if err != nil {
+				continue
+			}
+			for _, r := range results {
+				exerciseResult(r)
+			}
+		}
+	})
+}
+
+// exerciseResult calls the derived values of the result to find panics
+func exerciseResult(r Result) {
+	r.ATIS('A')
+	r.Ceiling()
+	r.ColourState()
+	r.Emoji()
+	r.EstimatedCumulusBaseFt()
+	r.GustFactor()
+	r.HourlyPrecipitation()
+	r.IcingRisk()
+	r.MeetsMinimums(Minimums{CeilingFt: 500, VisibilitySM: 1, MaxCrosswindKt: 15}, 230)
+	r.EvaluateUAS(Part107Limits)
+	r.PressureChange()
+	r.Remarks()
+	r.RunwayStates()
+	r.SeaState()
+	r.SnowDepth()
+	r.Trends()
+	_ = r.Visibility().String()
+	r.WMOWeatherCode()
+	r.Weather()
+	r.WindDirectionCompass()
+	r.WindShearRunways()
+	r.MarshalJSON()
+	(Localizer{Lang: "de"}).Summary(r)
+}
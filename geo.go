@@ -0,0 +1,45 @@
+package metar
+
+import "math"
+
+// Distance returns the great circle distance between two positions (in
+// decimal degrees) in kilometers
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	return greatCircleKm(lat1, lon1, lat2, lon2)
+}
+
+// Bearing returns the initial true course (0-360 degrees) of the great
+// circle from the first to the second position
+func Bearing(lat1, lon1, lat2, lon2 float64) float64 {
+	rad := math.Pi / 180
+	dLon := (lon2 - lon1) * rad
+
+	y := math.Sin(dLon) * math.Cos(lat2*rad)
+	x := math.Cos(lat1*rad)*math.Sin(lat2*rad) -
+		math.Sin(lat1*rad)*math.Cos(lat2*rad)*math.Cos(dLon)
+	return math.Mod(math.Atan2(y, x)/rad+360, 360)
+}
+
+// DistanceTo returns the distance from the station to the position in
+// kilometers. The boolean is false if the report lacks the station
+// position.
+func (r Result) DistanceTo(lat, lon float64) (float64, bool) {
+	if !r.hasPosition() {
+		return 0, false
+	}
+	return Distance(r.Latitude, r.Longitude, lat, lon), true
+}
+
+// BearingTo returns the true course from the station to the position.
+// The boolean is false if the report lacks the station position.
+func (r Result) BearingTo(lat, lon float64) (float64, bool) {
+	if !r.hasPosition() {
+		return 0, false
+	}
+	return Bearing(r.Latitude, r.Longitude, lat, lon), true
+}
+
+// hasPosition reports whether the report carries the station position
+func (r Result) hasPosition() bool {
+	return r.Present.Has(FieldLatitude) && r.Present.Has(FieldLongitude)
+}
@@ -0,0 +1,43 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Distance and bearing", func() {
+
+	It("should calculate the distance between positions", func() {
+		// EDDH to EDDF
+		Expect(Distance(53.630, 9.988, 50.033, 8.571)).To(BeNumerically("~", 410, 2))
+		Expect(Distance(53.630, 9.988, 53.630, 9.988)).To(Equal(0.0))
+	})
+
+	It("should calculate the initial bearing", func() {
+		Expect(Bearing(0, 0, 1, 0)).To(BeNumerically("~", 0, 0.01))
+		Expect(Bearing(0, 0, 0, 1)).To(BeNumerically("~", 90, 0.01))
+		Expect(Bearing(0, 0, -1, 0)).To(BeNumerically("~", 180, 0.01))
+		Expect(Bearing(0, 0, 0, -1)).To(BeNumerically("~", 270, 0.01))
+		Expect(Bearing(53.630, 9.988, 50.033, 8.571)).To(BeNumerically("~", 194.2, 0.1))
+	})
+
+	It("should use the station position of the result", func() {
+		r := Result{Latitude: 53.630, Longitude: 9.988}
+		_, ok := r.DistanceTo(50.033, 8.571)
+		Expect(ok).To(BeFalse())
+
+		r.Present.Add(FieldLatitude)
+		r.Present.Add(FieldLongitude)
+
+		d, ok := r.DistanceTo(50.033, 8.571)
+		Expect(ok).To(BeTrue())
+		Expect(KmToNauticalMiles(d)).To(BeNumerically("~", 221, 2))
+
+		b, ok := r.BearingTo(50.033, 8.571)
+		Expect(ok).To(BeTrue())
+		Expect(b).To(BeNumerically("~", 194.2, 0.1))
+	})
+
+})
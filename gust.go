@@ -0,0 +1,58 @@
+package metar
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultSignificantGustSpread is the spread between gusts and sustained
+// wind in knots considered significant by HasSignificantGust
+const DefaultSignificantGustSpread = 10
+
+var windShearRunwayRegex = regexp.MustCompile(`^R(?:WY)?(\d{2}[LCR]?)$`)
+
+// GustFactor returns the spread between the reported gusts and the
+// sustained wind speed in knots
+func (r Result) GustFactor() (int64, bool) {
+	if !r.Present.Has(FieldWindGust) || !r.Present.Has(FieldWindSpeed) {
+		return 0, false
+	}
+	return r.WindGust - r.WindSpeed, true
+}
+
+// HasSignificantGust reports whether the gust factor reaches the given
+// spread in knots, a spread of zero or less uses
+// DefaultSignificantGustSpread
+func (r Result) HasSignificantGust(spread int64) bool {
+	if spread <= 0 {
+		spread = DefaultSignificantGustSpread
+	}
+
+	f, ok := r.GustFactor()
+	return ok && f >= spread
+}
+
+// WindShearRunways returns the runways wind shear groups (WS R27,
+// WS RWY09L) are reported for in the raw report. Wind shear on all
+// runways (WS ALL RWY) is returned as "ALL".
+func (r Result) WindShearRunways() []string {
+	var runways []string
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind != TokenWindShear {
+			continue
+		}
+
+		parts := strings.Fields(t.Text)
+		if m := windShearRunwayRegex.FindStringSubmatch(parts[1]); m != nil {
+			runways = append(runways, m[1])
+		} else {
+			runways = append(runways, "ALL")
+		}
+	}
+	return runways
+}
+
+// HasWindShear reports whether wind shear is reported in the raw report
+func (r Result) HasWindShear() bool {
+	return len(r.WindShearRunways()) > 0
+}
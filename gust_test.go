@@ -0,0 +1,50 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Gusts and wind shear", func() {
+
+	It("should calculate the gust factor", func() {
+		r, err := Parser{}.Parse("METAR EDDH 211020Z 27012G28KT 9999 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+
+		f, ok := r.GustFactor()
+		Expect(ok).To(BeTrue())
+		Expect(f).To(Equal(int64(16)))
+		Expect(r.HasSignificantGust(0)).To(BeTrue())
+		Expect(r.HasSignificantGust(20)).To(BeFalse())
+	})
+
+	It("should not report a gust factor without gusts", func() {
+		r, err := Parser{}.Parse("METAR EDDH 211020Z 27012KT 9999 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, ok := r.GustFactor()
+		Expect(ok).To(BeFalse())
+		Expect(r.HasSignificantGust(0)).To(BeFalse())
+	})
+
+	It("should detect wind shear groups", func() {
+		r := Result{RawText: "METAR LGAV 211020Z 33025G38KT 9999 FEW030 17/09 Q1012 WS R03L WS RWY21 NOSIG"}
+		Expect(r.WindShearRunways()).To(Equal([]string{"03L", "21"}))
+		Expect(r.HasWindShear()).To(BeTrue())
+
+		r.RawText = "METAR LGAV 211020Z 33025G38KT 9999 FEW030 17/09 Q1012 WS ALL RWY"
+		Expect(r.WindShearRunways()).To(Equal([]string{"ALL"}))
+
+		r.RawText = "METAR EDDH 211020Z 27012KT 9999 FEW030 17/09 Q1018"
+		Expect(r.HasWindShear()).To(BeFalse())
+	})
+
+	It("should not warn about wind shear groups", func() {
+		_, warnings, err := Parser{}.ParseWithWarnings("METAR LGAV 211020Z 33025G38KT 9999 FEW030 17/09 Q1012 WS R03L")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+})
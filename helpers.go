@@ -5,11 +5,31 @@ func InHgTohPa(inHg float64) float64 {
 	return inHg * 33.8638866667
 }
 
+// HPaToInHg converts "hectopascal" to "inch of mercury"
+func HPaToInHg(hPa float64) float64 {
+	return hPa / 33.8638866667
+}
+
 // KtsToMs converts "knots" to "meters per second"
 func KtsToMs(kts float64) float64 {
 	return kts * 0.514444
 }
 
+// KtsToKmh converts "knots" to "kilometers per hour"
+func KtsToKmh(kts float64) float64 {
+	return kts * 1.852
+}
+
+// KmhToKts converts "kilometers per hour" to "knots"
+func KmhToKts(kmh float64) float64 {
+	return kmh / 1.852
+}
+
+// KtsToMph converts "knots" to "miles per hour"
+func KtsToMph(kts float64) float64 {
+	return kts * 1.150779
+}
+
 // KtsToBft converts "knots" to "bft"
 func KtsToBft(kts float64) int {
 	switch {
@@ -47,7 +67,17 @@ func StatMileToKm(sm float64) float64 {
 	return sm * 1.60934
 }
 
+// KmToNauticalMiles converts "kilometers" to "nautical miles"
+func KmToNauticalMiles(km float64) float64 {
+	return km / 1.852
+}
+
 // MbTohPa converts "millibar" to "hectopascal"
 func MbTohPa(mb float64) float64 {
 	return mb * 0.1
 }
+
+// FahrenheitToCelsius converts "degrees Fahrenheit" to "degrees Celsius"
+func FahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
@@ -12,9 +12,15 @@ var _ = Describe("Helpers", func() {
 	It("should convert values into expected results", func() {
 		Expect(KtsToMs(1)).To(Equal(0.514444))
 		Expect(InHgTohPa(1)).To(Equal(33.8638866667))
+		Expect(HPaToInHg(33.8638866667)).To(Equal(1.0))
 		Expect(StatMileToKm(1)).To(Equal(1.60934))
+		Expect(KmToNauticalMiles(1.852)).To(Equal(1.0))
 		Expect(MbTohPa(1)).To(Equal(0.1))
+		Expect(KtsToKmh(1)).To(Equal(1.852))
+		Expect(KmhToKts(1.852)).To(Equal(1.0))
+		Expect(KtsToMph(1)).To(Equal(1.150779))
 		Expect(KtsToBft(5)).To(Equal(2))
+		Expect(FahrenheitToCelsius(212)).To(Equal(100.0))
 	})
 
 })
@@ -0,0 +1,170 @@
+package metar
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// StationHistory accumulates the observations of a single station and
+// computes trends over them. It is safe for concurrent use.
+type StationHistory struct {
+	retention time.Duration
+
+	mu      sync.Mutex
+	results []Result
+}
+
+// WindShift describes a change of wind direction by at least 45 degrees
+// with a wind speed of 10 knots or more
+type WindShift struct {
+	From, To int64 // Wind directions in degrees
+	At       time.Time
+}
+
+// NewStationHistory creates a history keeping observations up to the
+// retention older than the newest one
+func NewStationHistory(retention time.Duration) *StationHistory {
+	return &StationHistory{retention: retention}
+}
+
+// Add inserts the observation ordered by its ObservationTime, replacing
+// an observation of the same time
+func (h *StationHistory) Add(r Result) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	i := sort.Search(len(h.results), func(i int) bool {
+		return !h.results[i].ObservationTime.Before(r.ObservationTime)
+	})
+	switch {
+	case i < len(h.results) && h.results[i].ObservationTime.Equal(r.ObservationTime):
+		h.results[i] = r
+	default:
+		h.results = append(h.results, Result{})
+		copy(h.results[i+1:], h.results[i:])
+		h.results[i] = r
+	}
+
+	cutoff := h.results[len(h.results)-1].ObservationTime.Add(-h.retention)
+	for len(h.results) > 0 && h.results[0].ObservationTime.Before(cutoff) {
+		h.results = h.results[1:]
+	}
+}
+
+// Results returns the stored observations ordered from oldest to newest
+func (h *StationHistory) Results() []Result {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]Result(nil), h.results...)
+}
+
+// Latest returns the newest observation
+func (h *StationHistory) Latest() (Result, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.results) == 0 {
+		return Result{}, false
+	}
+	return h.results[len(h.results)-1], true
+}
+
+// window returns the observations within d before the newest one
+func (h *StationHistory) window(d time.Duration) []Result {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.results) == 0 {
+		return nil
+	}
+
+	cutoff := h.results[len(h.results)-1].ObservationTime.Add(-d)
+	i := sort.Search(len(h.results), func(i int) bool {
+		return !h.results[i].ObservationTime.Before(cutoff)
+	})
+	return append([]Result(nil), h.results[i:]...)
+}
+
+// PressureTendency returns the change of pressure in hPa between the
+// newest observation and the oldest one within the window (usually three
+// hours). The sea level pressure is used if reported by both observations,
+// the altimeter setting otherwise.
+func (h *StationHistory) PressureTendency(window time.Duration) (float64, bool) {
+	w := h.window(window)
+	if len(w) < 2 {
+		return 0, false
+	}
+
+	first, last := w[0], w[len(w)-1]
+	switch {
+	case first.SeaLevelPressure > 0 && last.SeaLevelPressure > 0:
+		return last.SeaLevelPressure - first.SeaLevelPressure, true
+	case first.Altimeter > 0 && last.Altimeter > 0:
+		return InHgTohPa(last.Altimeter) - InHgTohPa(first.Altimeter), true
+	}
+	return 0, false
+}
+
+// TemperatureGradient returns the temperature change in degrees celsius
+// per hour between the oldest and the newest observation within the window
+func (h *StationHistory) TemperatureGradient(window time.Duration) (float64, bool) {
+	var w []Result
+	for _, r := range h.window(window) {
+		if r.Present.Has(FieldTemperature) {
+			w = append(w, r)
+		}
+	}
+	if len(w) < 2 {
+		return 0, false
+	}
+
+	first, last := w[0], w[len(w)-1]
+	hours := last.ObservationTime.Sub(first.ObservationTime).Hours()
+	if hours == 0 {
+		return 0, false
+	}
+	return (last.Temperature - first.Temperature) / hours, true
+}
+
+// WindShift returns the most recent wind shift between consecutive
+// observations within the window
+func (h *StationHistory) WindShift(window time.Duration) (WindShift, bool) {
+	w := h.window(window)
+	for i := len(w) - 1; i > 0; i-- {
+		prev, cur := w[i-1], w[i]
+		if prev.WindDirDegrees == 0 || cur.WindDirDegrees == 0 || cur.WindSpeed < 10 {
+			continue
+		}
+
+		d := (cur.WindDirDegrees - prev.WindDirDegrees + 360) % 360
+		if d > 180 {
+			d = 360 - d
+		}
+		if d >= 45 {
+			return WindShift{From: prev.WindDirDegrees, To: cur.WindDirDegrees, At: cur.ObservationTime}, true
+		}
+	}
+	return WindShift{}, false
+}
+
+// Precipitation sums the hourly precipitation in inches reported by the
+// observations within the window. Only routine reports are counted as
+// specials repeat the amount accumulated since the last routine report.
+func (h *StationHistory) Precipitation(window time.Duration) (float64, bool) {
+	var (
+		sum   float64
+		found bool
+	)
+	for _, r := range h.window(window) {
+		if r.MetarType == "SPECI" {
+			continue
+		}
+		if v, ok := r.HourlyPrecipitation(); ok {
+			sum += v
+			found = true
+		}
+	}
+	return sum, found
+}
@@ -0,0 +1,72 @@
+package metar_test
+
+import (
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StationHistory", func() {
+	var (
+		history *StationHistory
+		parser  = Parser{Reference: time.Date(2016, 5, 21, 13, 0, 0, 0, time.UTC)}
+	)
+
+	add := func(raw string) {
+		r, err := parser.Parse(raw)
+		Expect(err).NotTo(HaveOccurred())
+		history.Add(*r)
+	}
+
+	BeforeEach(func() {
+		history = NewStationHistory(6 * time.Hour)
+		add("KBOS 210454Z 18008KT 10SM FEW250 14/08 A3005 RMK AO2 SLP175")
+		add("KBOS 210754Z 19012KT 10SM -RA BKN040 12/10 A2999 RMK AO2 SLP155 P0004")
+		add("KBOS 210654Z 18010KT 10SM OVC050 13/09 A3001 RMK AO2 SLP162 P0002")
+		add("KBOS 210954Z 27015KT 10SM -RA BKN030 10/09 A2994 RMK AO2 SLP138 P0010")
+	})
+
+	It("should keep the observations ordered and within the retention", func() {
+		add("KBOS 211254Z 27015KT 10SM SCT030 11/08 A2994 RMK AO2 SLP138")
+
+		results := history.Results()
+		Expect(results).To(HaveLen(4))
+		Expect(results[0].ObservationTime).To(Equal(time.Date(2016, 5, 21, 6, 54, 0, 0, time.UTC)))
+
+		latest, ok := history.Latest()
+		Expect(ok).To(BeTrue())
+		Expect(latest.ObservationTime.Hour()).To(Equal(12))
+	})
+
+	It("should compute the pressure tendency", func() {
+		t, ok := history.PressureTendency(3 * time.Hour)
+		Expect(ok).To(BeTrue())
+		Expect(t).To(BeNumerically("~", -2.4, 0.0001))
+	})
+
+	It("should compute the temperature gradient", func() {
+		g, ok := history.TemperatureGradient(5 * time.Hour)
+		Expect(ok).To(BeTrue())
+		Expect(g).To(BeNumerically("~", -0.8, 0.001))
+	})
+
+	It("should detect wind shifts", func() {
+		s, ok := history.WindShift(3 * time.Hour)
+		Expect(ok).To(BeTrue())
+		Expect(s.From).To(Equal(int64(190)))
+		Expect(s.To).To(Equal(int64(270)))
+
+		_, ok = history.WindShift(time.Hour)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should accumulate precipitation", func() {
+		p, ok := history.Precipitation(3 * time.Hour)
+		Expect(ok).To(BeTrue())
+		Expect(p).To(BeNumerically("~", 0.16, 0.0001))
+	})
+
+})
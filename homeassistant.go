@@ -0,0 +1,122 @@
+package metar
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// DefaultHomeAssistantPrefix is the default discovery prefix of the Home
+// Assistant MQTT integration
+const DefaultHomeAssistantPrefix = "homeassistant"
+
+// DiscoveryMessage is a message to be published to announce a sensor
+type DiscoveryMessage struct {
+	Topic   string
+	Payload []byte
+}
+
+type haDevice struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Manufacturer string   `json:"manufacturer"`
+}
+
+type haSensor struct {
+	Name              string   `json:"name"`
+	UniqueID          string   `json:"unique_id"`
+	StateTopic        string   `json:"state_topic"`
+	ValueTemplate     string   `json:"value_template"`
+	UnitOfMeasurement string   `json:"unit_of_measurement"`
+	DeviceClass       string   `json:"device_class"`
+	StateClass        string   `json:"state_class"`
+	Device            haDevice `json:"device"`
+}
+
+// haSensors are the announced sensors, the templates read the JSON
+// representation of the Result (see MarshalJSON)
+var haSensors = []struct {
+	object, name, template, unit, class string
+}{
+	{"temperature", "Temperature", "{{ value_json.temp_c }}", "°C", "temperature"},
+	{"dewpoint", "Dewpoint", "{{ value_json.dewpoint_c }}", "°C", "temperature"},
+	// Magnus formula as in RelativeHumidity
+	{"humidity", "Humidity", "{{ (100 * e ** (17.62 * value_json.dewpoint_c / (243.12 + value_json.dewpoint_c)) / e ** (17.62 * value_json.temp_c / (243.12 + value_json.temp_c))) | round(0) }}", "%", "humidity"},
+	{"wind_speed", "Wind speed", "{{ value_json.wind_speed_kt }}", "kn", "wind_speed"},
+	{"wind_gust", "Wind gust", "{{ value_json.wind_gust_kt }}", "kn", "wind_speed"},
+	{"pressure", "Pressure", "{{ value_json.altim_in_hg }}", "inHg", "atmospheric_pressure"},
+}
+
+// WithHomeAssistantDiscovery announces temperature, dewpoint, humidity,
+// wind and pressure sensors of every station using Home Assistant MQTT
+// discovery before its first observation is published. The prefix
+// defaults to DefaultHomeAssistantPrefix when empty.
+func WithHomeAssistantDiscovery(prefix string) PublishSinkOption {
+	return func(s *PublishSink) {
+		if prefix == "" {
+			prefix = DefaultHomeAssistantPrefix
+		}
+		s.discoveryPrefix = prefix
+	}
+}
+
+// HomeAssistantDiscovery returns the discovery messages announcing the
+// sensors of the station reading their state from the observations
+// published to stateTopic
+func HomeAssistantDiscovery(prefix, station, stateTopic string) ([]DiscoveryMessage, error) {
+	node := "metar_" + strings.ToLower(station)
+	device := haDevice{
+		Identifiers:  []string{node},
+		Name:         "METAR " + strings.ToUpper(station),
+		Manufacturer: "go-metar",
+	}
+
+	var msgs []DiscoveryMessage
+	for _, sensor := range haSensors {
+		payload, err := json.Marshal(haSensor{
+			Name:              sensor.name,
+			UniqueID:          node + "_" + sensor.object,
+			StateTopic:        stateTopic,
+			ValueTemplate:     sensor.template,
+			UnitOfMeasurement: sensor.unit,
+			DeviceClass:       sensor.class,
+			StateClass:        "measurement",
+			Device:            device,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, DiscoveryMessage{
+			Topic:   strings.Join([]string{prefix, "sensor", node, sensor.object, "config"}, "/"),
+			Payload: payload,
+		})
+	}
+	return msgs, nil
+}
+
+// announce publishes the discovery messages of the station once
+func (s *PublishSink) announce(ctx context.Context, station, stateTopic string) error {
+	if s.discoveryPrefix == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.announced[station] {
+		return nil
+	}
+
+	msgs, err := HomeAssistantDiscovery(s.discoveryPrefix, station, stateTopic)
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		if err := s.publisher.Publish(ctx, m.Topic, station, m.Payload); err != nil {
+			return err
+		}
+	}
+
+	s.announced[station] = true
+	return nil
+}
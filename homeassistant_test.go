@@ -0,0 +1,53 @@
+package metar_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Home Assistant discovery", func() {
+
+	It("should describe the sensors of the station", func() {
+		msgs, err := HomeAssistantDiscovery("homeassistant", "eddh", "metar/eddh")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msgs).To(HaveLen(6))
+		Expect(msgs[0].Topic).To(Equal("homeassistant/sensor/metar_eddh/temperature/config"))
+
+		var cfg map[string]interface{}
+		Expect(json.Unmarshal(msgs[0].Payload, &cfg)).To(Succeed())
+		Expect(cfg).To(HaveKeyWithValue("unique_id", "metar_eddh_temperature"))
+		Expect(cfg).To(HaveKeyWithValue("state_topic", "metar/eddh"))
+		Expect(cfg).To(HaveKeyWithValue("value_template", "{{ value_json.temp_c }}"))
+		Expect(cfg).To(HaveKeyWithValue("device_class", "temperature"))
+		Expect(cfg["device"]).To(HaveKeyWithValue("name", "METAR EDDH"))
+	})
+
+	It("should announce every station once before its observations", func() {
+		p := &recordingPublisher{}
+		sink := NewPublishSink(p, "metar/{station}", WithHomeAssistantDiscovery(""))
+
+		Expect(sink.Write(context.Background(), []Result{{StationID: "EDDH"}})).To(Succeed())
+		Expect(p.messages).To(HaveLen(7))
+		Expect(p.messages[0].topic).To(HavePrefix("homeassistant/sensor/metar_eddh/"))
+		Expect(p.messages[6].topic).To(Equal("metar/eddh"))
+
+		Expect(sink.Write(context.Background(), []Result{{StationID: "EDDH"}})).To(Succeed())
+		Expect(p.messages).To(HaveLen(8))
+	})
+
+	It("should not publish observations of stations failing to be announced", func() {
+		p := &recordingPublisher{fail: "EDDH"}
+		sink := NewPublishSink(p, "metar/{station}", WithHomeAssistantDiscovery("ha"))
+
+		err := sink.Write(context.Background(), []Result{{StationID: "EDDH"}, {StationID: "EDDW"}})
+		Expect(err).To(MatchError("Publishing observations failed: EDDH: broker unavailable"))
+		Expect(p.messages).To(HaveLen(7))
+		Expect(p.messages[0].topic).To(HavePrefix("ha/sensor/metar_eddw/"))
+	})
+
+})
@@ -0,0 +1,245 @@
+package metar
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Catalog provides the translated messages of the human-readable output
+type Catalog interface {
+	// Message returns the message with the key in the language
+	Message(lang, key string) (string, bool)
+}
+
+// MessageCatalog is a Catalog mapping languages to their messages
+type MessageCatalog map[string]map[string]string
+
+// Message implements Catalog
+func (c MessageCatalog) Message(lang, key string) (string, bool) {
+	msg, ok := c[lang][key]
+	return msg, ok
+}
+
+// DefaultCatalog contains the built-in English, German, French and
+// Spanish messages. Keys are "bft.<force>" for the Beaufort terms,
+// "wx.<code>" for weather phenomena and "summary.<part>" for the parts
+// of Localizer.Summary.
+var DefaultCatalog = MessageCatalog{
+	"en": {
+		"wx.format": "{intensity} {descriptor} {phenomena} {vicinity}",
+		"wx.-":      "light", "wx.+": "heavy", "wx.VC": "in the vicinity",
+		"wx.MI": "shallow", "wx.PR": "partial", "wx.BC": "patches", "wx.DR": "low drifting", "wx.BL": "blowing",
+		"wx.SH": "showers of", "wx.TS": "thunderstorm", "wx.FZ": "freezing",
+		"wx.DZ": "drizzle", "wx.RA": "rain", "wx.SN": "snow", "wx.SG": "snow grains", "wx.IC": "ice crystals",
+		"wx.PL": "ice pellets", "wx.GR": "hail", "wx.GS": "small hail", "wx.UP": "unknown precipitation",
+		"wx.BR": "mist", "wx.FG": "fog", "wx.FU": "smoke", "wx.VA": "volcanic ash", "wx.DU": "dust", "wx.SA": "sand",
+		"wx.HZ": "haze", "wx.PY": "spray", "wx.PO": "dust whirls", "wx.SQ": "squalls", "wx.FC": "funnel cloud",
+		"wx.SS": "sandstorm", "wx.DS": "duststorm",
+
+		"summary.temperature":   "temperature {temp} °C",
+		"summary.dewpoint":      "dewpoint {dewpoint} °C",
+		"summary.wind":          "wind from {dir}° at {speed} kt ({bft})",
+		"summary.wind_variable": "variable wind at {speed} kt ({bft})",
+		"summary.calm":          "calm",
+		"summary.gusts":         "gusts {gust} kt",
+		"summary.visibility":    "visibility {vis} km",
+		"summary.pressure":      "pressure {qnh} hPa",
+	},
+	"de": {
+		"wx.format": "{intensity} {descriptor} {phenomena} {vicinity}",
+		"wx.-":      "leichter", "wx.+": "starker", "wx.VC": "in der Umgebung",
+		"wx.MI": "flacher", "wx.PR": "teilweise", "wx.BC": "Schwaden von", "wx.DR": "fegender", "wx.BL": "treibender",
+		"wx.SH": "Schauer von", "wx.TS": "Gewitter mit", "wx.FZ": "gefrierender",
+		"wx.DZ": "Sprühregen", "wx.RA": "Regen", "wx.SN": "Schnee", "wx.SG": "Schneegriesel", "wx.IC": "Eisnadeln",
+		"wx.PL": "Eiskörner", "wx.GR": "Hagel", "wx.GS": "Graupel", "wx.UP": "unbekannter Niederschlag",
+		"wx.BR": "feuchter Dunst", "wx.FG": "Nebel", "wx.FU": "Rauch", "wx.VA": "Vulkanasche", "wx.DU": "Staub", "wx.SA": "Sand",
+		"wx.HZ": "trockener Dunst", "wx.PY": "Gischt", "wx.PO": "Staubwirbel", "wx.SQ": "Böen", "wx.FC": "Trichterwolke",
+		"wx.SS": "Sandsturm", "wx.DS": "Staubsturm",
+
+		"summary.temperature":   "Temperatur {temp} °C",
+		"summary.dewpoint":      "Taupunkt {dewpoint} °C",
+		"summary.wind":          "Wind aus {dir}° mit {speed} kt ({bft})",
+		"summary.wind_variable": "umlaufender Wind mit {speed} kt ({bft})",
+		"summary.calm":          "windstill",
+		"summary.gusts":         "Böen {gust} kt",
+		"summary.visibility":    "Sicht {vis} km",
+		"summary.pressure":      "Luftdruck {qnh} hPa",
+	},
+	"fr": {
+		"wx.format": "{descriptor} {phenomena} {intensity} {vicinity}",
+		"wx.-":      "faible", "wx.+": "forte", "wx.VC": "au voisinage",
+		"wx.MI": "mince", "wx.PR": "partiel", "wx.BC": "bancs de", "wx.DR": "chasse-basse de", "wx.BL": "chasse-haute de",
+		"wx.SH": "averses de", "wx.TS": "orage avec", "wx.FZ": "se congelant",
+		"wx.DZ": "bruine", "wx.RA": "pluie", "wx.SN": "neige", "wx.SG": "neige en grains", "wx.IC": "cristaux de glace",
+		"wx.PL": "granules de glace", "wx.GR": "grêle", "wx.GS": "grésil", "wx.UP": "précipitations inconnues",
+		"wx.BR": "brume", "wx.FG": "brouillard", "wx.FU": "fumée", "wx.VA": "cendres volcaniques", "wx.DU": "poussière", "wx.SA": "sable",
+		"wx.HZ": "brume sèche", "wx.PY": "embruns", "wx.PO": "tourbillons de poussière", "wx.SQ": "grains", "wx.FC": "nuage en entonnoir",
+		"wx.SS": "tempête de sable", "wx.DS": "tempête de poussière",
+
+		"summary.temperature":   "température {temp} °C",
+		"summary.dewpoint":      "point de rosée {dewpoint} °C",
+		"summary.wind":          "vent du {dir}° à {speed} kt ({bft})",
+		"summary.wind_variable": "vent variable à {speed} kt ({bft})",
+		"summary.calm":          "vent calme",
+		"summary.gusts":         "rafales {gust} kt",
+		"summary.visibility":    "visibilité {vis} km",
+		"summary.pressure":      "pression {qnh} hPa",
+	},
+	"es": {
+		"wx.format": "{descriptor} {phenomena} {intensity} {vicinity}",
+		"wx.-":      "débil", "wx.+": "fuerte", "wx.VC": "en las proximidades",
+		"wx.MI": "baja", "wx.PR": "parcial", "wx.BC": "bancos de", "wx.DR": "ventisca baja de", "wx.BL": "ventisca alta de",
+		"wx.SH": "chubascos de", "wx.TS": "tormenta con", "wx.FZ": "engelante",
+		"wx.DZ": "llovizna", "wx.RA": "lluvia", "wx.SN": "nieve", "wx.SG": "cinarra", "wx.IC": "cristales de hielo",
+		"wx.PL": "hielo granulado", "wx.GR": "granizo", "wx.GS": "granizo pequeño", "wx.UP": "precipitación desconocida",
+		"wx.BR": "neblina", "wx.FG": "niebla", "wx.FU": "humo", "wx.VA": "ceniza volcánica", "wx.DU": "polvo", "wx.SA": "arena",
+		"wx.HZ": "calima", "wx.PY": "rociones", "wx.PO": "remolinos de polvo", "wx.SQ": "turbonadas", "wx.FC": "nube embudo",
+		"wx.SS": "tempestad de arena", "wx.DS": "tempestad de polvo",
+
+		"summary.temperature":   "temperatura {temp} °C",
+		"summary.dewpoint":      "punto de rocío {dewpoint} °C",
+		"summary.wind":          "viento de {dir}° a {speed} kt ({bft})",
+		"summary.wind_variable": "viento variable a {speed} kt ({bft})",
+		"summary.calm":          "calma",
+		"summary.gusts":         "rachas {gust} kt",
+		"summary.visibility":    "visibilidad {vis} km",
+		"summary.pressure":      "presión {qnh} hPa",
+	},
+}
+
+func init() {
+	for lang, terms := range bftDescriptions {
+		for bft, term := range terms {
+			DefaultCatalog[lang][fmt.Sprintf("bft.%d", bft)] = term
+		}
+	}
+}
+
+// Localizer renders the human-readable output in a language. Messages
+// are looked up in Catalog (if set) before DefaultCatalog, regional
+// languages like "de-AT" fall back to their base language and English is
+// used for messages missing in the language.
+type Localizer struct {
+	Lang    string
+	Catalog Catalog
+}
+
+// Message returns the message with the key, replacements are pairs of
+// placeholder name and value ("temp", "17"). The key is returned if no
+// catalog contains the message.
+func (l Localizer) Message(key string, replacements ...string) string {
+	msg, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+
+	pairs := make([]string, 0, len(replacements))
+	for i := 0; i+1 < len(replacements); i += 2 {
+		pairs = append(pairs, "{"+replacements[i]+"}", replacements[i+1])
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+func (l Localizer) lookup(key string) (string, bool) {
+	langs := []string{l.Lang}
+	if i := strings.IndexAny(l.Lang, "-_"); i > 0 {
+		langs = append(langs, l.Lang[:i])
+	}
+
+	for _, c := range []Catalog{l.Catalog, DefaultCatalog} {
+		if c == nil {
+			continue
+		}
+		for _, lang := range langs {
+			if msg, ok := c.Message(lang, key); ok {
+				return msg, true
+			}
+		}
+	}
+	return DefaultCatalog.Message("en", key)
+}
+
+// Bft returns the descriptive term of the Beaufort force or an empty
+// string for values outside the scale
+func (l Localizer) Bft(bft int) string {
+	if bft < 0 || bft > 12 {
+		return ""
+	}
+	return l.Message(fmt.Sprintf("bft.%d", bft))
+}
+
+// Weather returns the name of the present weather group ("light showers
+// of rain" for -SHRA)
+func (l Localizer) Weather(w WeatherPhenomenon) string {
+	var intensity, descriptor, vicinity string
+	if w.Intensity != "" {
+		intensity = l.Message("wx." + w.Intensity)
+	}
+	if w.Descriptor != "" {
+		descriptor = l.Message("wx." + w.Descriptor)
+	}
+	if w.Vicinity {
+		vicinity = l.Message("wx.VC")
+	}
+
+	var phenomena []string
+	for _, p := range w.Phenomena {
+		phenomena = append(phenomena, l.Message("wx."+p))
+	}
+
+	return strings.Join(strings.Fields(l.Message("wx.format",
+		"intensity", intensity,
+		"descriptor", descriptor,
+		"phenomena", strings.Join(phenomena, ", "),
+		"vicinity", vicinity,
+	)), " ")
+}
+
+// Summary renders a one-line human-readable summary of the result like
+// "EDDH 10:20 UTC: temperature 17 °C, dewpoint 9 °C, wind from 270° at
+// 8 kt (Gentle breeze), visibility 10 km, pressure 1018 hPa"
+func (l Localizer) Summary(r Result) string {
+	var parts []string
+	if r.Present.Has(FieldTemperature) {
+		parts = append(parts, l.Message("summary.temperature", "temp", formatRounded(r.Temperature)))
+	}
+	if r.Present.Has(FieldDewpoint) {
+		parts = append(parts, l.Message("summary.dewpoint", "dewpoint", formatRounded(r.Dewpoint)))
+	}
+
+	if r.Present.Has(FieldWindSpeed) {
+		speed := strconv.FormatInt(r.WindSpeed, 10)
+		bft := l.Bft(KtsToBft(float64(r.WindSpeed)))
+		switch {
+		case r.WindSpeed == 0:
+			parts = append(parts, l.Message("summary.calm"))
+		case r.WindDirDegrees == 0:
+			parts = append(parts, l.Message("summary.wind_variable", "speed", speed, "bft", bft))
+		default:
+			parts = append(parts, l.Message("summary.wind", "dir", strconv.FormatInt(r.WindDirDegrees, 10), "speed", speed, "bft", bft))
+		}
+		if r.WindGust > r.WindSpeed {
+			parts = append(parts, l.Message("summary.gusts", "gust", strconv.FormatInt(r.WindGust, 10)))
+		}
+	}
+
+	if v := r.Visibility(); v.Value > 0 {
+		parts = append(parts, l.Message("summary.visibility", "vis", strconv.FormatFloat(math.Round(v.Meters()/100)/10, 'f', -1, 64)))
+	}
+	for _, w := range r.Weather() {
+		parts = append(parts, l.Weather(w))
+	}
+	if qnh, ok := r.QNH(); ok {
+		parts = append(parts, l.Message("summary.pressure", "qnh", formatRounded(qnh)))
+	}
+
+	return fmt.Sprintf("%s %s UTC: %s", r.StationID, r.ObservationTime.UTC().Format("15:04"), strings.Join(parts, ", "))
+}
+
+// formatRounded formats the value rounded to an integer, adding zero
+// turns a negative zero into zero
+func formatRounded(v float64) string {
+	return strconv.FormatFloat(math.Round(v)+0, 'f', -1, 64)
+}
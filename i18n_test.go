@@ -0,0 +1,48 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Localization", func() {
+
+	var r *Result
+
+	BeforeEach(func() {
+		var err error
+		r, err = ParseRaw("EDDH 211020Z 27008G20KT 9999 -SHRA FEW030 17/M00 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should name weather phenomena", func() {
+		w, _ := ParseWeather("-SHRA")
+		Expect(Localizer{Lang: "en"}.Weather(w)).To(Equal("light showers of rain"))
+		Expect(Localizer{Lang: "de"}.Weather(w)).To(Equal("leichter Schauer von Regen"))
+		Expect(Localizer{Lang: "fr"}.Weather(w)).To(Equal("averses de pluie faible"))
+		Expect(Localizer{Lang: "es"}.Weather(w)).To(Equal("chubascos de lluvia débil"))
+
+		w, _ = ParseWeather("VCFG")
+		Expect(Localizer{Lang: "en"}.Weather(w)).To(Equal("fog in the vicinity"))
+	})
+
+	It("should summarize the result", func() {
+		Expect(Localizer{Lang: "en"}.Summary(*r)).To(Equal("EDDH 10:20 UTC: temperature 17 °C, dewpoint 0 °C, " +
+			"wind from 270° at 8 kt (Gentle breeze), gusts 20 kt, visibility 10 km, light showers of rain, pressure 1018 hPa"))
+		Expect(Localizer{Lang: "de-AT"}.Summary(*r)).To(Equal("EDDH 10:20 UTC: Temperatur 17 °C, Taupunkt 0 °C, " +
+			"Wind aus 270° mit 8 kt (Schwache Brise), Böen 20 kt, Sicht 10 km, leichter Schauer von Regen, Luftdruck 1018 hPa"))
+	})
+
+	It("should prefer messages of a custom catalog", func() {
+		l := Localizer{Lang: "nl", Catalog: MessageCatalog{
+			"nl": {"summary.temperature": "temperatuur {temp} °C", "bft.3": "Matig"},
+		}}
+		Expect(l.Bft(3)).To(Equal("Matig"))
+		Expect(l.Bft(4)).To(Equal("Moderate breeze"))
+		Expect(l.Message("summary.temperature", "temp", "5")).To(Equal("temperatuur 5 °C"))
+		Expect(l.Message("unknown")).To(Equal("unknown"))
+	})
+
+})
@@ -0,0 +1,77 @@
+package metar
+
+// IcingRisk classifies the risk of airframe icing near the surface
+type IcingRisk int
+
+// Icing risk levels
+const (
+	IcingRiskUnknown  IcingRisk = iota // Temperature not reported
+	IcingRiskNone                      // Too warm, too cold or too dry for icing
+	IcingRiskLow                       // Temperature and humidity allow icing in cloud
+	IcingRiskModerate                  // Visible moisture at icing temperatures or freezing fog
+	IcingRiskSevere                    // Freezing rain or drizzle reported
+)
+
+// precipitationCodes are the weather codes of precipitation
+var precipitationCodes = []string{"DZ", "RA", "SN", "SG", "IC", "PL", "GR", "GS", "UP"}
+
+var icingRiskNames = []string{"unknown", "none", "low", "moderate", "severe"}
+
+func (i IcingRisk) String() string {
+	if i < 0 || int(i) >= len(icingRiskNames) {
+		return "unknown"
+	}
+	return icingRiskNames[i]
+}
+
+// IcingRisk estimates the icing risk from temperature, dewpoint, present
+// weather and cloud cover. It is a heuristic for pre-flight checks of
+// small aircraft and drones and does not replace icing forecasts.
+func (r Result) IcingRisk() IcingRisk {
+	if !r.Present.Has(FieldTemperature) {
+		return IcingRiskUnknown
+	}
+
+	if r.HasFreezingPrecipitation() {
+		return IcingRiskSevere
+	}
+
+	var precipitation, fog bool
+	for _, w := range r.Weather() {
+		switch {
+		case w.Vicinity:
+		case w.Descriptor == "FZ" && w.Has("FG"):
+			return IcingRiskModerate
+		case w.Has("FG") || w.Has("BR"):
+			fog = true
+		case hasAnyPhenomenon(w, precipitationCodes):
+			precipitation = true
+		}
+	}
+
+	if r.Temperature > 2 || r.Temperature < -20 {
+		return IcingRiskNone
+	}
+
+	cloudy := r.SkyCondition.SkyCover == SkyCoverBKN || r.SkyCondition.SkyCover == SkyCoverOVC || r.SkyCondition.SkyCover == SkyCoverOVX
+	humid := r.Present.Has(FieldDewpoint) && r.Temperature-r.Dewpoint <= 3
+
+	switch {
+	case precipitation || fog:
+		return IcingRiskModerate
+	case humid && cloudy:
+		return IcingRiskModerate
+	case humid || cloudy:
+		return IcingRiskLow
+	}
+	return IcingRiskNone
+}
+
+func hasAnyPhenomenon(w WeatherPhenomenon, codes []string) bool {
+	for _, c := range codes {
+		if w.Has(c) {
+			return true
+		}
+	}
+	return false
+}
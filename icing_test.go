@@ -0,0 +1,34 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IcingRisk", func() {
+
+	table.DescribeTable("classification",
+		func(raw string, expected IcingRisk) {
+			r, err := ParseRaw(raw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.IcingRisk()).To(Equal(expected))
+		},
+		table.Entry("freezing rain", "EDDH 211020Z 27008KT 4000 -FZRA OVC008 M01/M02 Q1018", IcingRiskSevere),
+		table.Entry("freezing drizzle", "EDDH 211020Z 27008KT 4000 FZDZ OVC008 00/M01 Q1018", IcingRiskSevere),
+		table.Entry("freezing fog", "EDDH 211020Z 00000KT 0300 FZFG VV001 M03/M03 Q1018", IcingRiskModerate),
+		table.Entry("snow near freezing", "EDDH 211020Z 27008KT 3000 -SN BKN012 01/00 Q1018", IcingRiskModerate),
+		table.Entry("humid overcast", "EDDH 211020Z 27008KT 9999 OVC015 M05/M07 Q1018", IcingRiskModerate),
+		table.Entry("dry overcast", "EDDH 211020Z 27008KT 9999 OVC015 M05/M12 Q1018", IcingRiskLow),
+		table.Entry("cold and dry", "EDDH 211020Z 27008KT 9999 FEW030 M05/M15 Q1018", IcingRiskNone),
+		table.Entry("warm rain", "EDDH 211020Z 27008KT 4000 RA OVC008 12/11 Q1018", IcingRiskNone),
+		table.Entry("no temperature", "EDDH 211020Z 27008KT 9999 FEW030 Q1018", IcingRiskUnknown),
+	)
+
+	It("should describe the risk", func() {
+		Expect(IcingRiskModerate.String()).To(Equal("moderate"))
+	})
+
+})
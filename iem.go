@@ -0,0 +1,199 @@
+package metar
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	iemBaseURL = "https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py"
+)
+
+// ErrMissingTimeWindow is returned by archive sources when the query does
+// not specify a start time
+var ErrMissingTimeWindow = errors.New("Query needs a start time for archive requests")
+
+// IEM is a Source backed by the ASOS / METAR archive of the Iowa
+// Environmental Mesonet (mesonet.agron.iastate.edu) which provides reports
+// reaching back many years. Queries must specify a Start time (End
+// defaults to now). Use AllReports as selection to get every report
+// within the window.
+//
+// Stations are identified by ICAO identifiers outside of the US and by
+// their FAA identifiers (like "JFK" instead of "KJFK") within the US.
+type IEM struct {
+	// BaseURL of the asos.py endpoint, defaults to the IEM server
+	BaseURL string
+}
+
+// Fetch retrieves the archived reports within the time window of the query
+func (i IEM) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	if q.Start.IsZero() {
+		return nil, ErrMissingTimeWindow
+	}
+
+	end := q.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start, end := q.Start.UTC(), end.UTC()
+
+	params := url.Values{
+		"station":     q.Stations,
+		"data":        {"all"},
+		"tz":          {"Etc/UTC"},
+		"format":      {"onlycomma"},
+		"latlon":      {"yes"},
+		"elev":        {"yes"},
+		"missing":     {"M"},
+		"trace":       {"T"},
+		"direct":      {"no"},
+		"report_type": {"3", "4"},
+	}
+	for n, t := range map[string]time.Time{"1": start, "2": end} {
+		params.Set("year"+n, strconv.Itoa(t.Year()))
+		params.Set("month"+n, strconv.Itoa(int(t.Month())))
+		params.Set("day"+n, strconv.Itoa(t.Day()))
+		params.Set("hour"+n, strconv.Itoa(t.Hour()))
+		params.Set("minute"+n, strconv.Itoa(t.Minute()))
+	}
+
+	base := i.BaseURL
+	if base == "" {
+		base = iemBaseURL
+	}
+
+	res, err := get(ctx, base+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	results, err := decodeIEM(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	setOrigin(results, res)
+
+	return selectReports(results, q.Selection), nil
+}
+
+// decodeIEM reads the comma separated output of the asos.py endpoint
+func decodeIEM(r io.Reader) ([]Result, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[h] = i
+	}
+
+	var results []Result
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := iemRow{rec: rec, col: col}
+		r := Result{MetarType: "METAR"}
+
+		r.StationID = row.str("station", FieldStationID, &r.Present)
+		r.RawText = row.str("metar", FieldRawText, &r.Present)
+		r.WXString = row.str("wxcodes", FieldWXString, &r.Present)
+
+		if v, ok := row.value("valid"); ok {
+			if r.ObservationTime, err = time.Parse("2006-01-02 15:04", v); err != nil {
+				return nil, fmt.Errorf("Invalid observation time %q: %s", v, err)
+			}
+			r.Present.Add(FieldObservationTime)
+		}
+
+		r.Latitude = row.float("lat", FieldLatitude, &r.Present)
+		r.Longitude = row.float("lon", FieldLongitude, &r.Present)
+		r.Elevation = row.float("elevation", FieldElevation, &r.Present)
+		r.WindDirDegrees = int64(row.float("drct", FieldWindDirDegrees, &r.Present))
+		r.WindSpeed = int64(row.float("sknt", FieldWindSpeed, &r.Present))
+		r.WindGust = int64(row.float("gust", FieldWindGust, &r.Present))
+		r.VisibilityStatute = row.float("vsby", FieldVisibilityStatute, &r.Present)
+		r.Altimeter = row.float("alti", FieldAltimeter, &r.Present)
+		r.SeaLevelPressure = row.float("mslp", FieldSeaLevelPressure, &r.Present)
+
+		if row.has("tmpf") {
+			r.Temperature = FahrenheitToCelsius(row.float("tmpf", FieldTemperature, &r.Present))
+		}
+		if row.has("dwpf") {
+			r.Dewpoint = FahrenheitToCelsius(row.float("dwpf", FieldDewpoint, &r.Present))
+		}
+
+		for _, c := range []string{"skyc1", "skyc2", "skyc3", "skyc4"} {
+			if v, ok := row.value(c); ok {
+				r.SkyCondition.SkyCover = SkyCover(v)
+				r.Present.Add(FieldSkyCondition)
+			}
+		}
+
+		setReportMarkers(&r)
+		results = append(results, r)
+	}
+}
+
+// iemRow gives access to the columns of an IEM record treating "M" and
+// empty values as missing
+type iemRow struct {
+	rec []string
+	col map[string]int
+}
+
+func (r iemRow) value(name string) (string, bool) {
+	i, ok := r.col[name]
+	if !ok || i >= len(r.rec) || r.rec[i] == "M" || r.rec[i] == "" {
+		return "", false
+	}
+	return r.rec[i], true
+}
+
+func (r iemRow) has(name string) bool {
+	_, ok := r.value(name)
+	return ok
+}
+
+func (r iemRow) str(name string, f Field, present *FieldSet) string {
+	v, ok := r.value(name)
+	if ok {
+		present.Add(f)
+	}
+	return v
+}
+
+func (r iemRow) float(name string, f Field, present *FieldSet) float64 {
+	v, ok := r.value(name)
+	if !ok {
+		return 0
+	}
+
+	fv, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0
+	}
+
+	present.Add(f)
+	return fv
+}
@@ -0,0 +1,76 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IEM", func() {
+	var (
+		query  url.Values
+		server *httptest.Server
+		src    IEM
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			http.ServeFile(w, r, filepath.Join("testdata", "iem.csv"))
+		}))
+		src = IEM{BaseURL: server.URL}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should require a time window", func() {
+		_, err := src.Fetch(context.Background(), Query{Stations: []string{"EDDH"}})
+		Expect(err).To(Equal(ErrMissingTimeWindow))
+	})
+
+	It("should return all archived reports", func() {
+		results, err := src.Fetch(context.Background(), Query{
+			Stations:  []string{"EDDH"},
+			Selection: AllReports,
+			Start:     time.Date(2016, 5, 20, 6, 0, 0, 0, time.UTC),
+			End:       time.Date(2016, 5, 20, 7, 0, 0, 0, time.UTC),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query.Get("station")).To(Equal("EDDH"))
+		Expect(query.Get("year1")).To(Equal("2016"))
+		Expect(query.Get("hour2")).To(Equal("7"))
+
+		Expect(results).To(HaveLen(2))
+		Expect(results[1].StationID).To(Equal("EDDH"))
+		Expect(results[1].ObservationTime).To(Equal(time.Date(2016, 5, 20, 6, 50, 0, 0, time.UTC)))
+		Expect(results[1].Temperature).To(BeNumerically("~", 11, 0.001))
+		Expect(results[1].WindDirDegrees).To(Equal(int64(270)))
+		Expect(results[1].WindSpeed).To(Equal(int64(9)))
+		Expect(results[1].WXString).To(Equal("-RA"))
+		Expect(results[1].SkyCondition.SkyCover).To(Equal(SkyCoverBKN))
+		Expect(results[1].RawText).To(HavePrefix("EDDH 200650Z"))
+		Expect(results[1].Present.Has(FieldWindGust)).To(BeFalse())
+		Expect(results[1].Present.Has(FieldTemperature)).To(BeTrue())
+	})
+
+	It("should select the most recent report", func() {
+		results, err := src.Fetch(context.Background(), Query{
+			Stations: []string{"EDDH"},
+			Start:    time.Date(2016, 5, 20, 6, 0, 0, 0, time.UTC),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].ObservationTime.Minute()).To(Equal(50))
+	})
+
+})
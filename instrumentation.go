@@ -0,0 +1,37 @@
+package metar
+
+import (
+	"context"
+	"time"
+)
+
+// Instrumentation receives notifications about upstream requests made by a
+// Client. See the metarotel package for an OpenTelemetry implementation.
+type Instrumentation interface {
+	// FetchStarted is called before an upstream request is made. The
+	// returned context is used for the request and passed to FetchFinished.
+	FetchStarted(ctx context.Context, station string) context.Context
+	// FetchFinished is called after the upstream request has completed
+	FetchFinished(ctx context.Context, info FetchInfo)
+}
+
+// FetchInfo describes a finished upstream request
+type FetchInfo struct {
+	Station    string        // Station identifier as requested
+	Duration   time.Duration // Time spent on the request including decoding
+	StatusCode int           // HTTP status code, zero if no response was received
+	Results    int           // Number of results contained in the response
+	Err        error         // Error which occurred during the request
+
+	// NotModified is set if the upstream answered 304 Not Modified and the
+	// results were decoded from the response kept by
+	// WithConditionalRequests. StatusCode is 304 in this case while the
+	// results carry the status of the kept response.
+	NotModified bool
+}
+
+// WithInstrumentation reports every upstream request to the given
+// Instrumentation
+func WithInstrumentation(i Instrumentation) ClientOption {
+	return func(c *Client) { c.instrumentation = i }
+}
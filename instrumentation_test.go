@@ -0,0 +1,54 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type ctxKey string
+
+type recordingInstrumentation struct {
+	started []string
+	infos   []FetchInfo
+	ctxOK   bool
+}
+
+func (r *recordingInstrumentation) FetchStarted(ctx context.Context, station string) context.Context {
+	r.started = append(r.started, station)
+	return context.WithValue(ctx, ctxKey("span"), station)
+}
+
+func (r *recordingInstrumentation) FetchFinished(ctx context.Context, info FetchInfo) {
+	r.ctxOK = ctx.Value(ctxKey("span")) == info.Station
+	r.infos = append(r.infos, info)
+}
+
+var _ = Describe("Instrumentation", func() {
+
+	It("should report upstream requests", func() {
+		inst := &recordingInstrumentation{}
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithInstrumentation(inst),
+		)
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(inst.started).To(Equal([]string{"EDDH"}))
+		Expect(inst.infos).To(HaveLen(1))
+		Expect(inst.ctxOK).To(BeTrue())
+		Expect(inst.infos[0].StatusCode).To(Equal(http.StatusOK))
+		Expect(inst.infos[0].Results).To(Equal(1))
+		Expect(inst.infos[0].Err).NotTo(HaveOccurred())
+		Expect(inst.infos[0].Duration).To(BeNumerically(">", 0))
+	})
+
+})
@@ -0,0 +1,94 @@
+package metar
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrNoPositions is returned by Interpolate if none of the results
+// contains the station position
+var ErrNoPositions = errors.New("No results with station position to interpolate from")
+
+// Estimate holds the values Interpolate estimated for a position. Values
+// whose Has flag is false could not be estimated from the results.
+type Estimate struct {
+	Temperature    float64 // Air temperature (celsius)
+	QNH            float64 // Altimeter setting (hPa)
+	WindDirDegrees float64 // Direction from which the wind is blowing (1-360), 0 if calm
+	WindSpeed      float64 // Wind speed (kts)
+
+	HasTemperature bool
+	HasQNH         bool
+	HasWind        bool
+
+	Stations  int     // Number of results used for the estimate
+	NearestKm float64 // Distance to the nearest station
+}
+
+// Interpolate estimates temperature, pressure and wind at the position
+// using inverse distance weighting (power 2) of the given results.
+//
+// This is experimental: differences in elevation and terrain between the
+// stations and the position are not taken into account. Wind is averaged
+// as vectors, variable winds are left out.
+func Interpolate(lat, lon float64, results []Result) (Estimate, error) {
+	var (
+		e                  = Estimate{NearestKm: math.Inf(1)}
+		temp, qnh, u, v    float64
+		wTemp, wQNH, wWind float64
+	)
+
+	for _, r := range results {
+		d, ok := r.DistanceTo(lat, lon)
+		if !ok {
+			continue
+		}
+		e.Stations++
+		e.NearestKm = math.Min(e.NearestKm, d)
+
+		// Stations closer than 100m get the same (dominating) weight
+		w := 1 / math.Max(d*d, 0.01)
+
+		if r.Present.Has(FieldTemperature) {
+			temp += w * r.Temperature
+			wTemp += w
+		}
+
+		if p, ok := r.QNH(); ok {
+			qnh += w * p
+			wQNH += w
+		}
+
+		if r.Present.Has(FieldWindSpeed) && (r.WindDirDegrees != 0 || r.WindSpeed == 0) {
+			rad := float64(r.WindDirDegrees) * math.Pi / 180
+			u += w * float64(r.WindSpeed) * math.Sin(rad)
+			v += w * float64(r.WindSpeed) * math.Cos(rad)
+			wWind += w
+		}
+	}
+
+	if e.Stations == 0 {
+		return Estimate{}, ErrNoPositions
+	}
+
+	if wTemp > 0 {
+		e.Temperature, e.HasTemperature = temp/wTemp, true
+	}
+
+	if wQNH > 0 {
+		e.QNH, e.HasQNH = qnh/wQNH, true
+	}
+
+	if wWind > 0 {
+		u, v = u/wWind, v/wWind
+		e.WindSpeed, e.HasWind = math.Hypot(u, v), true
+		if e.WindSpeed > 0 {
+			e.WindDirDegrees = math.Mod(math.Atan2(u, v)*180/math.Pi+360, 360)
+			if e.WindDirDegrees == 0 {
+				e.WindDirDegrees = 360
+			}
+		}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,54 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interpolation", func() {
+
+	station := func(raw string, lat, lon float64) Result {
+		r, err := ParseRaw(raw)
+		Expect(err).NotTo(HaveOccurred())
+		r.Latitude, r.Longitude = lat, lon
+		r.Present.Add(FieldLatitude)
+		r.Present.Add(FieldLongitude)
+		return *r
+	}
+
+	It("should weight the stations by distance", func() {
+		e, err := Interpolate(0, 0.25, []Result{
+			station("AAAA 211020Z 09010KT 9999 FEW030 10/05 Q1010", 0, 0),
+			station("BBBB 211020Z 09010KT 9999 FEW030 20/05 Q1020", 0, 1),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		// Weights 1/0.25² and 1/0.75² are 9:1
+		Expect(e.HasTemperature).To(BeTrue())
+		Expect(e.Temperature).To(BeNumerically("~", 11, 0.01))
+		Expect(e.HasQNH).To(BeTrue())
+		Expect(e.QNH).To(BeNumerically("~", 1011, 0.01))
+		Expect(e.Stations).To(Equal(2))
+		Expect(e.NearestKm).To(BeNumerically("~", 27.8, 0.1))
+	})
+
+	It("should average the wind as vectors", func() {
+		e, err := Interpolate(0, 0.5, []Result{
+			station("AAAA 211020Z 35010KT 9999 FEW030 10/05 Q1010", 0, 0),
+			station("BBBB 211020Z 01010KT 9999 FEW030 10/05 Q1010", 0, 1),
+			station("CCCC 211020Z VRB02KT 9999 FEW030 10/05 Q1010", 0, 0.5),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(e.HasWind).To(BeTrue())
+		Expect(e.WindDirDegrees).To(BeNumerically("~", 360, 0.01))
+		Expect(e.WindSpeed).To(BeNumerically("~", 9.85, 0.01))
+	})
+
+	It("should skip results without position", func() {
+		_, err := Interpolate(0, 0, []Result{{Temperature: 10}})
+		Expect(err).To(Equal(ErrNoPositions))
+	})
+
+})
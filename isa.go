@@ -0,0 +1,17 @@
+package metar
+
+// ISATemperature returns the temperature (celsius) of the International
+// Standard Atmosphere at the given elevation (meters) within the troposphere
+func ISATemperature(elevation float64) float64 {
+	return isaSeaLevelTempK - 273.15 - isaLapseRate*elevation
+}
+
+// ISADeviation returns the difference between the reported temperature
+// and the ISA temperature at station elevation (e.g. ISA+12). It requires
+// the report to contain temperature and station elevation.
+func (r Result) ISADeviation() (float64, bool) {
+	if !r.Present.Has(FieldTemperature) || !r.Present.Has(FieldElevation) {
+		return 0, false
+	}
+	return r.Temperature - ISATemperature(r.Elevation), true
+}
@@ -0,0 +1,30 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ISA", func() {
+
+	It("should compute the standard temperature", func() {
+		Expect(ISATemperature(0)).To(BeNumerically("~", 15, 1e-9))
+		Expect(ISATemperature(1000)).To(BeNumerically("~", 8.5, 1e-9))
+	})
+
+	It("should compute the deviation of a result", func() {
+		r := Result{Temperature: 30, Elevation: 1655}
+		r.Present.Add(FieldTemperature)
+
+		_, ok := r.ISADeviation()
+		Expect(ok).To(BeFalse())
+
+		r.Present.Add(FieldElevation)
+		d, ok := r.ISADeviation()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(BeNumerically("~", 25.76, 0.01))
+	})
+
+})
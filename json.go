@@ -0,0 +1,184 @@
+package metar
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JSONSchemaVersion is the version of the JSON representation of Results
+// written by MarshalJSON. The format is described by the JSON schema in
+// schema/result.v1.json, keys follow the dataserver element names.
+const JSONSchemaVersion = 1
+
+// resultJSON is version 1 of the JSON representation of a Result
+type resultJSON struct {
+	SchemaVersion int `json:"schema_version"`
+
+	RawText             string          `json:"raw_text"`
+	StationID           string          `json:"station_id"`
+	ObservationTime     time.Time       `json:"observation_time"`
+	Latitude            float64         `json:"latitude"`
+	Longitude           float64         `json:"longitude"`
+	Temperature         float64         `json:"temp_c"`
+	Dewpoint            float64         `json:"dewpoint_c"`
+	WindDirDegrees      int64           `json:"wind_dir_degrees"`
+	WindSpeed           int64           `json:"wind_speed_kt"`
+	WindGust            int64           `json:"wind_gust_kt"`
+	VisibilityStatute   float64         `json:"visibility_statute_mi"`
+	Altimeter           float64         `json:"altim_in_hg"`
+	SeaLevelPressure    float64         `json:"sea_level_pressure_mb"`
+	QualityControlFlags qualityFlagJSON `json:"quality_control_flags"`
+	WXString            string          `json:"wx_string"`
+	SkyCover            SkyCover        `json:"sky_cover"`
+	FlightCategory      FlightCategory  `json:"flight_category"`
+	PrecipitationIn     float64         `json:"precip_in"`
+	SnowDepthIn         float64         `json:"snow_in"`
+	VerticalVisibility  int64           `json:"vert_vis_ft"`
+	MetarType           string          `json:"metar_type"`
+	Elevation           float64         `json:"elevation_m"`
+
+	Automated  bool       `json:"automated"`
+	Correction string     `json:"correction"`
+	SensorType SensorType `json:"sensor_type"`
+
+	Source     string    `json:"source"`
+	SourceURL  string    `json:"source_url"`
+	StatusCode int       `json:"status_code"`
+	FetchedAt  time.Time `json:"fetched_at"`
+	FromCache  bool      `json:"from_cache"`
+	Stale      bool      `json:"stale"`
+	Present    FieldSet  `json:"present"`
+}
+
+type qualityFlagJSON struct {
+	Corrected            bool `json:"corrected"`
+	Auto                 bool `json:"auto"`
+	AutoStation          bool `json:"auto_station"`
+	MaintenanceIndicator bool `json:"maintenance_indicator_on"`
+	NoSignal             bool `json:"no_signal"`
+}
+
+// legacyResult has the methods of Result stripped to decode JSON written
+// before the schema was versioned (struct field names as keys)
+type legacyResult Result
+
+// MarshalJSON encodes the result using the versioned JSON representation
+// (see JSONSchemaVersion). All values are always present, use the present
+// list to tell missing values from zero values.
+func (r Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(resultJSON{
+		SchemaVersion: JSONSchemaVersion,
+
+		RawText:           r.RawText,
+		StationID:         r.StationID,
+		ObservationTime:   r.ObservationTime,
+		Latitude:          r.Latitude,
+		Longitude:         r.Longitude,
+		Temperature:       r.Temperature,
+		Dewpoint:          r.Dewpoint,
+		WindDirDegrees:    r.WindDirDegrees,
+		WindSpeed:         r.WindSpeed,
+		WindGust:          r.WindGust,
+		VisibilityStatute: r.VisibilityStatute,
+		Altimeter:         r.Altimeter,
+		SeaLevelPressure:  r.SeaLevelPressure,
+		QualityControlFlags: qualityFlagJSON{
+			Corrected:            r.QualityControlFlags.Corrected,
+			Auto:                 r.QualityControlFlags.Auto,
+			AutoStation:          r.QualityControlFlags.AutoStation,
+			MaintenanceIndicator: r.QualityControlFlags.MaintenanceIndicator,
+			NoSignal:             r.QualityControlFlags.NoSignal,
+		},
+		WXString:           r.WXString,
+		SkyCover:           r.SkyCondition.SkyCover,
+		FlightCategory:     r.FlightCategory,
+		PrecipitationIn:    r.PrecipitationIn,
+		SnowDepthIn:        r.SnowDepthIn,
+		VerticalVisibility: r.VerticalVisibilityFt,
+		MetarType:          r.MetarType,
+		Elevation:          r.Elevation,
+
+		Automated:  r.Automated,
+		Correction: r.Correction,
+		SensorType: r.SensorType,
+
+		Source:     r.Source,
+		SourceURL:  r.SourceURL,
+		StatusCode: r.StatusCode,
+		FetchedAt:  r.FetchedAt,
+		FromCache:  r.FromCache,
+		Stale:      r.Stale,
+		Present:    r.Present,
+	})
+}
+
+// UnmarshalJSON decodes the versioned JSON representation. Documents
+// without schema version written by earlier versions of this package are
+// decoded using the struct field names.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var version struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &version); err != nil {
+		return err
+	}
+
+	switch version.SchemaVersion {
+	case 0:
+		return json.Unmarshal(data, (*legacyResult)(r))
+	case JSONSchemaVersion:
+	default:
+		return fmt.Errorf("Unsupported JSON schema version %d", version.SchemaVersion)
+	}
+
+	var v resultJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*r = Result{
+		RawText:           v.RawText,
+		StationID:         v.StationID,
+		ObservationTime:   v.ObservationTime,
+		Latitude:          v.Latitude,
+		Longitude:         v.Longitude,
+		Temperature:       v.Temperature,
+		Dewpoint:          v.Dewpoint,
+		WindDirDegrees:    v.WindDirDegrees,
+		WindSpeed:         v.WindSpeed,
+		WindGust:          v.WindGust,
+		VisibilityStatute: v.VisibilityStatute,
+		Altimeter:         v.Altimeter,
+		SeaLevelPressure:  v.SeaLevelPressure,
+		QualityControlFlags: QualityControlFlags{
+			Corrected:            v.QualityControlFlags.Corrected,
+			Auto:                 v.QualityControlFlags.Auto,
+			AutoStation:          v.QualityControlFlags.AutoStation,
+			MaintenanceIndicator: v.QualityControlFlags.MaintenanceIndicator,
+			NoSignal:             v.QualityControlFlags.NoSignal,
+		},
+		WXString:             v.WXString,
+		FlightCategory:       v.FlightCategory,
+		PrecipitationIn:      v.PrecipitationIn,
+		SnowDepthIn:          v.SnowDepthIn,
+		VerticalVisibilityFt: v.VerticalVisibility,
+		MetarType:            v.MetarType,
+		Elevation:            v.Elevation,
+
+		Automated:  v.Automated,
+		Correction: v.Correction,
+		SensorType: v.SensorType,
+
+		Source:     v.Source,
+		SourceURL:  v.SourceURL,
+		StatusCode: v.StatusCode,
+		FetchedAt:  v.FetchedAt,
+		FromCache:  v.FromCache,
+		Stale:      v.Stale,
+		Present:    v.Present,
+	}
+	r.XMLName.Local = "METAR"
+	r.SkyCondition.SkyCover = v.SkyCover
+	return nil
+}
@@ -0,0 +1,83 @@
+package metar_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSON", func() {
+
+	var r Result
+
+	BeforeEach(func() {
+		r = Result{
+			RawText:         "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018",
+			StationID:       "EDDH",
+			ObservationTime: time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC),
+			Temperature:     17,
+			WindDirDegrees:  270,
+			FlightCategory:  FlightCategoryVFR,
+			Automated:       true,
+		}
+		r.XMLName.Local = "METAR"
+		r.SkyCondition.SkyCover = SkyCoverFEW
+		r.QualityControlFlags.Auto = true
+		r.Present.Add(FieldStationID)
+		r.Present.Add(FieldTemperature)
+	})
+
+	It("should write the keys documented in the schema", func() {
+		data, err := json.Marshal(r)
+		Expect(err).NotTo(HaveOccurred())
+
+		var doc map[string]interface{}
+		Expect(json.Unmarshal(data, &doc)).To(Succeed())
+		Expect(doc["schema_version"]).To(Equal(float64(JSONSchemaVersion)))
+
+		raw, err := ioutil.ReadFile(filepath.Join("schema", "result.v1.json"))
+		Expect(err).NotTo(HaveOccurred())
+		var schema struct {
+			Required []string `json:"required"`
+		}
+		Expect(json.Unmarshal(raw, &schema)).To(Succeed())
+
+		var keys []string
+		for k := range doc {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sort.Strings(schema.Required)
+		Expect(keys).To(Equal(schema.Required))
+	})
+
+	It("should round-trip results", func() {
+		data, err := json.Marshal(r)
+		Expect(err).NotTo(HaveOccurred())
+
+		var got Result
+		Expect(json.Unmarshal(data, &got)).To(Succeed())
+		Expect(got).To(Equal(r))
+	})
+
+	It("should decode documents without schema version", func() {
+		var got Result
+		Expect(json.Unmarshal([]byte(`{"StationID":"EDDH","Temperature":17,"Present":["temp_c"]}`), &got)).To(Succeed())
+		Expect(got.StationID).To(Equal("EDDH"))
+		Expect(got.Temperature).To(Equal(17.0))
+		Expect(got.Present.Has(FieldTemperature)).To(BeTrue())
+	})
+
+	It("should reject unknown schema versions", func() {
+		var got Result
+		Expect(json.Unmarshal([]byte(`{"schema_version":2}`), &got)).NotTo(Succeed())
+	})
+
+})
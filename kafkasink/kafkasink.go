@@ -0,0 +1,68 @@
+// Package kafkasink publishes observations collected by a metar.Collector
+// to Kafka topics.
+//
+//	p := kafkasink.New("localhost:9092")
+//	defer p.Close()
+//	sink := metar.NewPublishSink(p, "metar-observations")
+package kafkasink
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+var _ metar.BatchPublisher = (*Publisher)(nil)
+
+// Publisher implements metar.BatchPublisher writing to Kafka. Messages
+// are partitioned by their key so all observations of a station keep
+// their order.
+type Publisher struct {
+	w Writer
+}
+
+// Writer writes messages to Kafka, implemented by kafka.Writer
+type Writer interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// New creates a Publisher writing to the given brokers. The batch timeout
+// is lowered from the default of one second as every write is synchronous.
+func New(brokers ...string) *Publisher {
+	return NewWithWriter(&kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Balancer:               &kafka.Hash{},
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           10 * time.Millisecond,
+	})
+}
+
+// NewWithWriter creates a Publisher using a preconfigured writer. The
+// writer must not have a Topic set as the topic is given per message.
+func NewWithWriter(w Writer) *Publisher {
+	return &Publisher{w: w}
+}
+
+// Publish writes the message to the topic
+func (p *Publisher) Publish(ctx context.Context, topic, key string, value []byte) error {
+	return p.w.WriteMessages(ctx, kafka.Message{Topic: topic, Key: []byte(key), Value: value})
+}
+
+// PublishBatch writes all messages using a single request as the writer
+// waits up to its BatchTimeout for every synchronous write
+func (p *Publisher) PublishBatch(ctx context.Context, messages []metar.PublishMessage) error {
+	msgs := make([]kafka.Message, len(messages))
+	for i, m := range messages {
+		msgs[i] = kafka.Message{Topic: m.Topic, Key: []byte(m.Key), Value: m.Value}
+	}
+	return p.w.WriteMessages(ctx, msgs...)
+}
+
+// Close flushes pending messages and closes the writer
+func (p *Publisher) Close() error {
+	return p.w.Close()
+}
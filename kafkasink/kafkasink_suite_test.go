@@ -0,0 +1,13 @@
+package kafkasink_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestKafkasink(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Kafkasink Suite")
+}
@@ -0,0 +1,66 @@
+package kafkasink_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/segmentio/kafka-go"
+
+	metar "github.com/Luzifer/go-metar"
+	. "github.com/Luzifer/go-metar/kafkasink"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingWriter struct {
+	writes [][]kafka.Message
+	err    error
+	closed bool
+}
+
+func (w *recordingWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.writes = append(w.writes, msgs)
+	return w.err
+}
+
+func (w *recordingWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+var _ = Describe("Publisher", func() {
+
+	It("should write all observations of a sink write at once", func() {
+		w := &recordingWriter{}
+		sink := metar.NewPublishSink(NewWithWriter(w), "metar-observations")
+
+		Expect(sink.Write(context.Background(), []metar.Result{{StationID: "EDDH"}, {StationID: "EDDW"}, {StationID: "EDDF"}})).To(Succeed())
+
+		Expect(w.writes).To(HaveLen(1))
+		Expect(w.writes[0]).To(HaveLen(3))
+		Expect(w.writes[0][0].Topic).To(Equal("metar-observations"))
+		Expect(string(w.writes[0][0].Key)).To(Equal("EDDH"))
+		Expect(string(w.writes[0][2].Key)).To(Equal("EDDF"))
+		Expect(string(w.writes[0][1].Value)).To(ContainSubstring(`"station_id":"EDDW"`))
+	})
+
+	It("should write single messages", func() {
+		w := &recordingWriter{}
+		Expect(NewWithWriter(w).Publish(context.Background(), "topic", "EDDH", []byte("{}"))).To(Succeed())
+		Expect(w.writes).To(Equal([][]kafka.Message{{{Topic: "topic", Key: []byte("EDDH"), Value: []byte("{}")}}}))
+	})
+
+	It("should report failed writes", func() {
+		w := &recordingWriter{err: errors.New("Leader not available")}
+		err := metar.NewPublishSink(NewWithWriter(w), "topic").Write(context.Background(), []metar.Result{{StationID: "EDDH"}})
+		Expect(err).To(MatchError("Publishing observations failed: Leader not available"))
+	})
+
+	It("should close the writer", func() {
+		w := &recordingWriter{}
+		Expect(NewWithWriter(w).Close()).To(Succeed())
+		Expect(w.closed).To(BeTrue())
+	})
+
+})
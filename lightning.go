@@ -0,0 +1,107 @@
+package metar
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LightningFrequency is the frequency of lightning reported in the remarks
+type LightningFrequency string
+
+// Lightning frequencies, LightningFrequencyUnknown if none was reported
+const (
+	LightningFrequencyUnknown    LightningFrequency = ""
+	LightningFrequencyOccasional LightningFrequency = "OCNL" // Less than 1 flash per minute
+	LightningFrequencyFrequent   LightningFrequency = "FRQ"  // 1 to 6 flashes per minute
+	LightningFrequencyContinuous LightningFrequency = "CONS" // More than 6 flashes per minute
+)
+
+// LightningType is the type of lightning reported in the remarks
+type LightningType string
+
+// Lightning types
+const (
+	LightningInCloud       LightningType = "IC" // Within the cloud
+	LightningCloudToCloud  LightningType = "CC" // Between clouds
+	LightningCloudToGround LightningType = "CG" // Between cloud and ground
+	LightningCloudToAir    LightningType = "CA" // Between cloud and surrounding air
+)
+
+// LightningDistance is the distance of lightning to the station
+type LightningDistance string
+
+// Lightning distances, LightningDistanceUnknown if none was reported
+const (
+	LightningDistanceUnknown LightningDistance = ""
+	LightningOverhead        LightningDistance = "OHD"  // Over the station
+	LightningVicinity        LightningDistance = "VC"   // 5 to 10 statute miles from the station
+	LightningDistant         LightningDistance = "DSNT" // More than 10 statute miles from the station
+)
+
+var lightningDirectionRegex = regexp.MustCompile(`^(?:[NESW]{1,2}(?:-[NESW]{1,2})*|ALQDS)$`)
+
+// Lightning is a lightning remark (OCNL LTGICCG DSNT SW)
+type Lightning struct {
+	Frequency LightningFrequency
+	Types     []LightningType
+	Distance  LightningDistance
+	// Directions the lightning was observed in as reported (SW, N-NE),
+	// ALQDS for all quadrants
+	Directions []string
+}
+
+// Lightning returns the lightning remarks of the report
+func (r Result) Lightning() []Lightning {
+	var (
+		rmk    = r.Remarks()
+		result []Lightning
+	)
+
+	for i, g := range rmk {
+		if !strings.HasPrefix(g, "LTG") {
+			continue
+		}
+
+		l := Lightning{}
+		ok := true
+		for t := g[3:]; t != "" && ok; t = t[2:] {
+			if len(t) < 2 {
+				ok = false
+				break
+			}
+			switch lt := LightningType(t[:2]); lt {
+			case LightningInCloud, LightningCloudToCloud, LightningCloudToGround, LightningCloudToAir:
+				l.Types = append(l.Types, lt)
+			default:
+				ok = false
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		if i > 0 {
+			switch f := LightningFrequency(rmk[i-1]); f {
+			case LightningFrequencyOccasional, LightningFrequencyFrequent, LightningFrequencyContinuous:
+				l.Frequency = f
+			}
+		}
+
+	location:
+		for _, loc := range rmk[i+1:] {
+			switch d := LightningDistance(loc); {
+			case d == LightningOverhead, d == LightningVicinity, d == LightningDistant:
+				l.Distance = d
+			case loc == "AND":
+			case lightningDirectionRegex.MatchString(loc):
+				l.Directions = append(l.Directions, loc)
+			default:
+				break location
+			}
+		}
+
+		result = append(result, l)
+	}
+
+	return result
+}
@@ -0,0 +1,56 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lightning", func() {
+
+	It("should decode frequency, types, distance and direction", func() {
+		r := Result{RawText: "KMIA 151853Z 09015G28KT 2SM +TSRA BR BKN025CB 24/22 A2990 RMK AO2 OCNL LTGICCG DSNT SW SLP125"}
+
+		Expect(r.Lightning()).To(Equal([]Lightning{{
+			Frequency:  LightningFrequencyOccasional,
+			Types:      []LightningType{LightningInCloud, LightningCloudToGround},
+			Distance:   LightningDistant,
+			Directions: []string{"SW"},
+		}}))
+	})
+
+	It("should decode direction ranges and multiple remarks", func() {
+		r := Result{RawText: "KTPA 151853Z 09015KT 3SM TSRA BKN025CB 24/22 A2990 RMK AO2 FRQ LTGCCCG OHD AND NE-S CONS LTGIC VC ALQDS T02440222"}
+
+		l := r.Lightning()
+		Expect(l).To(HaveLen(2))
+		Expect(l[0]).To(Equal(Lightning{
+			Frequency:  LightningFrequencyFrequent,
+			Types:      []LightningType{LightningCloudToCloud, LightningCloudToGround},
+			Distance:   LightningOverhead,
+			Directions: []string{"NE-S"},
+		}))
+		Expect(l[1]).To(Equal(Lightning{
+			Frequency:  LightningFrequencyContinuous,
+			Types:      []LightningType{LightningInCloud},
+			Distance:   LightningVicinity,
+			Directions: []string{"ALQDS"},
+		}))
+	})
+
+	It("should decode lightning without frequency and type", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 10SM FEW050 24/22 A2990 RMK AO2 LTG DSNT W"}
+
+		Expect(r.Lightning()).To(Equal([]Lightning{{
+			Distance:   LightningDistant,
+			Directions: []string{"W"},
+		}}))
+	})
+
+	It("should ignore unknown lightning types", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 10SM FEW050 24/22 A2990 RMK AO2 LTGXX DSNT W"}
+		Expect(r.Lightning()).To(BeEmpty())
+	})
+
+})
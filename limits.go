@@ -0,0 +1,63 @@
+package metar
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrResponseTooLarge is returned when an upstream response exceeds the
+	// size configured using WithMaxResponseSize
+	ErrResponseTooLarge = errors.New("Upstream response exceeds the maximum response size")
+	// ErrTooManyResults is returned when an upstream response contains more
+	// results than configured using WithMaxResults
+	ErrTooManyResults = errors.New("Upstream response exceeds the maximum number of results")
+)
+
+// WithMaxResponseSize limits the size of upstream responses in bytes.
+// Reading a larger response fails with ErrResponseTooLarge. Responses are
+// not limited by default.
+func WithMaxResponseSize(bytes int64) ClientOption {
+	return func(c *Client) { c.maxResponseSize = bytes }
+}
+
+// WithMaxResults limits the number of results decoded from a single
+// upstream response. Decoding stops with ErrTooManyResults as soon as the
+// limit is exceeded instead of materializing all results first. Results
+// are not limited by default.
+func WithMaxResults(n int) ClientOption {
+	return func(c *Client) { c.maxResults = n }
+}
+
+// maxResults returns the result limit of the client making the request
+func maxResults(ctx context.Context) int {
+	if rc, ok := ctx.Value(requestContextKey{}).(requestContext); ok {
+		return rc.client.maxResults
+	}
+	return 0
+}
+
+// limitedBody fails reads beyond the remaining number of bytes
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	// Read one byte more than allowed to detect oversized responses
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), ErrResponseTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,48 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Limits", func() {
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, filepath.Join("testdata", "multi.xml"))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should fail on oversized responses", func() {
+		client := NewClient(WithBaseURL(server.URL), WithMaxResponseSize(512))
+		_, err := client.FetchStationsWeather(context.Background(), []string{"EDDH", "EDDF"})
+		Expect(err).To(Equal(ErrResponseTooLarge))
+	})
+
+	It("should fail on too many results", func() {
+		client := NewClient(WithBaseURL(server.URL), WithMaxResults(1))
+		_, err := client.FetchStationsWeather(context.Background(), []string{"EDDH", "EDDF"})
+		Expect(err).To(Equal(ErrTooManyResults))
+	})
+
+	It("should pass responses within the limits", func() {
+		client := NewClient(WithBaseURL(server.URL), WithMaxResponseSize(1<<20), WithMaxResults(2))
+		results, err := client.FetchStationsWeather(context.Background(), []string{"EDDH", "EDDF"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+	})
+
+})
@@ -0,0 +1,31 @@
+package metar
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// WithLogger makes the client log its upstream requests, cache usage and
+// failures to the given logger. Request details are logged at debug level,
+// failures and degraded operation (stale results, open circuit) at warn
+// level. A nil logger disables logging.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if l == nil {
+			l = discardLogger
+		}
+		c.logger = l
+	}
+}
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// requestLogger returns the logger of the Client executing the request,
+// sources used on their own do not log
+func requestLogger(ctx context.Context) *slog.Logger {
+	if rc, ok := ctx.Value(requestContextKey{}).(requestContext); ok {
+		return rc.client.logger
+	}
+	return discardLogger
+}
@@ -0,0 +1,76 @@
+package metar_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logging", func() {
+
+	It("should log upstream requests to the configured logger", func() {
+		buf := new(bytes.Buffer)
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		)
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(ContainSubstring("requesting upstream"))
+		Expect(buf.String()).To(ContainSubstring("stationString=EDDH"))
+		Expect(buf.String()).To(ContainSubstring("upstream request finished"))
+	})
+
+	It("should warn about inconsistent responses", func() {
+		buf := new(bytes.Buffer)
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, `<response><data num_results="2"><METAR><station_id>EDDH</station_id></METAR></data></response>`)
+			})}),
+			WithLogger(slog.New(slog.NewTextHandler(buf, nil))),
+		)
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).To(Equal(ErrInconsistentResults))
+		Expect(buf.String()).To(ContainSubstring("inconsistent response"))
+		Expect(buf.String()).To(ContainSubstring("num_results=2 delivered=1"))
+	})
+
+	It("should not log without a logger", func() {
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithLogger(nil),
+		)
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should warn about stale observations", func() {
+		buf := new(bytes.Buffer)
+		client := NewClient(
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, singleResultXML)
+			})}),
+			WithMaxObservationAge(time.Hour),
+			WithLogger(slog.New(slog.NewTextHandler(buf, nil))),
+		)
+
+		_, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).To(Equal(ErrStaleObservation))
+		Expect(buf.String()).To(ContainSubstring("level=WARN msg=\"observation exceeds maximum age\""))
+	})
+
+})
@@ -0,0 +1,35 @@
+package metar
+
+import "math"
+
+// TrueToMagnetic converts a true north referenced direction into a
+// magnetic one using the magnetic declination (positive east, negative
+// west) at the position
+func TrueToMagnetic(deg, declination float64) float64 {
+	return normalizeDegrees(deg - declination)
+}
+
+// MagneticToTrue converts a magnetic direction into a true north
+// referenced one using the magnetic declination (positive east, negative
+// west) at the position
+func MagneticToTrue(deg, declination float64) float64 {
+	return normalizeDegrees(deg + declination)
+}
+
+func normalizeDegrees(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// WindDirectionMagnetic returns the wind direction referenced to magnetic
+// north for comparison with runway headings. Variable or missing wind
+// directions are reported as not available.
+func (r Result) WindDirectionMagnetic(declination float64) (float64, bool) {
+	if !r.Present.Has(FieldWindDirDegrees) || r.WindDirDegrees == 0 {
+		return 0, false
+	}
+	return TrueToMagnetic(float64(r.WindDirDegrees), declination), true
+}
@@ -0,0 +1,35 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Magnetic variation", func() {
+
+	It("should convert between true and magnetic directions", func() {
+		Expect(TrueToMagnetic(240, 3)).To(Equal(237.0))
+		Expect(TrueToMagnetic(240, -14)).To(Equal(254.0))
+		Expect(TrueToMagnetic(2, 5)).To(Equal(357.0))
+		Expect(MagneticToTrue(357, 5)).To(Equal(2.0))
+		Expect(MagneticToTrue(5, -10)).To(Equal(355.0))
+	})
+
+	It("should convert the wind direction of a result", func() {
+		r := Result{WindDirDegrees: 240}
+		_, ok := r.WindDirectionMagnetic(3)
+		Expect(ok).To(BeFalse())
+
+		r.Present.Add(FieldWindDirDegrees)
+		dir, ok := r.WindDirectionMagnetic(3)
+		Expect(ok).To(BeTrue())
+		Expect(dir).To(Equal(237.0))
+
+		r.WindDirDegrees = 0
+		_, ok = r.WindDirectionMagnetic(3)
+		Expect(ok).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,58 @@
+package metar
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SensorType is the type of an automated station reported in the remarks
+type SensorType string
+
+// Automated station types
+const (
+	SensorTypeAO1 SensorType = "AO1" // Automated station without precipitation discriminator
+	SensorTypeAO2 SensorType = "AO2" // Automated station with precipitation discriminator (rain / snow)
+)
+
+var correctionRegex = regexp.MustCompile(`^(?:COR|CC[A-Z])$`)
+
+// setReportMarkers decodes the AUTO and correction markers of the report
+// header and the station type from the remarks of the raw text. Values
+// only reported within the remarks are filled in by setRemarkValues.
+func setReportMarkers(r *Result) {
+	setTokenMarkers(r, Tokenize(r.RawText))
+}
+
+// setTokenMarkers works like setReportMarkers using the tokens of the raw
+// text the parser already has
+func setTokenMarkers(r *Result, tokens []Token) {
+	for _, t := range tokens {
+		switch t.Kind {
+		case TokenModifier:
+			switch {
+			case t.Text == "AUTO":
+				r.Automated = true
+			case correctionRegex.MatchString(t.Text):
+				r.Correction = t.Text
+			}
+
+		case TokenRemarks:
+			for _, g := range strings.Fields(t.Text) {
+				switch g {
+				// Some stations report the types with zeros instead of the letter O
+				case "AO1", "A01":
+					r.SensorType = SensorTypeAO1
+				case "AO2", "A02":
+					r.SensorType = SensorTypeAO2
+				}
+			}
+		}
+	}
+
+	r.Automated = r.Automated || r.QualityControlFlags.Auto
+	if r.Correction == "" && r.QualityControlFlags.Corrected {
+		r.Correction = "COR"
+	}
+
+	setRemarkValues(r)
+}
@@ -0,0 +1,44 @@
+package metar_test
+
+import (
+	"strings"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Report markers", func() {
+
+	It("should decode AUTO, corrections and the station type from raw reports", func() {
+		r, err := ParseRaw("METAR CCA KBOS 210754Z AUTO 19012KT 10SM BKN040 12/10 A2999 RMK AO2 SLP155")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Automated).To(BeTrue())
+		Expect(r.Correction).To(Equal("CCA"))
+		Expect(r.SensorType).To(Equal(SensorTypeAO2))
+	})
+
+	It("should leave manual reports unmarked", func() {
+		r, err := ParseRaw("EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Automated).To(BeFalse())
+		Expect(r.Correction).To(BeEmpty())
+		Expect(r.SensorType).To(BeEmpty())
+	})
+
+	It("should use the quality control flags of the dataserver", func() {
+		results, err := DecodeXML(strings.NewReader(`<response><data num_results="1"><METAR>
+			<raw_text>KXYZ 210754Z 19012KT 10SM CLR 12/10 A2999 RMK A01</raw_text>
+			<station_id>KXYZ</station_id>
+			<quality_control_flags><corrected>TRUE</corrected><auto>TRUE</auto><auto_station>TRUE</auto_station></quality_control_flags>
+		</METAR></data></response>`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].QualityControlFlags.AutoStation).To(BeTrue())
+		Expect(results[0].Automated).To(BeTrue())
+		Expect(results[0].Correction).To(Equal("COR"))
+		Expect(results[0].SensorType).To(Equal(SensorTypeAO1))
+	})
+
+})
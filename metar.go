@@ -1,20 +1,19 @@
 package metar
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
-	"fmt"
 	"net/http"
 	"time"
 )
 
-const (
-	apiSource = "https://www.aviationweather.gov/adds/dataserver_current/httpparam?dataSource=metars&requestType=retrieve&format=xml&stationString=%s&hoursBeforeNow=2&mostRecent=true"
-)
-
 var (
 	// HTTPClient is used to make requests, you can insert your own
 	HTTPClient = http.DefaultClient
+
+	// ErrNoResults is returned when the API did not return any data for the requested station
+	ErrNoResults = errors.New("Did not find any data for your station")
 )
 
 // Result holds all the data from the METAR request
@@ -39,15 +38,35 @@ type Result struct {
 		SkyCover SkyCover `xml:"sky_cover,attr"` // Sky cover, up to four levels of sky cover can be reported ; OVX present when vert_vis_ft is reported
 	} `xml:"sky_condition"`
 	FlightCategory FlightCategory `xml:"flight_category"` // Flight category of this METAR
-	// Fields 19 to 29 currently not implemented
-	MetarType string  `xml:"metar_type"`  // METAR or SPECI
-	Elevation float64 `xml:"elevation_m"` // The elevation of the station that reported this METAR (meters)
+	// Pressure tendency, temperature extremes and 3/6/24 hour precipitation currently not implemented
+	PrecipitationIn      float64 `xml:"precip_in"`   // Liquid precipitation since the last regular METAR (inches)
+	SnowDepthIn          float64 `xml:"snow_in"`     // Snow depth on the ground (inches)
+	VerticalVisibilityFt int64   `xml:"vert_vis_ft"` // Vertical visibility into an obscured sky (feet)
+	MetarType            string  `xml:"metar_type"`  // METAR or SPECI
+	Elevation            float64 `xml:"elevation_m"` // The elevation of the station that reported this METAR (meters)
+
+	Automated  bool       `xml:"-"` // Report was generated without human intervention (AUTO)
+	Correction string     `xml:"-"` // Correction marker of corrected reports (COR, CCA, CCB, ...)
+	SensorType SensorType `xml:"-"` // Type of the automated station (AO1 / AO2 in remarks)
+
+	Source     string    `xml:"-"` // Name of the Source which delivered the result (see SourceName)
+	SourceURL  string    `xml:"-"` // URL the result was retrieved from
+	StatusCode int       `xml:"-"` // HTTP status code of the upstream response containing the result
+	Payload    []byte    `xml:"-"` // Raw upstream response containing the result, only kept using WithRawPayload and shared by all results of the response
+	FetchedAt  time.Time `xml:"-"` // Time the result was retrieved from the upstream API
+	FromCache  bool      `xml:"-"` // Set when the result was served from the client's store instead of an upstream request
+	Stale      bool      `xml:"-"` // Set when a stored result is served past its revalidation interval or because upstream is unavailable
+	Present    FieldSet  `xml:"-"` // Fields reported by the upstream API, allows to tell missing values from zero values
 }
 
 // QualityControlFlags provide useful information about the METAR station(s) that provide the data.
 type QualityControlFlags struct {
-	XMLName  xml.Name `xml:"quality_control_flags"`
-	NoSignal bool     `xml:"no_signal"`
+	XMLName              xml.Name `xml:"quality_control_flags"`
+	Corrected            bool     `xml:"corrected"`                // Corrected report
+	Auto                 bool     `xml:"auto"`                     // Fully automated report
+	AutoStation          bool     `xml:"auto_station"`             // Station is of type AO1 or AO2
+	MaintenanceIndicator bool     `xml:"maintenance_indicator_on"` // Station requires maintenance ($)
+	NoSignal             bool     `xml:"no_signal"`
 }
 
 // SkyCover defines and explains possible sky coverage situations
@@ -62,6 +81,7 @@ const (
 	SkyCoverSCT   SkyCover = "SCT"   // "Scattered" = 3–4 oktas
 	SkyCoverBKN   SkyCover = "BKN"   // "Broken" = 5–7 oktas
 	SkyCoverOVC   SkyCover = "OVC"   //	"Overcast" = 8 oktas, i.e., full cloud coverage
+	SkyCoverOVX   SkyCover = "OVX"   // Sky obscured, reported together with a vertical visibility
 	SkyCoverCAVOK SkyCover = "CAVOK" // Ceiling And Visibility OKay, indicating no cloud below 5,000 ft (1,500 m) or the highest minimum sector altitude and no cumulonimbus or towering cumulus at any level, a visibility of 10 km (6 mi) or more and no significant weather change
 )
 
@@ -76,34 +96,23 @@ const (
 	FlightCategoryLIFR FlightCategory = "LIFR" // Low Instrument Flight Rules (Ceiling below 500 feet AGL and/or visibility less than 1 mile)
 )
 
-type response struct {
-	XMLName xml.Name `xml:"response"`
-	Data    struct {
-		NumResults int      `xml:"num_results,attr"`
-		Results    []Result `xml:"METAR"`
-	} `xml:"data"`
+var flightCategorySeverity = map[FlightCategory]int{
+	FlightCategoryVFR:  1,
+	FlightCategoryMVFR: 2,
+	FlightCategoryIFR:  3,
+	FlightCategoryLIFR: 4,
+}
+
+// WorseThan reports whether the flight category is more restrictive than
+// the other one. Unknown categories are never worse than any other.
+func (f FlightCategory) WorseThan(other FlightCategory) bool {
+	s, ok := flightCategorySeverity[f]
+	return ok && s > flightCategorySeverity[other]
 }
 
 // FetchCurrentStationWeather fetches the last result from the specified station if it was reported during last 2 hours
+//
+// The request is made using the DefaultClient.
 func FetchCurrentStationWeather(station string) (*Result, error) {
-	req, _ := http.NewRequest("GET", fmt.Sprintf(apiSource, station), nil)
-	res, err := HTTPClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r := &response{}
-	if err = xml.NewDecoder(res.Body).Decode(r); err != nil {
-		return nil, err
-	}
-
-	if r.Data.NumResults != len(r.Data.Results) {
-		return nil, errors.New("Got inconsistent number of results")
-	}
-
-	if r.Data.NumResults == 0 {
-		return nil, errors.New("Did not find any data for your station")
-	}
-
-	return &r.Data.Results[0], nil
+	return DefaultClient.FetchCurrentStationWeather(context.Background(), station)
 }
@@ -1,6 +1,13 @@
 package metar_test
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"time"
 
 	. "github.com/Luzifer/go-metar"
@@ -9,26 +16,49 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
 var _ = Describe("Metar", func() {
 	var (
+		fixture = ""
 		station = ""
+		query   string
+		server  *httptest.Server
+		client  *Client
 		result  *Result
 		err     error
 	)
 
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			http.ServeFile(w, r, filepath.Join("testdata", fixture))
+		}))
+		client = NewClient(WithSource(ADDS{BaseURL: server.URL}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
 	JustBeforeEach(func() {
-		result, err = FetchCurrentStationWeather(station)
+		result, err = client.FetchCurrentStationWeather(context.Background(), station)
 	})
 
 	Context("with station EDDH (HAM)", func() {
 		BeforeEach(func() {
-			station = "EDDH"
+			fixture, station = "eddh.xml", "EDDH"
 		})
 
 		It("should not have errored", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should have requested the station", func() {
+			Expect(query).To(ContainSubstring("stationString=EDDH"))
+			Expect(query).To(ContainSubstring("mostRecent=true"))
+		})
+
 		It("should be at the expected position", func() {
 			Expect(result.Latitude).To(Equal(53.63))
 			Expect(result.Longitude).To(Equal(10.0))
@@ -38,8 +68,8 @@ var _ = Describe("Metar", func() {
 			Expect(result.MetarType).To(Equal("METAR"))
 		})
 
-		It("should be a fairly new result", func() {
-			Expect(time.Since(result.ObservationTime) < 1*time.Hour).To(BeTrue())
+		It("should have the reported observation time", func() {
+			Expect(result.ObservationTime).To(Equal(time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)))
 		})
 
 		It("should have information about SkyCover and FlightCategory", func() {
@@ -48,4 +78,42 @@ var _ = Describe("Metar", func() {
 		})
 	})
 
+	Context("with a station not reporting", func() {
+		BeforeEach(func() {
+			fixture, station = "no_results.xml", "XXXX"
+		})
+
+		It("should report missing data", func() {
+			Expect(err).To(Equal(ErrNoResults))
+		})
+	})
+
+	for _, name := range []string{"eddh", "calm", "cavok", "thunderstorm", "no_flight_category"} {
+		name := name
+
+		Context("with payload "+name, func() {
+			BeforeEach(func() {
+				fixture, station = name+".xml", ""
+			})
+
+			It("should match the golden file", func() {
+				Expect(err).NotTo(HaveOccurred())
+				result.FetchedAt = time.Time{}
+				result.SourceURL = ""
+
+				got, jerr := json.MarshalIndent(result, "", "  ")
+				Expect(jerr).NotTo(HaveOccurred())
+
+				golden := filepath.Join("testdata", name+".golden.json")
+				if *updateGolden {
+					Expect(ioutil.WriteFile(golden, append(got, '\n'), 0644)).To(Succeed())
+				}
+
+				expected, rerr := ioutil.ReadFile(golden)
+				Expect(rerr).NotTo(HaveOccurred())
+				Expect(string(got) + "\n").To(Equal(string(expected)))
+			})
+		})
+	}
+
 })
@@ -0,0 +1,65 @@
+package metargrpc
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+
+	metar "github.com/Luzifer/go-metar"
+	"github.com/Luzifer/go-metar/metarpb"
+)
+
+// Client calls a MetarService
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient creates a Client using the connection
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{cc: cc}
+}
+
+// GetMetar returns the latest observation of every requested station
+func (c *Client) GetMetar(ctx context.Context, stations ...string) ([]metar.Result, error) {
+	resp := &metarpb.GetMetarResponse{}
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetMetar", &metarpb.GetMetarRequest{Stations: stations}, resp, grpc.ForceCodec(codec{})); err != nil {
+		return nil, err
+	}
+
+	results := make([]metar.Result, len(resp.Observations))
+	for i, o := range resp.Observations {
+		results[i] = metarpb.FromProto(o)
+	}
+	return results, nil
+}
+
+// WatchStation calls the handler for every new observation of the station
+// until the context is cancelled or the server ends the stream. An
+// interval of zero uses the interval of the server.
+func (c *Client) WatchStation(ctx context.Context, station string, interval time.Duration, h func(metar.Result)) error {
+	stream, err := c.cc.NewStream(ctx, &serviceDesc.Streams[0], "/"+serviceName+"/WatchStation", grpc.ForceCodec(codec{}))
+	if err != nil {
+		return err
+	}
+
+	req := &metarpb.WatchStationRequest{Station: station, IntervalSeconds: int64(interval / time.Second)}
+	if err := stream.SendMsg(req); err != nil {
+		return err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		o := &metarpb.Observation{}
+		if err := stream.RecvMsg(o); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		h(metarpb.FromProto(o))
+	}
+}
@@ -0,0 +1,226 @@
+// Package metargrpc serves the MetarService defined in
+// metarpb/service.proto using a metar.Client, so services written in other
+// languages can consume observations without embedding Go code.
+//
+//	srv := metargrpc.NewServer(metar.NewClient())
+//	lis, _ := net.Listen("tcp", ":9090")
+//	srv.Serve(lis)
+//
+// The messages are encoded by the metarpb package, therefore the server
+// and client use a codec of their own. Services using generated protobuf
+// code need to be served by another grpc.Server. GetTaf is part of the
+// service definition but not supported and fails with codes.Unimplemented.
+package metargrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	metar "github.com/Luzifer/go-metar"
+	"github.com/Luzifer/go-metar/metarpb"
+)
+
+const (
+	serviceName = "metar.v1.MetarService"
+
+	// DefaultWatchInterval is used by WatchStation if the request does not
+	// specify an interval
+	DefaultWatchInterval = 5 * time.Minute
+	// MinWatchInterval is the shortest polling interval accepted by
+	// WatchStation to protect the upstream APIs
+	MinWatchInterval = time.Minute
+)
+
+// message is implemented by all metarpb messages
+type message interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// codec encodes the metarpb messages
+type codec struct{}
+
+func (codec) Name() string { return "proto" }
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(message)
+	if !ok {
+		return nil, fmt.Errorf("Unsupported message type %T", v)
+	}
+	return m.Marshal()
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(message)
+	if !ok {
+		return fmt.Errorf("Unsupported message type %T", v)
+	}
+	return m.Unmarshal(data)
+}
+
+// MetarServiceServer is the server API of the MetarService
+type MetarServiceServer interface {
+	GetMetar(context.Context, *metarpb.GetMetarRequest) (*metarpb.GetMetarResponse, error)
+	GetTaf(context.Context, *metarpb.GetTafRequest) (*metarpb.GetTafResponse, error)
+	WatchStation(*metarpb.WatchStationRequest, grpc.ServerStream) error
+}
+
+// Service implements the MetarService
+type Service struct {
+	client *metar.Client
+}
+
+// NewService creates a Service answering requests using the client. A nil
+// client uses the metar.DefaultClient.
+func NewService(c *metar.Client) *Service {
+	if c == nil {
+		c = metar.DefaultClient
+	}
+	return &Service{client: c}
+}
+
+// NewServer creates a grpc.Server serving the MetarService using the
+// client
+func NewServer(c *metar.Client, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(append(opts, grpc.ForceServerCodec(codec{}))...)
+	Register(s, NewService(c))
+	return s
+}
+
+// Register adds the service to a server which needs to be created with
+// the codec of this package (see NewServer)
+func Register(s grpc.ServiceRegistrar, svc MetarServiceServer) {
+	s.RegisterService(&serviceDesc, svc)
+}
+
+// GetMetar returns the latest observation of every requested station
+func (s *Service) GetMetar(ctx context.Context, req *metarpb.GetMetarRequest) (*metarpb.GetMetarResponse, error) {
+	if len(req.Stations) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No stations requested")
+	}
+
+	results, err := s.client.FetchStationsWeather(ctx, req.Stations)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &metarpb.GetMetarResponse{}
+	for _, r := range results {
+		resp.Observations = append(resp.Observations, metarpb.ToProto(r))
+	}
+	return resp, nil
+}
+
+// GetTaf is not supported as the metar package does not decode forecasts,
+// it always fails with codes.Unimplemented
+func (s *Service) GetTaf(ctx context.Context, req *metarpb.GetTafRequest) (*metarpb.GetTafResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "Forecasts are not supported")
+}
+
+// WatchStation sends every new observation of the station until the
+// client cancels the call
+func (s *Service) WatchStation(req *metarpb.WatchStationRequest, stream grpc.ServerStream) error {
+	if req.Station == "" {
+		return status.Error(codes.InvalidArgument, "No station requested")
+	}
+
+	interval := DefaultWatchInterval
+	if req.IntervalSeconds > 0 {
+		interval = time.Duration(req.IntervalSeconds) * time.Second
+	}
+	if interval < MinWatchInterval {
+		interval = MinWatchInterval
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	w := metar.NewWatcher(s.client, []string{req.Station}, interval)
+	w.OnObservation(func(_ *metar.Result, cur metar.Result) {
+		if err := stream.SendMsg(metarpb.ToProto(cur)); err != nil {
+			sendErr = err
+			cancel()
+		}
+	})
+
+	err := w.Run(ctx)
+	switch {
+	case sendErr != nil:
+		return sendErr
+	case errors.Is(err, context.Canceled):
+		return nil
+	}
+	return statusError(err)
+}
+
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, metar.ErrNoResults):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*MetarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMetar",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &metarpb.GetMetarRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(MetarServiceServer).GetMetar(ctx, req.(*metarpb.GetMetarRequest))
+				}
+				if interceptor == nil {
+					return handler(ctx, req)
+				}
+				return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetMetar"}, handler)
+			},
+		},
+		{
+			MethodName: "GetTaf",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &metarpb.GetTafRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(MetarServiceServer).GetTaf(ctx, req.(*metarpb.GetTafRequest))
+				}
+				if interceptor == nil {
+					return handler(ctx, req)
+				}
+				return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetTaf"}, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStation",
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				req := &metarpb.WatchStationRequest{}
+				if err := stream.RecvMsg(req); err != nil {
+					return err
+				}
+				return srv.(MetarServiceServer).WatchStation(req, stream)
+			},
+		},
+	},
+	Metadata: "service.proto",
+}
@@ -0,0 +1,13 @@
+package metargrpc_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMetargrpc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metargrpc Suite")
+}
@@ -0,0 +1,121 @@
+package metargrpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	metar "github.com/Luzifer/go-metar"
+	. "github.com/Luzifer/go-metar/metargrpc"
+	"github.com/Luzifer/go-metar/metarpb"
+	"github.com/Luzifer/go-metar/metartest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetarService", func() {
+	var (
+		src    *metartest.Source
+		lis    *bufconn.Listener
+		srv    *grpc.Server
+		conn   *grpc.ClientConn
+		client *Client
+		obs    = time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		src = metartest.NewSource()
+		src.Add(
+			metar.Result{StationID: "EDDH", ObservationTime: obs, Temperature: 17, WindSpeed: 8, FlightCategory: metar.FlightCategoryVFR},
+			metar.Result{StationID: "EDDW", ObservationTime: obs, Temperature: 12},
+		)
+
+		lis = bufconn.Listen(1 << 20)
+		srv = NewServer(metar.NewClient(metar.WithSource(src)))
+		go srv.Serve(lis)
+
+		var err error
+		conn, err = grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		client = NewClient(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		srv.Stop()
+		lis.Close()
+	})
+
+	It("should round-trip observations through GetMetar", func() {
+		results, err := client.GetMetar(context.Background(), "EDDH", "EDDW")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+
+		Expect(results[0].StationID).To(Equal("EDDH"))
+		Expect(results[0].ObservationTime.Equal(obs)).To(BeTrue())
+		Expect(results[0].Temperature).To(Equal(17.0))
+		Expect(results[0].WindSpeed).To(Equal(int64(8)))
+		Expect(results[0].FlightCategory).To(Equal(metar.FlightCategoryVFR))
+		Expect(results[1].StationID).To(Equal("EDDW"))
+	})
+
+	It("should map errors to status codes", func() {
+		_, err := client.GetMetar(context.Background())
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		results, err := client.GetMetar(context.Background(), "EDDF")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+
+		src.Err = metar.ErrNoResults
+		_, err = client.GetMetar(context.Background(), "EDDH")
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+		src.Err = errors.New("Upstream down")
+		_, err = client.GetMetar(context.Background(), "EDDH")
+		Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	})
+
+	It("should stream observations through WatchStation", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		received := make(chan metar.Result, 1)
+		done := make(chan error, 1)
+		go func() {
+			done <- client.WatchStation(ctx, "EDDH", time.Minute, func(r metar.Result) {
+				received <- r
+			})
+		}()
+
+		var r metar.Result
+		Eventually(received).Should(Receive(&r))
+		Expect(r.StationID).To(Equal("EDDH"))
+		Expect(r.Temperature).To(Equal(17.0))
+
+		cancel()
+		Eventually(done).Should(Receive(HaveOccurred()))
+	})
+
+	It("should reject watching without a station", func() {
+		err := client.WatchStation(context.Background(), "", 0, func(metar.Result) {})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("should not support forecasts", func() {
+		_, err := NewService(metar.NewClient(metar.WithSource(src))).GetTaf(context.Background(), &metarpb.GetTafRequest{Station: "EDDH"})
+		Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+	})
+
+})
@@ -0,0 +1,89 @@
+// Package metarotel reports the upstream requests of a metar.Client as
+// OpenTelemetry spans and metrics.
+//
+//	inst, err := metarotel.New(otel.GetTracerProvider(), otel.GetMeterProvider())
+//	if err != nil { ... }
+//	client := metar.NewClient(metar.WithInstrumentation(inst))
+package metarotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+const instrumentationName = "github.com/Luzifer/go-metar"
+
+// Instrumentation implements metar.Instrumentation using OpenTelemetry
+type Instrumentation struct {
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// New creates an Instrumentation using the given providers
+func New(tp trace.TracerProvider, mp metric.MeterProvider) (*Instrumentation, error) {
+	meter := mp.Meter(instrumentationName)
+
+	requests, err := meter.Int64Counter("metar.fetch.requests",
+		metric.WithDescription("Number of upstream METAR requests"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("metar.fetch.duration",
+		metric.WithDescription("Duration of upstream METAR requests"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		tracer:   tp.Tracer(instrumentationName),
+		requests: requests,
+		duration: duration,
+	}, nil
+}
+
+// FetchStarted starts a span for the upstream request
+func (i *Instrumentation) FetchStarted(ctx context.Context, station string) context.Context {
+	ctx, _ = i.tracer.Start(ctx, "metar.Fetch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("metar.station", station)),
+	)
+	return ctx
+}
+
+// FetchFinished ends the span of the request and records its metrics
+func (i *Instrumentation) FetchFinished(ctx context.Context, info metar.FetchInfo) {
+	outcome := "success"
+	if info.Err != nil {
+		outcome = "error"
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("metar.station", info.Station),
+		attribute.String("metar.outcome", outcome),
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.Int("http.response.status_code", info.StatusCode),
+		attribute.Int("metar.result_count", info.Results),
+	)
+	if info.Err != nil {
+		span.RecordError(info.Err)
+		span.SetStatus(codes.Error, info.Err.Error())
+	}
+	span.End()
+
+	i.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+	i.duration.Record(ctx, info.Duration.Seconds(), metric.WithAttributes(attrs...))
+}
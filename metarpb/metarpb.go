@@ -0,0 +1,283 @@
+// Package metarpb converts metar.Results into the Observation message
+// defined in metar.proto to ship them between services.
+//
+// The messages are encoded by hand instead of using generated code to keep
+// the package free of dependencies. They are wire compatible with code
+// generated from metar.proto in any language.
+package metarpb
+
+import (
+	"time"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+// Observation is the metar.v1.Observation message
+type Observation struct {
+	RawText             string
+	StationID           string
+	ObservationTime     time.Time
+	Latitude            float64
+	Longitude           float64
+	TempC               float64
+	DewpointC           float64
+	WindDirDegrees      int64
+	WindSpeedKt         int64
+	WindGustKt          int64
+	VisibilityStatuteMi float64
+	AltimInHg           float64
+	SeaLevelPressureMb  float64
+	QualityControlFlags QualityControlFlags
+	WxString            string
+	SkyCover            string
+	FlightCategory      string
+	PrecipIn            float64
+	SnowIn              float64
+	VertVisFt           int64
+	MetarType           string
+	ElevationM          float64
+
+	Automated  bool
+	Correction string
+	SensorType string
+
+	Source    string
+	FetchedAt time.Time
+
+	Present []string
+}
+
+// QualityControlFlags is the metar.v1.QualityControlFlags message
+type QualityControlFlags struct {
+	Corrected              bool
+	Auto                   bool
+	AutoStation            bool
+	MaintenanceIndicatorOn bool
+	NoSignal               bool
+}
+
+// ToProto converts the result into an Observation
+func ToProto(r metar.Result) *Observation {
+	o := &Observation{
+		RawText:             r.RawText,
+		StationID:           r.StationID,
+		ObservationTime:     r.ObservationTime,
+		Latitude:            r.Latitude,
+		Longitude:           r.Longitude,
+		TempC:               r.Temperature,
+		DewpointC:           r.Dewpoint,
+		WindDirDegrees:      r.WindDirDegrees,
+		WindSpeedKt:         r.WindSpeed,
+		WindGustKt:          r.WindGust,
+		VisibilityStatuteMi: r.VisibilityStatute,
+		AltimInHg:           r.Altimeter,
+		SeaLevelPressureMb:  r.SeaLevelPressure,
+		QualityControlFlags: QualityControlFlags{
+			Corrected:              r.QualityControlFlags.Corrected,
+			Auto:                   r.QualityControlFlags.Auto,
+			AutoStation:            r.QualityControlFlags.AutoStation,
+			MaintenanceIndicatorOn: r.QualityControlFlags.MaintenanceIndicator,
+			NoSignal:               r.QualityControlFlags.NoSignal,
+		},
+		WxString:       r.WXString,
+		SkyCover:       string(r.SkyCondition.SkyCover),
+		FlightCategory: string(r.FlightCategory),
+		PrecipIn:       r.PrecipitationIn,
+		SnowIn:         r.SnowDepthIn,
+		VertVisFt:      r.VerticalVisibilityFt,
+		MetarType:      r.MetarType,
+		ElevationM:     r.Elevation,
+
+		Automated:  r.Automated,
+		Correction: r.Correction,
+		SensorType: string(r.SensorType),
+
+		Source:    r.Source,
+		FetchedAt: r.FetchedAt,
+	}
+
+	for _, f := range r.Present.Fields() {
+		o.Present = append(o.Present, string(f))
+	}
+	return o
+}
+
+// FromProto converts the Observation back into a Result
+func FromProto(o *Observation) metar.Result {
+	r := metar.Result{
+		RawText:           o.RawText,
+		StationID:         o.StationID,
+		ObservationTime:   o.ObservationTime,
+		Latitude:          o.Latitude,
+		Longitude:         o.Longitude,
+		Temperature:       o.TempC,
+		Dewpoint:          o.DewpointC,
+		WindDirDegrees:    o.WindDirDegrees,
+		WindSpeed:         o.WindSpeedKt,
+		WindGust:          o.WindGustKt,
+		VisibilityStatute: o.VisibilityStatuteMi,
+		Altimeter:         o.AltimInHg,
+		SeaLevelPressure:  o.SeaLevelPressureMb,
+		QualityControlFlags: metar.QualityControlFlags{
+			Corrected:            o.QualityControlFlags.Corrected,
+			Auto:                 o.QualityControlFlags.Auto,
+			AutoStation:          o.QualityControlFlags.AutoStation,
+			MaintenanceIndicator: o.QualityControlFlags.MaintenanceIndicatorOn,
+			NoSignal:             o.QualityControlFlags.NoSignal,
+		},
+		WXString:             o.WxString,
+		FlightCategory:       metar.FlightCategory(o.FlightCategory),
+		PrecipitationIn:      o.PrecipIn,
+		SnowDepthIn:          o.SnowIn,
+		VerticalVisibilityFt: o.VertVisFt,
+		MetarType:            o.MetarType,
+		Elevation:            o.ElevationM,
+
+		Automated:  o.Automated,
+		Correction: o.Correction,
+		SensorType: metar.SensorType(o.SensorType),
+
+		Source:    o.Source,
+		FetchedAt: o.FetchedAt,
+	}
+	r.XMLName.Local = "METAR"
+	r.SkyCondition.SkyCover = metar.SkyCover(o.SkyCover)
+
+	for _, f := range o.Present {
+		r.Present.Add(metar.Field(f))
+	}
+	return r
+}
+
+// Marshal encodes the Observation in the protobuf wire format
+func (o *Observation) Marshal() ([]byte, error) {
+	var b []byte
+	b = appendString(b, 1, o.RawText)
+	b = appendString(b, 2, o.StationID)
+	b = appendTimestamp(b, 3, o.ObservationTime)
+	b = appendDouble(b, 4, o.Latitude)
+	b = appendDouble(b, 5, o.Longitude)
+	b = appendDouble(b, 6, o.TempC)
+	b = appendDouble(b, 7, o.DewpointC)
+	b = appendInt(b, 8, o.WindDirDegrees)
+	b = appendInt(b, 9, o.WindSpeedKt)
+	b = appendInt(b, 10, o.WindGustKt)
+	b = appendDouble(b, 11, o.VisibilityStatuteMi)
+	b = appendDouble(b, 12, o.AltimInHg)
+	b = appendDouble(b, 13, o.SeaLevelPressureMb)
+	if qcf := o.QualityControlFlags.marshal(); len(qcf) > 0 {
+		b = appendMessage(b, 14, qcf)
+	}
+	b = appendString(b, 15, o.WxString)
+	b = appendString(b, 16, o.SkyCover)
+	b = appendString(b, 17, o.FlightCategory)
+	b = appendDouble(b, 18, o.PrecipIn)
+	b = appendDouble(b, 19, o.SnowIn)
+	b = appendInt(b, 20, o.VertVisFt)
+	b = appendString(b, 21, o.MetarType)
+	b = appendDouble(b, 22, o.ElevationM)
+	b = appendBool(b, 23, o.Automated)
+	b = appendString(b, 24, o.Correction)
+	b = appendString(b, 25, o.SensorType)
+	b = appendString(b, 26, o.Source)
+	b = appendTimestamp(b, 27, o.FetchedAt)
+	for _, p := range o.Present {
+		b = appendString(b, 28, p)
+	}
+	return b, nil
+}
+
+// Unmarshal decodes the protobuf wire format into the Observation,
+// unknown fields are skipped
+func (o *Observation) Unmarshal(b []byte) error {
+	*o = Observation{}
+	return consumeFields(b, func(f field) (err error) {
+		switch f.num {
+		case 1:
+			o.RawText = string(f.bytes)
+		case 2:
+			o.StationID = string(f.bytes)
+		case 3:
+			o.ObservationTime, err = consumeTimestamp(f.bytes)
+		case 4:
+			o.Latitude = f.double()
+		case 5:
+			o.Longitude = f.double()
+		case 6:
+			o.TempC = f.double()
+		case 7:
+			o.DewpointC = f.double()
+		case 8:
+			o.WindDirDegrees = int64(f.varint)
+		case 9:
+			o.WindSpeedKt = int64(f.varint)
+		case 10:
+			o.WindGustKt = int64(f.varint)
+		case 11:
+			o.VisibilityStatuteMi = f.double()
+		case 12:
+			o.AltimInHg = f.double()
+		case 13:
+			o.SeaLevelPressureMb = f.double()
+		case 14:
+			err = o.QualityControlFlags.unmarshal(f.bytes)
+		case 15:
+			o.WxString = string(f.bytes)
+		case 16:
+			o.SkyCover = string(f.bytes)
+		case 17:
+			o.FlightCategory = string(f.bytes)
+		case 18:
+			o.PrecipIn = f.double()
+		case 19:
+			o.SnowIn = f.double()
+		case 20:
+			o.VertVisFt = int64(f.varint)
+		case 21:
+			o.MetarType = string(f.bytes)
+		case 22:
+			o.ElevationM = f.double()
+		case 23:
+			o.Automated = f.varint != 0
+		case 24:
+			o.Correction = string(f.bytes)
+		case 25:
+			o.SensorType = string(f.bytes)
+		case 26:
+			o.Source = string(f.bytes)
+		case 27:
+			o.FetchedAt, err = consumeTimestamp(f.bytes)
+		case 28:
+			o.Present = append(o.Present, string(f.bytes))
+		}
+		return err
+	})
+}
+
+func (q QualityControlFlags) marshal() []byte {
+	var b []byte
+	b = appendBool(b, 1, q.Corrected)
+	b = appendBool(b, 2, q.Auto)
+	b = appendBool(b, 3, q.AutoStation)
+	b = appendBool(b, 4, q.MaintenanceIndicatorOn)
+	b = appendBool(b, 5, q.NoSignal)
+	return b
+}
+
+func (q *QualityControlFlags) unmarshal(b []byte) error {
+	return consumeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			q.Corrected = f.varint != 0
+		case 2:
+			q.Auto = f.varint != 0
+		case 3:
+			q.AutoStation = f.varint != 0
+		case 4:
+			q.MaintenanceIndicatorOn = f.varint != 0
+		case 5:
+			q.NoSignal = f.varint != 0
+		}
+		return nil
+	})
+}
@@ -0,0 +1,13 @@
+package metarpb_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMetarpb(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metarpb Suite")
+}
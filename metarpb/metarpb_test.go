@@ -0,0 +1,86 @@
+package metarpb_test
+
+import (
+	"time"
+
+	metar "github.com/Luzifer/go-metar"
+	"github.com/Luzifer/go-metar/metarpb"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Metarpb", func() {
+
+	It("should round-trip results through the wire format", func() {
+		r := metar.Result{
+			RawText:         "EDDH 211020Z 27008KT 9999 FEW030 M02/M05 Q1018",
+			StationID:       "EDDH",
+			ObservationTime: time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC),
+			Latitude:        53.63,
+			Longitude:       10,
+			Temperature:     -2,
+			Dewpoint:        -5,
+			WindDirDegrees:  270,
+			WindSpeed:       8,
+			FlightCategory:  metar.FlightCategoryVFR,
+			SensorType:      metar.SensorTypeAO2,
+			Automated:       true,
+			Source:          "adds",
+			FetchedAt:       time.Date(2016, 5, 21, 10, 25, 3, 500, time.UTC),
+		}
+		r.XMLName.Local = "METAR"
+		r.SkyCondition.SkyCover = metar.SkyCoverFEW
+		r.QualityControlFlags.AutoStation = true
+		r.Present.Add(metar.FieldStationID)
+		r.Present.Add(metar.FieldTemperature)
+
+		data, err := metarpb.ToProto(r).Marshal()
+		Expect(err).NotTo(HaveOccurred())
+
+		var o metarpb.Observation
+		Expect(o.Unmarshal(data)).To(Succeed())
+		Expect(metarpb.FromProto(&o)).To(Equal(r))
+	})
+
+	It("should produce the canonical encoding", func() {
+		data, err := (&metarpb.Observation{StationID: "EDDH", WindSpeedKt: 8, Automated: true}).Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal([]byte{0x12, 0x04, 'E', 'D', 'D', 'H', 0x48, 0x08, 0xb8, 0x01, 0x01}))
+	})
+
+	It("should skip unknown fields", func() {
+		var o metarpb.Observation
+		Expect(o.Unmarshal([]byte{0xf8, 0x07, 0x01, 0x12, 0x04, 'E', 'D', 'D', 'H'})).To(Succeed())
+		Expect(o.StationID).To(Equal("EDDH"))
+	})
+
+	It("should reject truncated messages", func() {
+		var o metarpb.Observation
+		Expect(o.Unmarshal([]byte{0x12, 0x04, 'E', 'D'})).NotTo(Succeed())
+	})
+
+	It("should round-trip the service messages", func() {
+		req := &metarpb.GetMetarRequest{Stations: []string{"EDDH", "EDDW"}}
+		data, err := req.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		var gotReq metarpb.GetMetarRequest
+		Expect(gotReq.Unmarshal(data)).To(Succeed())
+		Expect(&gotReq).To(Equal(req))
+
+		resp := &metarpb.GetMetarResponse{Observations: []*metarpb.Observation{{StationID: "EDDH"}, {StationID: "EDDW", WindSpeedKt: 12}}}
+		data, err = resp.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		var gotResp metarpb.GetMetarResponse
+		Expect(gotResp.Unmarshal(data)).To(Succeed())
+		Expect(&gotResp).To(Equal(resp))
+
+		watch := &metarpb.WatchStationRequest{Station: "EDDH", IntervalSeconds: 300}
+		data, err = watch.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		var gotWatch metarpb.WatchStationRequest
+		Expect(gotWatch.Unmarshal(data)).To(Succeed())
+		Expect(&gotWatch).To(Equal(watch))
+	})
+
+})
@@ -0,0 +1,129 @@
+package metarpb
+
+// GetMetarRequest is the metar.v1.GetMetarRequest message
+type GetMetarRequest struct {
+	Stations []string
+}
+
+// GetMetarResponse is the metar.v1.GetMetarResponse message
+type GetMetarResponse struct {
+	Observations []*Observation
+}
+
+// GetTafRequest is the metar.v1.GetTafRequest message
+type GetTafRequest struct {
+	Station string
+}
+
+// GetTafResponse is the metar.v1.GetTafResponse message
+type GetTafResponse struct {
+	RawText string
+}
+
+// WatchStationRequest is the metar.v1.WatchStationRequest message
+type WatchStationRequest struct {
+	Station         string
+	IntervalSeconds int64
+}
+
+// Marshal encodes the message in the protobuf wire format
+func (m *GetMetarRequest) Marshal() ([]byte, error) {
+	var b []byte
+	for _, s := range m.Stations {
+		b = appendString(b, 1, s)
+	}
+	return b, nil
+}
+
+// Unmarshal decodes the protobuf wire format into the message
+func (m *GetMetarRequest) Unmarshal(b []byte) error {
+	*m = GetMetarRequest{}
+	return consumeFields(b, func(f field) error {
+		if f.num == 1 {
+			m.Stations = append(m.Stations, string(f.bytes))
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the message in the protobuf wire format
+func (m *GetMetarResponse) Marshal() ([]byte, error) {
+	var b []byte
+	for _, o := range m.Observations {
+		ob, err := o.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		b = appendMessage(b, 1, ob)
+	}
+	return b, nil
+}
+
+// Unmarshal decodes the protobuf wire format into the message
+func (m *GetMetarResponse) Unmarshal(b []byte) error {
+	*m = GetMetarResponse{}
+	return consumeFields(b, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+
+		o := &Observation{}
+		if err := o.Unmarshal(f.bytes); err != nil {
+			return err
+		}
+		m.Observations = append(m.Observations, o)
+		return nil
+	})
+}
+
+// Marshal encodes the message in the protobuf wire format
+func (m *GetTafRequest) Marshal() ([]byte, error) {
+	return appendString(nil, 1, m.Station), nil
+}
+
+// Unmarshal decodes the protobuf wire format into the message
+func (m *GetTafRequest) Unmarshal(b []byte) error {
+	*m = GetTafRequest{}
+	return consumeFields(b, func(f field) error {
+		if f.num == 1 {
+			m.Station = string(f.bytes)
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the message in the protobuf wire format
+func (m *GetTafResponse) Marshal() ([]byte, error) {
+	return appendString(nil, 1, m.RawText), nil
+}
+
+// Unmarshal decodes the protobuf wire format into the message
+func (m *GetTafResponse) Unmarshal(b []byte) error {
+	*m = GetTafResponse{}
+	return consumeFields(b, func(f field) error {
+		if f.num == 1 {
+			m.RawText = string(f.bytes)
+		}
+		return nil
+	})
+}
+
+// Marshal encodes the message in the protobuf wire format
+func (m *WatchStationRequest) Marshal() ([]byte, error) {
+	b := appendString(nil, 1, m.Station)
+	return appendInt(b, 2, m.IntervalSeconds), nil
+}
+
+// Unmarshal decodes the protobuf wire format into the message
+func (m *WatchStationRequest) Unmarshal(b []byte) error {
+	*m = WatchStationRequest{}
+	return consumeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Station = string(f.bytes)
+		case 2:
+			m.IntervalSeconds = int64(f.varint)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,144 @@
+package metarpb
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// Wire types of the protobuf encoding
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("Truncated protobuf message")
+
+func appendTag(b []byte, num, typ int) []byte {
+	return binary.AppendUvarint(b, uint64(num)<<3|uint64(typ))
+}
+
+func appendString(b []byte, num int, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = appendTag(b, num, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func appendMessage(b []byte, num int, msg []byte) []byte {
+	b = appendTag(b, num, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(msg)))
+	return append(b, msg...)
+}
+
+func appendDouble(b []byte, num int, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendTag(b, num, wireFixed64)
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+}
+
+func appendInt(b []byte, num int, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = appendTag(b, num, wireVarint)
+	return binary.AppendUvarint(b, uint64(v))
+}
+
+func appendBool(b []byte, num int, v bool) []byte {
+	if !v {
+		return b
+	}
+	b = appendTag(b, num, wireVarint)
+	return append(b, 1)
+}
+
+// appendTimestamp encodes a google.protobuf.Timestamp, zero times are
+// omitted
+func appendTimestamp(b []byte, num int, t time.Time) []byte {
+	if t.IsZero() {
+		return b
+	}
+
+	var ts []byte
+	ts = appendInt(ts, 1, t.Unix())
+	ts = appendInt(ts, 2, int64(t.Nanosecond()))
+	return appendMessage(b, num, ts)
+}
+
+// field is a single decoded field of a message
+type field struct {
+	num, typ int
+	varint   uint64
+	bytes    []byte
+}
+
+func (f field) double() float64 { return math.Float64frombits(f.varint) }
+
+// consumeFields splits a message into its fields
+func consumeFields(b []byte, fn func(field) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errTruncated
+		}
+		b = b[n:]
+
+		f := field{num: int(tag >> 3), typ: int(tag & 7)}
+		switch f.typ {
+		case wireVarint:
+			if f.varint, n = binary.Uvarint(b); n <= 0 {
+				return errTruncated
+			}
+			b = b[n:]
+
+		case wireFixed64:
+			if len(b) < 8 {
+				return errTruncated
+			}
+			f.varint, b = binary.LittleEndian.Uint64(b), b[8:]
+
+		case wireFixed32:
+			if len(b) < 4 {
+				return errTruncated
+			}
+			f.varint, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errTruncated
+			}
+			f.bytes, b = b[n:n+int(l)], b[n+int(l):]
+
+		default:
+			return errors.New("Unsupported protobuf wire type")
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func consumeTimestamp(b []byte) (time.Time, error) {
+	var sec, nsec int64
+	err := consumeFields(b, func(f field) error {
+		switch f.num {
+		case 1:
+			sec = int64(f.varint)
+		case 2:
+			nsec = int64(f.varint)
+		}
+		return nil
+	})
+	return time.Unix(sec, nsec).UTC(), err
+}
@@ -0,0 +1,101 @@
+// Package metartest provides utilities for testing code using the metar
+// package without contacting the upstream APIs.
+//
+// Source is a fake metar.Source serving canned observations:
+//
+//	src := metartest.NewSource()
+//	src.AddXML(fixture)
+//	client := metar.NewClient(metar.WithSource(src))
+//
+// Recorder and Replayer capture real HTTP responses once and serve them
+// afterwards for deterministic tests:
+//
+//	hc := &http.Client{Transport: metartest.NewReplayer("testdata/http")}
+//	client := metar.NewClient(metar.WithHTTPClient(hc))
+package metartest
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+// Source is a fake metar.Source serving canned observations
+type Source struct {
+	// Err is returned by Fetch instead of the observations when set
+	Err error
+
+	mu       sync.Mutex
+	results  map[string][]metar.Result
+	requests []metar.Query
+}
+
+// NewSource creates an empty Source
+func NewSource() *Source {
+	return &Source{results: make(map[string][]metar.Result)}
+}
+
+// Add registers the given results to be served for their stations
+func (s *Source) Add(results ...metar.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range results {
+		key := strings.ToUpper(r.StationID)
+		s.results[key] = append(s.results[key], r)
+	}
+}
+
+// AddXML registers the results contained in a dataserver XML response
+func (s *Source) AddXML(payload string) error {
+	results, err := metar.DecodeXML(strings.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	s.Add(results...)
+	return nil
+}
+
+// AddRaw registers a raw METAR decoded using metar.ParseRaw
+func (s *Source) AddRaw(raw string) error {
+	r, err := metar.ParseRaw(raw)
+	if err != nil {
+		return err
+	}
+
+	s.Add(*r)
+	return nil
+}
+
+// Name identifies the source
+func (s *Source) Name() string { return "metartest" }
+
+// Fetch returns the most recently added result of every requested station
+func (s *Source) Fetch(ctx context.Context, q metar.Query) ([]metar.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, q)
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	var out []metar.Result
+	for _, station := range q.Stations {
+		if r := s.results[strings.ToUpper(station)]; len(r) > 0 {
+			out = append(out, r[len(r)-1])
+		}
+	}
+	return out, nil
+}
+
+// Requests returns all queries received by Fetch
+func (s *Source) Requests() []metar.Query {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]metar.Query(nil), s.requests...)
+}
@@ -0,0 +1,13 @@
+package metartest_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMetartest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metartest Suite")
+}
@@ -0,0 +1,97 @@
+package metartest_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	metar "github.com/Luzifer/go-metar"
+	. "github.com/Luzifer/go-metar/metartest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const fixture = `<response><data num_results="1"><METAR>
+<raw_text>EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG</raw_text>
+<station_id>EDDH</station_id>
+<observation_time>2016-05-21T10:20:00Z</observation_time>
+<temp_c>17.0</temp_c>
+<flight_category>VFR</flight_category>
+</METAR></data></response>`
+
+var _ = Describe("Source", func() {
+	var src *Source
+
+	BeforeEach(func() {
+		src = NewSource()
+	})
+
+	It("should serve canned XML results", func() {
+		Expect(src.AddXML(fixture)).To(Succeed())
+
+		client := metar.NewClient(metar.WithSource(src))
+		r, err := client.FetchCurrentStationWeather(context.Background(), "eddh")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Temperature).To(Equal(17.0))
+		Expect(r.FlightCategory).To(Equal(metar.FlightCategoryVFR))
+		Expect(src.Requests()).To(HaveLen(1))
+	})
+
+	It("should serve raw METARs", func() {
+		Expect(src.AddRaw("KJFK 011251Z 31012KT 10SM FEW250 M04/M17 A3020")).To(Succeed())
+
+		results, err := src.Fetch(context.Background(), metar.Query{Stations: []string{"KJFK", "EDDH"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].StationID).To(Equal("KJFK"))
+		Expect(results[0].ObservationTime.Day()).To(Equal(1))
+		Expect(results[0].ObservationTime.Hour()).To(Equal(12))
+		Expect(results[0].Temperature).To(Equal(-4.0))
+	})
+
+	It("should return the configured error", func() {
+		src.Err = errors.New("boom")
+		_, err := src.Fetch(context.Background(), metar.Query{Stations: []string{"EDDH"}})
+		Expect(err).To(MatchError("boom"))
+	})
+})
+
+var _ = Describe("Recorder and Replayer", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "metartest")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should replay recorded responses", func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fixture))
+		}))
+		defer srv.Close()
+
+		rec := &http.Client{Transport: NewRecorder(dir, nil)}
+		res, err := rec.Get(srv.URL + "/metar?station=EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		res.Body.Close()
+		srv.Close()
+
+		rep := &http.Client{Transport: NewReplayer(dir)}
+		res, err = rep.Get(srv.URL + "/metar?station=EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		body, _ := ioutil.ReadAll(res.Body)
+		Expect(string(body)).To(Equal(fixture))
+
+		_, err = rep.Get(srv.URL + "/metar?station=KJFK")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,79 @@
+package metartest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+)
+
+// Recorder is a http.RoundTripper passing requests to the next
+// RoundTripper and storing the responses in a directory to be served by a
+// Replayer later on
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+}
+
+// NewRecorder creates a Recorder storing responses in dir. If next is nil
+// http.DefaultTransport is used.
+func NewRecorder(dir string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next}
+}
+
+// RoundTrip executes the request and records its response
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	dump, err := httputil.DumpResponse(res, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(fixturePath(r.dir, req), dump, 0644); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Replayer is a http.RoundTripper serving responses stored by a Recorder
+type Replayer struct {
+	dir string
+}
+
+// NewReplayer creates a Replayer serving responses from dir
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{dir: dir}
+}
+
+// RoundTrip returns the recorded response for the request or an error if
+// there is none
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	dump, err := ioutil.ReadFile(fixturePath(r.dir, req))
+	if err != nil {
+		return nil, fmt.Errorf("No recorded response for %s: %s", req.URL, err)
+	}
+
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(dump)), req)
+}
+
+// fixturePath derives the file name for the request from its method and URL
+func fixturePath(dir string, req *http.Request) string {
+	return filepath.Join(dir, fmt.Sprintf("%x.http", sha1.Sum([]byte(req.Method+" "+req.URL.String()))))
+}
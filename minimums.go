@@ -0,0 +1,129 @@
+package metar
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Minimums are personal weather minimums, zero values are not checked
+type Minimums struct {
+	CeilingFt      int64   // Lowest acceptable ceiling (feet above ground)
+	VisibilitySM   float64 // Lowest acceptable visibility (statute miles)
+	MaxCrosswindKt float64 // Highest acceptable crosswind component (knots)
+	MaxGustKt      int64   // Highest acceptable gust speed (knots)
+}
+
+// ViolationKind names the minimum violated by a report
+type ViolationKind string
+
+// Minimums checked by Result.MeetsMinimums
+const (
+	ViolationCeiling    ViolationKind = "ceiling"
+	ViolationVisibility ViolationKind = "visibility"
+	ViolationCrosswind  ViolationKind = "crosswind"
+	ViolationGust       ViolationKind = "gust"
+)
+
+// Additional limits checked by Result.EvaluateUAS
+const (
+	ViolationWind           ViolationKind = "wind"
+	ViolationCloudClearance ViolationKind = "cloud clearance"
+)
+
+// Violation describes a minimum not met by a report
+type Violation struct {
+	Kind    ViolationKind
+	Limit   float64
+	Actual  float64
+	Missing bool // The value was not reported and could not be checked
+}
+
+func (v Violation) String() string {
+	if v.Missing {
+		return fmt.Sprintf("%s not reported", v.Kind)
+	}
+
+	op := "above maximum"
+	if v.Kind == ViolationCeiling || v.Kind == ViolationVisibility || v.Kind == ViolationCloudClearance {
+		op = "below minimum"
+	}
+	return fmt.Sprintf("%s %s %s %s", v.Kind,
+		strconv.FormatFloat(v.Actual, 'f', -1, 64), op, strconv.FormatFloat(v.Limit, 'f', -1, 64))
+}
+
+// WindComponents splits the wind (direction in degrees, speed in any
+// unit) into the crosswind and headwind components for the runway
+// heading. The headwind is negative for tailwinds, the crosswind is
+// returned as absolute value.
+func WindComponents(windDir, windSpeed, runwayHeading float64) (crosswind, headwind float64) {
+	angle := (windDir - runwayHeading) * math.Pi / 180
+	return math.Abs(windSpeed * math.Sin(angle)), windSpeed * math.Cos(angle)
+}
+
+// Crosswind returns the crosswind component (knots) for the runway
+// heading (degrees true). Gusts are used if reported and variable winds
+// are assumed to blow across the runway.
+func (r Result) Crosswind(runwayHeading int) (float64, bool) {
+	if !r.Present.Has(FieldWindSpeed) {
+		return 0, false
+	}
+
+	speed := float64(r.WindSpeed)
+	if r.WindGust > r.WindSpeed {
+		speed = float64(r.WindGust)
+	}
+	if r.WindDirDegrees == 0 {
+		return speed, true
+	}
+
+	cross, _ := WindComponents(float64(r.WindDirDegrees), speed, float64(runwayHeading))
+	return cross, true
+}
+
+// MeetsMinimums checks the report against the minimums for a runway
+// (heading in degrees true, see TrueToMagnetic) and returns the violated
+// minimums. Values required by a minimum but missing from the report are
+// violations as the conditions are unknown.
+func (r Result) MeetsMinimums(m Minimums, runwayHeading int) (bool, []Violation) {
+	var violations []Violation
+
+	if m.CeilingFt > 0 {
+		if ceiling, ok := r.Ceiling(); ok && ceiling < m.CeilingFt {
+			violations = append(violations, Violation{Kind: ViolationCeiling, Limit: float64(m.CeilingFt), Actual: float64(ceiling)})
+		} else if !ok && !r.Present.Has(FieldSkyCondition) {
+			violations = append(violations, Violation{Kind: ViolationCeiling, Limit: float64(m.CeilingFt), Missing: true})
+		}
+	}
+
+	if m.VisibilitySM > 0 {
+		v := r.Visibility()
+		switch {
+		case !r.Present.Has(FieldVisibilityStatute):
+			violations = append(violations, Violation{Kind: ViolationVisibility, Limit: m.VisibilitySM, Missing: true})
+		case v.StatuteMiles() < m.VisibilitySM && v.Modifier != VisibilityGreaterThan:
+			violations = append(violations, Violation{Kind: ViolationVisibility, Limit: m.VisibilitySM, Actual: math.Round(v.StatuteMiles()*100) / 100})
+		}
+	}
+
+	if m.MaxCrosswindKt > 0 {
+		cross, ok := r.Crosswind(runwayHeading)
+		switch {
+		case !ok:
+			violations = append(violations, Violation{Kind: ViolationCrosswind, Limit: m.MaxCrosswindKt, Missing: true})
+		case cross > m.MaxCrosswindKt:
+			violations = append(violations, Violation{Kind: ViolationCrosswind, Limit: m.MaxCrosswindKt, Actual: math.Round(cross*10) / 10})
+		}
+	}
+
+	if m.MaxGustKt > 0 {
+		switch {
+		case !r.Present.Has(FieldWindSpeed):
+			violations = append(violations, Violation{Kind: ViolationGust, Limit: float64(m.MaxGustKt), Missing: true})
+		case r.WindGust > m.MaxGustKt:
+			violations = append(violations, Violation{Kind: ViolationGust, Limit: float64(m.MaxGustKt), Actual: float64(r.WindGust)})
+		}
+	}
+
+	return len(violations) == 0, violations
+}
@@ -0,0 +1,55 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Minimums", func() {
+
+	minimums := Minimums{CeilingFt: 1500, VisibilitySM: 5, MaxCrosswindKt: 12, MaxGustKt: 20}
+
+	parse := func(raw string) Result {
+		r, err := ParseRaw(raw)
+		Expect(err).NotTo(HaveOccurred())
+		return *r
+	}
+
+	It("should split the wind into components", func() {
+		cross, head := WindComponents(270, 20, 240)
+		Expect(cross).To(BeNumerically("~", 10, 0.01))
+		Expect(head).To(BeNumerically("~", 17.32, 0.01))
+
+		_, head = WindComponents(90, 10, 270)
+		Expect(head).To(BeNumerically("~", -10, 0.01))
+	})
+
+	It("should accept reports within the minimums", func() {
+		ok, violations := parse("EDDH 211020Z 25010KT 9999 BKN030 17/09 Q1018").MeetsMinimums(minimums, 230)
+		Expect(ok).To(BeTrue())
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("should report the violated minimums", func() {
+		ok, violations := parse("EDDH 211020Z 32015G25KT 4000 BKN012 17/09 Q1018").MeetsMinimums(minimums, 230)
+		Expect(ok).To(BeFalse())
+		Expect(violations).To(Equal([]Violation{
+			{Kind: ViolationCeiling, Limit: 1500, Actual: 1200},
+			{Kind: ViolationVisibility, Limit: 5, Actual: 2.49},
+			{Kind: ViolationCrosswind, Limit: 12, Actual: 25},
+			{Kind: ViolationGust, Limit: 20, Actual: 25},
+		}))
+		Expect(violations[0].String()).To(Equal("ceiling 1200 below minimum 1500"))
+		Expect(violations[2].String()).To(Equal("crosswind 25 above maximum 12"))
+	})
+
+	It("should treat missing values as violations", func() {
+		ok, violations := (Result{StationID: "EDDH"}).MeetsMinimums(Minimums{VisibilitySM: 3}, 230)
+		Expect(ok).To(BeFalse())
+		Expect(violations).To(Equal([]Violation{{Kind: ViolationVisibility, Limit: 3, Missing: true}}))
+		Expect(violations[0].String()).To(Equal("visibility not reported"))
+	})
+
+})
@@ -0,0 +1,68 @@
+// Package mqttsink publishes observations collected by a metar.Collector
+// to MQTT topics. Combined with metar.WithHomeAssistantDiscovery the
+// stations show up as devices in Home Assistant.
+//
+//	p := mqttsink.New(client, mqttsink.WithRetain(true))
+//	sink := metar.NewPublishSink(p, "metar/{station}", metar.WithHomeAssistantDiscovery(""))
+package mqttsink
+
+import (
+	"context"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+var _ metar.Publisher = (*Publisher)(nil)
+
+// Publisher implements metar.Publisher publishing to MQTT. The key is
+// not transmitted as MQTT messages have no key.
+type Publisher struct {
+	client mqtt.Client
+	qos    byte
+	retain bool
+}
+
+// Option configures a Publisher
+type Option func(*Publisher)
+
+// WithQoS sets the quality of service level of the messages (default 0)
+func WithQoS(qos byte) Option {
+	return func(p *Publisher) { p.qos = qos }
+}
+
+// WithRetain marks the messages to be retained by the broker so
+// subscribers (and Home Assistant after a restart) receive the latest
+// observation and the discovery messages immediately
+func WithRetain(retain bool) Option {
+	return func(p *Publisher) { p.retain = retain }
+}
+
+// New creates a Publisher using the connected client
+func New(client mqtt.Client, opts ...Option) *Publisher {
+	p := &Publisher{client: client}
+	for _, o := range opts {
+		o(p)
+	}
+	return p
+}
+
+// Publish sends the message to the topic and waits for its delivery
+func (p *Publisher) Publish(ctx context.Context, topic, key string, value []byte) error {
+	token := p.client.Publish(topic, p.qos, p.retain, value)
+	select {
+	case <-token.Done():
+		return token.Error()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close disconnects the client after pending work completed (waiting at
+// most 250ms). The client is disconnected although it was created and
+// connected by the caller, it must not be used afterwards.
+func (p *Publisher) Close() error {
+	p.client.Disconnect(250)
+	return nil
+}
@@ -0,0 +1,13 @@
+package mqttsink_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMqttsink(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mqttsink Suite")
+}
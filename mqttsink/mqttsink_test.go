@@ -0,0 +1,97 @@
+package mqttsink_test
+
+import (
+	"context"
+	"errors"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	metar "github.com/Luzifer/go-metar"
+	. "github.com/Luzifer/go-metar/mqttsink"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type message struct {
+	topic   string
+	qos     byte
+	retain  bool
+	payload []byte
+}
+
+type token struct {
+	mqtt.Token
+	done chan struct{}
+	err  error
+}
+
+func (t *token) Done() <-chan struct{} { return t.done }
+func (t *token) Error() error          { return t.err }
+
+// recordingClient implements the methods of mqtt.Client used by the
+// Publisher, calling any other method panics
+type recordingClient struct {
+	mqtt.Client
+	msgs         []message
+	err          error
+	pending      bool
+	disconnected bool
+}
+
+func (c *recordingClient) Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token {
+	c.msgs = append(c.msgs, message{topic, qos, retained, payload.([]byte)})
+
+	t := &token{done: make(chan struct{}), err: c.err}
+	if !c.pending {
+		close(t.done)
+	}
+	return t
+}
+
+func (c *recordingClient) Disconnect(quiesce uint) {
+	c.disconnected = true
+}
+
+var _ = Describe("Publisher", func() {
+
+	It("should publish observations with the configured QoS and retain flag", func() {
+		client := &recordingClient{}
+		sink := metar.NewPublishSink(New(client, WithQoS(1), WithRetain(true)), "metar/{station}")
+
+		Expect(sink.Write(context.Background(), []metar.Result{{StationID: "EDDH"}, {StationID: "EDDW"}})).To(Succeed())
+
+		Expect(client.msgs).To(HaveLen(2))
+		Expect(client.msgs[0].topic).To(Equal("metar/eddh"))
+		Expect(client.msgs[0].qos).To(Equal(byte(1)))
+		Expect(client.msgs[0].retain).To(BeTrue())
+		Expect(string(client.msgs[1].payload)).To(ContainSubstring(`"station_id":"EDDW"`))
+	})
+
+	It("should publish with QoS 0 and without retain by default", func() {
+		client := &recordingClient{}
+		Expect(New(client).Publish(context.Background(), "metar/eddh", "EDDH", []byte("{}"))).To(Succeed())
+
+		Expect(client.msgs).To(Equal([]message{{"metar/eddh", 0, false, []byte("{}")}}))
+	})
+
+	It("should stop waiting for the delivery when the context is cancelled", func() {
+		client := &recordingClient{pending: true}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		Expect(New(client).Publish(ctx, "metar/eddh", "EDDH", []byte("{}"))).To(MatchError(context.Canceled))
+	})
+
+	It("should report failed deliveries", func() {
+		client := &recordingClient{err: errors.New("Not connected")}
+		Expect(New(client).Publish(context.Background(), "metar/eddh", "EDDH", []byte("{}"))).To(MatchError("Not connected"))
+	})
+
+	It("should disconnect the client on close", func() {
+		client := &recordingClient{}
+		Expect(New(client).Close()).To(Succeed())
+		Expect(client.disconnected).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,143 @@
+package metar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// NamedSource is implemented by sources providing a name to be recorded
+// on the results they delivered
+type NamedSource interface {
+	Source
+	Name() string
+}
+
+// SourceName returns the name of the source or its type if it does not
+// implement NamedSource
+func SourceName(s Source) string {
+	if n, ok := s.(NamedSource); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", s)
+}
+
+// Name identifies the source
+func (ADDS) Name() string { return "adds" }
+
+// Name identifies the source
+func (AVWX) Name() string { return "avwx" }
+
+// Name identifies the source
+func (CheckWX) Name() string { return "checkwx" }
+
+// Name identifies the source
+func (IEM) Name() string { return "iem" }
+
+// Name identifies the source
+func (Ogimet) Name() string { return "ogimet" }
+
+// MultiSource tries its sources in order until all requested stations are
+// satisfied: stations missing from the results of one source (because it
+// failed or had no report) are requested from the next one. Queries
+// without stations (for example area queries) are answered by the first
+// source not failing. The Source field of the results names the source
+// which delivered them.
+//
+//	client := metar.NewClient(metar.WithSource(metar.MultiSource{
+//		metar.ADDS{}, metar.NOAAText{}, metar.CheckWX{APIKey: key},
+//	}))
+type MultiSource []Source
+
+// NewFailoverSource creates the default failover chain of ADDS, the NOAA
+// station files and CheckWX. CheckWX is left out of the chain without an
+// API key.
+func NewFailoverSource(checkWXAPIKey string) MultiSource {
+	m := MultiSource{ADDS{}, NOAAText{}}
+	if checkWXAPIKey != "" {
+		m = append(m, CheckWX{APIKey: checkWXAPIKey})
+	}
+	return m
+}
+
+// WithFailover makes the client use the default failover chain, see
+// NewFailoverSource
+func WithFailover(checkWXAPIKey string) ClientOption {
+	return WithSource(NewFailoverSource(checkWXAPIKey))
+}
+
+// Fetch executes the query against the sources
+func (m MultiSource) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	if len(q.Stations) == 0 {
+		return m.fetchFirst(ctx, q)
+	}
+
+	var (
+		results   []Result
+		errs      []string
+		remaining = q.Stations
+	)
+
+	for _, src := range m {
+		if len(remaining) == 0 {
+			break
+		}
+
+		sq := q
+		sq.Stations = remaining
+
+		sr, err := src.Fetch(ctx, sq)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", SourceName(src), err))
+			continue
+		}
+
+		satisfied := make(map[string]bool)
+		for _, r := range sr {
+			if r.Source == "" {
+				r.Source = SourceName(src)
+			}
+			satisfied[strings.ToUpper(r.StationID)] = true
+			results = append(results, r)
+		}
+
+		var next []string
+		for _, s := range remaining {
+			if !satisfied[strings.ToUpper(s)] {
+				next = append(next, s)
+			}
+		}
+		remaining = next
+	}
+
+	if len(results) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("All sources failed: %s", strings.Join(errs, "; "))
+	}
+
+	return selectReports(results, q.Selection), nil
+}
+
+// fetchFirst returns the results of the first source not failing to
+// execute the query
+func (m MultiSource) fetchFirst(ctx context.Context, q Query) ([]Result, error) {
+	var errs []string
+	for _, src := range m {
+		results, err := src.Fetch(ctx, q)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", SourceName(src), err))
+			continue
+		}
+
+		for i := range results {
+			if results[i].Source == "" {
+				results[i].Source = SourceName(src)
+			}
+		}
+		return selectReports(results, q.Selection), nil
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("All sources failed: %s", strings.Join(errs, "; "))
+	}
+	return nil, nil
+}
@@ -0,0 +1,124 @@
+package metar_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type staticSource struct {
+	name    string
+	results []Result
+	err     error
+	queries []Query
+}
+
+func (s *staticSource) Name() string { return s.name }
+
+func (s *staticSource) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	s.queries = append(s.queries, q)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if len(q.Stations) == 0 {
+		return append([]Result(nil), s.results...), nil
+	}
+
+	var out []Result
+	for _, r := range s.results {
+		for _, st := range q.Stations {
+			if r.StationID == st {
+				out = append(out, r)
+			}
+		}
+	}
+	return out, nil
+}
+
+var _ = Describe("MultiSource", func() {
+	var primary, secondary *staticSource
+
+	BeforeEach(func() {
+		primary = &staticSource{name: "primary", results: []Result{{StationID: "EDDH"}}}
+		secondary = &staticSource{name: "secondary", results: []Result{{StationID: "EDDH"}, {StationID: "EDDW"}}}
+	})
+
+	It("should use the first source able to satisfy the request", func() {
+		results, err := MultiSource{primary, secondary}.Fetch(context.Background(), Query{Stations: []string{"EDDH"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Source).To(Equal("primary"))
+		Expect(secondary.queries).To(BeEmpty())
+	})
+
+	It("should request missing stations from the next source", func() {
+		results, err := MultiSource{primary, secondary}.Fetch(context.Background(), Query{
+			Stations:  []string{"EDDH", "EDDW"},
+			Selection: MostRecentForEachStation,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(secondary.queries).To(HaveLen(1))
+		Expect(secondary.queries[0].Stations).To(Equal([]string{"EDDW"}))
+
+		sources := map[string]string{}
+		for _, r := range results {
+			sources[r.StationID] = r.Source
+		}
+		Expect(sources).To(Equal(map[string]string{"EDDH": "primary", "EDDW": "secondary"}))
+	})
+
+	It("should fail over on errors", func() {
+		primary.err = errors.New("Broken")
+		results, err := MultiSource{primary, secondary}.Fetch(context.Background(), Query{Stations: []string{"EDDH"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Source).To(Equal("secondary"))
+	})
+
+	It("should report an error when all sources fail", func() {
+		primary.err = errors.New("Broken")
+		secondary.err = errors.New("Down")
+		_, err := MultiSource{primary, secondary}.Fetch(context.Background(), Query{Stations: []string{"EDDH"}})
+		Expect(err).To(MatchError("All sources failed: primary: Broken; secondary: Down"))
+	})
+
+	It("should answer area queries from the first source not failing", func() {
+		q := Query{Area: &BoundingBox{MinLat: 53, MinLon: 8, MaxLat: 54, MaxLon: 10}, Selection: MostRecentForEachStation}
+
+		results, err := MultiSource{primary, secondary}.Fetch(context.Background(), q)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Source).To(Equal("primary"))
+		Expect(primary.queries).To(Equal([]Query{q}))
+		Expect(secondary.queries).To(BeEmpty())
+
+		primary.err = errors.New("Broken")
+		results, err = MultiSource{primary, secondary}.Fetch(context.Background(), q)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Source).To(Equal("secondary"))
+
+		secondary.err = errors.New("Down")
+		_, err = MultiSource{primary, secondary}.Fetch(context.Background(), q)
+		Expect(err).To(MatchError("All sources failed: primary: Broken; secondary: Down"))
+	})
+
+	It("should be recorded on results fetched by the client", func() {
+		c := NewClient(WithSource(MultiSource{primary, secondary}))
+		r, err := c.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Source).To(Equal("primary"))
+	})
+
+	It("should create the default failover chain", func() {
+		Expect(NewFailoverSource("")).To(Equal(MultiSource{ADDS{}, NOAAText{}}))
+		Expect(NewFailoverSource("s3cr3t")).To(Equal(MultiSource{ADDS{}, NOAAText{}, CheckWX{APIKey: "s3cr3t"}}))
+	})
+
+})
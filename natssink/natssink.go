@@ -0,0 +1,64 @@
+// Package natssink publishes observations collected by a metar.Collector
+// to NATS subjects.
+//
+//	p, err := natssink.Connect(nats.DefaultURL)
+//	if err != nil { ... }
+//	defer p.Close()
+//	sink := metar.NewPublishSink(p, "metar.{station}")
+package natssink
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+// StationHeader is the message header carrying the station identifier
+const StationHeader = "Metar-Station"
+
+var _ metar.Publisher = (*Publisher)(nil)
+
+// Publisher implements metar.Publisher publishing to NATS. The key is
+// sent in the StationHeader as NATS messages have no key.
+type Publisher struct {
+	nc Conn
+}
+
+// Conn publishes messages to NATS, implemented by nats.Conn
+type Conn interface {
+	PublishMsg(m *nats.Msg) error
+	Drain() error
+}
+
+// Connect connects to the NATS server and creates a Publisher using the
+// connection
+func Connect(url string, opts ...nats.Option) (*Publisher, error) {
+	nc, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return New(nc), nil
+}
+
+// New creates a Publisher using an existing connection
+func New(nc Conn) *Publisher {
+	return &Publisher{nc: nc}
+}
+
+// Publish sends the message to the subject
+func (p *Publisher) Publish(ctx context.Context, subject, key string, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg := &nats.Msg{Subject: subject, Data: value, Header: nats.Header{}}
+	msg.Header.Set(StationHeader, key)
+	return p.nc.PublishMsg(msg)
+}
+
+// Close drains pending messages and closes the connection
+func (p *Publisher) Close() error {
+	return p.nc.Drain()
+}
@@ -0,0 +1,13 @@
+package natssink_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestNatssink(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Natssink Suite")
+}
@@ -0,0 +1,66 @@
+package natssink_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nats-io/nats.go"
+
+	metar "github.com/Luzifer/go-metar"
+	. "github.com/Luzifer/go-metar/natssink"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type recordingConn struct {
+	msgs    []*nats.Msg
+	err     error
+	drained bool
+}
+
+func (c *recordingConn) PublishMsg(m *nats.Msg) error {
+	c.msgs = append(c.msgs, m)
+	return c.err
+}
+
+func (c *recordingConn) Drain() error {
+	c.drained = true
+	return nil
+}
+
+var _ = Describe("Publisher", func() {
+
+	It("should publish observations with the station header", func() {
+		nc := &recordingConn{}
+		sink := metar.NewPublishSink(New(nc), "metar.{station}")
+
+		Expect(sink.Write(context.Background(), []metar.Result{{StationID: "EDDH"}, {StationID: "EDDW"}})).To(Succeed())
+
+		Expect(nc.msgs).To(HaveLen(2))
+		Expect(nc.msgs[0].Subject).To(Equal("metar.eddh"))
+		Expect(nc.msgs[0].Header.Get(StationHeader)).To(Equal("EDDH"))
+		Expect(string(nc.msgs[1].Data)).To(ContainSubstring(`"station_id":"EDDW"`))
+	})
+
+	It("should not publish with a cancelled context", func() {
+		nc := &recordingConn{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		Expect(New(nc).Publish(ctx, "metar", "EDDH", []byte("{}"))).To(MatchError(context.Canceled))
+		Expect(nc.msgs).To(BeEmpty())
+	})
+
+	It("should report failed publications", func() {
+		nc := &recordingConn{err: errors.New("Connection closed")}
+		Expect(New(nc).Publish(context.Background(), "metar", "EDDH", []byte("{}"))).To(MatchError("Connection closed"))
+	})
+
+	It("should drain the connection on close", func() {
+		nc := &recordingConn{}
+		Expect(New(nc).Close()).To(Succeed())
+		Expect(nc.drained).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,88 @@
+package metar
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	noaaTextBaseURL = "https://tgftp.nws.noaa.gov/data/observations/metar/stations"
+)
+
+// NOAAText is a Source reading the latest raw report of stations from the
+// text files published by the NOAA on tgftp.nws.noaa.gov. The reports are
+// decoded using the raw parser, so station positions are not available.
+// The time window of queries is ignored.
+type NOAAText struct {
+	// BaseURL of the directory containing the station files, defaults to the NOAA server
+	BaseURL string
+}
+
+// Name identifies the source
+func (NOAAText) Name() string { return "noaa" }
+
+// Fetch retrieves the latest report of every requested station
+func (n NOAAText) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	base := n.BaseURL
+	if base == "" {
+		base = noaaTextBaseURL
+	}
+
+	var results []Result
+	for _, station := range q.Stations {
+		res, err := get(ctx, fmt.Sprintf("%s/%s.TXT", base, url.PathEscape(strings.ToUpper(station))), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode == http.StatusNotFound {
+			res.Body.Close()
+			continue
+		}
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return nil, fmt.Errorf("NOAA server returned status %d", res.StatusCode)
+		}
+
+		r, err := decodeNOAAText(bufio.NewScanner(res.Body))
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		rs := []Result{*r}
+		setOrigin(rs, res)
+		results = append(results, rs...)
+	}
+
+	return selectReports(results, q.Selection), nil
+}
+
+// decodeNOAAText reads a station file consisting of a line with the time
+// of issue ("2016/05/21 10:20") followed by the raw report
+func decodeNOAAText(s *bufio.Scanner) (*Result, error) {
+	var lines []string
+	for s.Scan() {
+		if l := strings.TrimSpace(s.Text()); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("Unexpected NOAA station file with %d lines", len(lines))
+	}
+
+	ref, err := time.Parse(noaaTimeLayout, lines[0])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid NOAA issue time %q: %s", lines[0], err)
+	}
+
+	return Parser{Reference: ref}.Parse(strings.Join(lines[1:], " "))
+}
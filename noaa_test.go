@@ -0,0 +1,52 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NOAAText", func() {
+	var (
+		server *httptest.Server
+		src    NOAAText
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/EDDH.TXT" {
+				http.NotFound(w, r)
+				return
+			}
+			http.ServeFile(w, r, filepath.Join("testdata", "noaa.txt"))
+		}))
+		src = NOAAText{BaseURL: server.URL}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should parse the station file", func() {
+		results, err := src.Fetch(context.Background(), Query{Stations: []string{"eddh"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].StationID).To(Equal("EDDH"))
+		Expect(results[0].ObservationTime).To(Equal(time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)))
+		Expect(results[0].Temperature).To(Equal(17.0))
+	})
+
+	It("should skip unknown stations", func() {
+		results, err := src.Fetch(context.Background(), Query{Stations: []string{"XXXX"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+	})
+
+})
@@ -0,0 +1,114 @@
+package metar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	noaaCycleBaseURL = "https://tgftp.nws.noaa.gov/data/observations/metar/cycles"
+)
+
+// NOAACycles is a Source reading the hourly cycle files published by the
+// NOAA on tgftp.nws.noaa.gov. Every cycle file contains the reports of
+// all stations worldwide received during that hour of the last 24 hours,
+// making it suitable to mirror global data. Queries without stations
+// return the reports of all stations. Reports the raw parser does not
+// understand are skipped.
+type NOAACycles struct {
+	// BaseURL of the directory containing the cycle files, defaults to the NOAA server
+	BaseURL string
+}
+
+// Name identifies the source
+func (NOAACycles) Name() string { return "noaa-cycles" }
+
+// Fetch retrieves the cycles covering the time window of the query and
+// returns the reports of the requested stations observed within it. The
+// cycle files only cover the last 24 hours, every file is fetched once
+// even if the window is longer and reports contained in multiple files
+// are returned once.
+func (n NOAACycles) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	start, end := q.Start, q.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		hours := q.HoursBeforeNow
+		if hours <= 0 {
+			hours = 2
+		}
+		start = end.Add(-time.Duration(hours * float64(time.Hour)))
+	}
+
+	stations := make(map[string]bool)
+	for _, s := range q.Stations {
+		stations[strings.ToUpper(s)] = true
+	}
+
+	var (
+		results []Result
+		fetched = make(map[int]bool)
+		seen    = make(map[string]bool)
+	)
+	for cycle := start.UTC().Truncate(time.Hour); !cycle.After(end) && len(fetched) < 24; cycle = cycle.Add(time.Hour) {
+		if fetched[cycle.Hour()] {
+			continue
+		}
+		fetched[cycle.Hour()] = true
+
+		cr, err := n.FetchCycle(ctx, cycle.Hour())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range cr {
+			if (len(stations) > 0 && !stations[r.StationID]) || r.ObservationTime.Before(start) || r.ObservationTime.After(end) {
+				continue
+			}
+
+			key := r.StationID + "@" + r.ObservationTime.UTC().Format(time.RFC3339)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			results = append(results, r)
+		}
+	}
+
+	return selectReports(results, q.Selection), nil
+}
+
+// FetchCycle retrieves and parses all reports of the cycle file of the
+// given hour (0-23), sorted by station and observation time
+func (n NOAACycles) FetchCycle(ctx context.Context, hour int) ([]Result, error) {
+	if hour < 0 || hour > 23 {
+		return nil, fmt.Errorf("Invalid cycle hour %d", hour)
+	}
+
+	base := n.BaseURL
+	if base == "" {
+		base = noaaCycleBaseURL
+	}
+
+	res, err := get(ctx, fmt.Sprintf("%s/%02dZ.TXT", base, hour), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("NOAA server returned status %d", res.StatusCode)
+	}
+
+	results, err := parseRawReports(ctx, res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	setOrigin(results, res)
+	return results, nil
+}
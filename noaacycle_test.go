@@ -0,0 +1,88 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NOAACycles", func() {
+	var (
+		server *httptest.Server
+		src    NOAACycles
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/10Z.TXT" {
+				http.NotFound(w, r)
+				return
+			}
+			http.ServeFile(w, r, filepath.Join("testdata", "cycle.txt"))
+		}))
+		src = NOAACycles{BaseURL: server.URL}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should parse all reports of a cycle", func() {
+		results, err := src.FetchCycle(context.Background(), 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(4))
+		Expect(results[0].StationID).To(Equal("EDDH"))
+		Expect(results[0].ObservationTime).To(Equal(time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)))
+		Expect(results[1].ObservationTime).To(Equal(time.Date(2016, 5, 21, 10, 50, 0, 0, time.UTC)))
+		Expect(results[3].StationID).To(Equal("KBOS"))
+		Expect(results[3].SourceURL).To(Equal(server.URL + "/10Z.TXT"))
+	})
+
+	It("should fail on missing cycles and invalid hours", func() {
+		_, err := src.FetchCycle(context.Background(), 11)
+		Expect(err).To(HaveOccurred())
+
+		_, err = src.FetchCycle(context.Background(), 24)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should select the requested reports of the window", func() {
+		results, err := src.Fetch(context.Background(), Query{
+			Stations:  []string{"eddh", "EDDW"},
+			Selection: MostRecentForEachStation,
+			Start:     time.Date(2016, 5, 21, 10, 0, 0, 0, time.UTC),
+			End:       time.Date(2016, 5, 21, 10, 59, 0, 0, time.UTC),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].ObservationTime.Minute()).To(Equal(50))
+		Expect(results[1].StationID).To(Equal("EDDW"))
+	})
+
+	It("should fetch every cycle once for windows longer than a day", func() {
+		var requests int32
+		all := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			http.ServeFile(w, r, filepath.Join("testdata", "cycle.txt"))
+		}))
+		defer all.Close()
+
+		results, err := NOAACycles{BaseURL: all.URL}.Fetch(context.Background(), Query{
+			Selection: AllReports,
+			Start:     time.Date(2016, 5, 20, 0, 0, 0, 0, time.UTC),
+			End:       time.Date(2016, 5, 22, 23, 0, 0, 0, time.UTC),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(atomic.LoadInt32(&requests)).To(Equal(int32(24)))
+		Expect(results).To(HaveLen(4))
+	})
+
+})
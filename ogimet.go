@@ -0,0 +1,102 @@
+package metar
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ogimetBaseURL = "https://www.ogimet.com/cgi-bin/getmetar"
+)
+
+// Ogimet is a Source retrieving raw METAR history from ogimet.com which
+// covers many stations poorly covered by the NOAA services. The reports are
+// decoded using the raw parser, so station positions are not available.
+// Queries must specify a Start time (End defaults to now).
+type Ogimet struct {
+	// BaseURL of the getmetar endpoint, defaults to ogimet.com
+	BaseURL string
+}
+
+// Fetch retrieves the raw reports within the time window of the query
+func (o Ogimet) Fetch(ctx context.Context, q Query) ([]Result, error) {
+	if q.Start.IsZero() {
+		return nil, ErrMissingTimeWindow
+	}
+
+	end := q.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	base := o.BaseURL
+	if base == "" {
+		base = ogimetBaseURL
+	}
+
+	var results []Result
+	for _, station := range q.Stations {
+		params := url.Values{
+			"icao":   {station},
+			"begin":  {q.Start.UTC().Format("200601021504")},
+			"end":    {end.UTC().Format("200601021504")},
+			"header": {"no"},
+		}
+
+		res, err := get(ctx, base+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		sr, err := decodeOgimet(bufio.NewScanner(res.Body))
+		res.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		setOrigin(sr, res)
+		results = append(results, sr...)
+	}
+
+	return selectReports(results, q.Selection), nil
+}
+
+// decodeOgimet reads lines in the format
+// "ICAO,YYYY,MM,DD,HH,mm,METAR ICAO DDHHMMZ ...="
+func decodeOgimet(s *bufio.Scanner) ([]Result, error) {
+	var results []Result
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, ",", 7)
+		if len(parts) != 7 {
+			return nil, fmt.Errorf("Unexpected Ogimet line %q", line)
+		}
+
+		var date [5]int
+		for i := range date {
+			v, err := strconv.Atoi(parts[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("Unexpected Ogimet line %q", line)
+			}
+			date[i] = v
+		}
+
+		p := Parser{Reference: time.Date(date[0], time.Month(date[1]), date[2], date[3], date[4], 0, 0, time.UTC)}
+		r, err := p.Parse(parts[6])
+		if err != nil {
+			// Ogimet also delivers NIL and garbled reports which are skipped
+			continue
+		}
+		results = append(results, *r)
+	}
+
+	return results, s.Err()
+}
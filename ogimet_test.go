@@ -0,0 +1,59 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ogimet", func() {
+	var (
+		query  url.Values
+		server *httptest.Server
+		src    Ogimet
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			http.ServeFile(w, r, filepath.Join("testdata", "ogimet.txt"))
+		}))
+		src = Ogimet{BaseURL: server.URL}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should require a time window", func() {
+		_, err := src.Fetch(context.Background(), Query{Stations: []string{"EDDH"}})
+		Expect(err).To(Equal(ErrMissingTimeWindow))
+	})
+
+	It("should parse the raw report history", func() {
+		results, err := src.Fetch(context.Background(), Query{
+			Stations:  []string{"EDDH"},
+			Selection: AllReports,
+			Start:     time.Date(2016, 5, 20, 6, 0, 0, 0, time.UTC),
+			End:       time.Date(2016, 5, 20, 8, 0, 0, 0, time.UTC),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query.Get("icao")).To(Equal("EDDH"))
+		Expect(query.Get("begin")).To(Equal("201605200600"))
+		Expect(query.Get("end")).To(Equal("201605200800"))
+
+		Expect(results).To(HaveLen(2))
+		Expect(results[1].ObservationTime).To(Equal(time.Date(2016, 5, 20, 6, 50, 0, 0, time.UTC)))
+		Expect(results[1].WXString).To(Equal("-RA"))
+		Expect(results[1].FlightCategory).To(Equal(FlightCategoryVFR))
+	})
+
+})
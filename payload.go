@@ -0,0 +1,45 @@
+package metar
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// WithRawPayload keeps the raw upstream response (XML, JSON or text) on
+// the results decoded from it (see Result.Payload) so consumers can archive
+// the original payloads. The response is read completely before decoding,
+// which disables the flat memory usage of Stream.
+func WithRawPayload() ClientOption {
+	return func(c *Client) { c.rawPayload = true }
+}
+
+// payloadBody is a response body read into memory
+type payloadBody struct {
+	*bytes.Reader
+	data []byte
+}
+
+func (payloadBody) Close() error { return nil }
+
+// retainPayload reads the response body into memory to be able to attach
+// it to the results
+func retainPayload(res *http.Response) (*http.Response, error) {
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Body = payloadBody{Reader: bytes.NewReader(data), data: data}
+	return res, nil
+}
+
+// responsePayload returns the retained body of the response
+func responsePayload(res *http.Response) []byte {
+	if pb, ok := res.Body.(payloadBody); ok {
+		return pb.data
+	}
+	return nil
+}
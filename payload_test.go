@@ -0,0 +1,35 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Raw payload", func() {
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return xmlResponse(req, singleResultXML)
+	})
+
+	It("should keep the upstream response on the result", func() {
+		client := NewClient(WithRawPayload(), WithHTTPClient(&http.Client{Transport: transport}))
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(r.Payload)).To(Equal(singleResultXML))
+	})
+
+	It("should not keep the response by default", func() {
+		client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.Payload).To(BeNil())
+	})
+
+})
@@ -0,0 +1,202 @@
+// Package pgstore writes observations into PostgreSQL (or TimescaleDB)
+// using a *sql.DB opened with a driver of your choice.
+//
+//	db, err := sql.Open("pgx", dsn)
+//	if err != nil { ... }
+//	if err := pgstore.Migrate(ctx, db); err != nil { ... }
+//
+//	w := pgstore.New(db)
+//	err = w.Write(ctx, results)
+package pgstore
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+// Table is the name of the table holding the observations
+const Table = "metar_observations"
+
+// migrations are applied in order, their index + 1 is the schema version
+// stored in metar_schema_version
+var migrations = []string{
+	`CREATE TABLE IF NOT EXISTS ` + Table + ` (
+		station               TEXT             NOT NULL,
+		obs_time              TIMESTAMPTZ      NOT NULL,
+		raw_text              TEXT             NOT NULL,
+		metar_type            TEXT,
+		latitude              DOUBLE PRECISION,
+		longitude             DOUBLE PRECISION,
+		elevation_m           DOUBLE PRECISION,
+		temp_c                DOUBLE PRECISION,
+		dewpoint_c            DOUBLE PRECISION,
+		wind_dir_degrees      INTEGER,
+		wind_speed_kt         INTEGER,
+		wind_gust_kt          INTEGER,
+		visibility_statute_mi DOUBLE PRECISION,
+		altim_in_hg           DOUBLE PRECISION,
+		sea_level_pressure_mb DOUBLE PRECISION,
+		wx_string             TEXT,
+		sky_cover             TEXT,
+		flight_category       TEXT,
+		source                TEXT,
+		fetched_at            TIMESTAMPTZ,
+		PRIMARY KEY (station, obs_time)
+	)`,
+}
+
+// Migrate creates or updates the schema. It is safe to call on every start.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS metar_schema_version (version INTEGER NOT NULL)`); err != nil {
+		return err
+	}
+
+	var version int
+	switch err := tx.QueryRowContext(ctx, `SELECT version FROM metar_schema_version`).Scan(&version); err {
+	case nil:
+	case sql.ErrNoRows:
+		if _, err := tx.ExecContext(ctx, `INSERT INTO metar_schema_version (version) VALUES (0)`); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	for ; version < len(migrations); version++ {
+		if _, err := tx.ExecContext(ctx, migrations[version]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE metar_schema_version SET version = $1`, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CreateHypertable converts the observation table into a TimescaleDB
+// hypertable partitioned by observation time. Call it after Migrate on
+// databases having the timescaledb extension installed.
+func CreateHypertable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `SELECT create_hypertable('`+Table+`', 'obs_time', if_not_exists => TRUE, migrate_data => TRUE)`)
+	return err
+}
+
+// Writer upserts observations into the table created by Migrate
+type Writer struct {
+	db *sql.DB
+}
+
+var _ metar.Sink = (*Writer)(nil)
+
+// New creates a Writer using the given database
+func New(db *sql.DB) *Writer {
+	return &Writer{db: db}
+}
+
+var columns = []string{
+	"station", "obs_time", "raw_text", "metar_type", "latitude", "longitude", "elevation_m",
+	"temp_c", "dewpoint_c", "wind_dir_degrees", "wind_speed_kt", "wind_gust_kt",
+	"visibility_statute_mi", "altim_in_hg", "sea_level_pressure_mb", "wx_string",
+	"sky_cover", "flight_category", "source", "fetched_at",
+}
+
+var upsertQuery = func() string {
+	var (
+		params  = make([]string, len(columns))
+		updates []string
+	)
+	for i, c := range columns {
+		params[i] = "$" + strconv.Itoa(i+1)
+		if i > 1 {
+			updates = append(updates, c+" = EXCLUDED."+c)
+		}
+	}
+
+	return "INSERT INTO " + Table + " (" + strings.Join(columns, ", ") + ") VALUES (" +
+		strings.Join(params, ", ") + ") ON CONFLICT (station, obs_time) DO UPDATE SET " +
+		strings.Join(updates, ", ")
+}()
+
+// Write upserts the results in a single transaction. Values not reported
+// by the source are stored as NULL.
+func (w *Writer) Write(ctx context.Context, results []metar.Result) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, upsertQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range results {
+		if _, err := stmt.ExecContext(ctx, values(r)...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// values returns the column values of the result in the order of columns
+func values(r metar.Result) []interface{} {
+	// Results without presence information (e.g. constructed by hand)
+	// have all their values stored
+	known := func(f metar.Field) bool {
+		return r.Present == 0 || r.Present.Has(f)
+	}
+	opt := func(f metar.Field, v interface{}) interface{} {
+		if !known(f) {
+			return nil
+		}
+		return v
+	}
+	str := func(s string) interface{} {
+		if s == "" {
+			return nil
+		}
+		return s
+	}
+
+	var fetchedAt interface{}
+	if !r.FetchedAt.IsZero() {
+		fetchedAt = r.FetchedAt.UTC()
+	}
+
+	return []interface{}{
+		strings.ToUpper(r.StationID),
+		r.ObservationTime.UTC(),
+		r.RawText,
+		str(r.MetarType),
+		opt(metar.FieldLatitude, r.Latitude),
+		opt(metar.FieldLongitude, r.Longitude),
+		opt(metar.FieldElevation, r.Elevation),
+		opt(metar.FieldTemperature, r.Temperature),
+		opt(metar.FieldDewpoint, r.Dewpoint),
+		opt(metar.FieldWindDirDegrees, r.WindDirDegrees),
+		opt(metar.FieldWindSpeed, r.WindSpeed),
+		opt(metar.FieldWindGust, r.WindGust),
+		opt(metar.FieldVisibilityStatute, r.VisibilityStatute),
+		opt(metar.FieldAltimeter, r.Altimeter),
+		opt(metar.FieldSeaLevelPressure, r.SeaLevelPressure),
+		str(r.WXString),
+		str(string(r.SkyCondition.SkyCover)),
+		str(string(r.FlightCategory)),
+		str(r.Source),
+		fetchedAt,
+	}
+}
@@ -0,0 +1,13 @@
+package pgstore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPgstore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pgstore Suite")
+}
@@ -0,0 +1,147 @@
+package pgstore_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	metar "github.com/Luzifer/go-metar"
+	. "github.com/Luzifer/go-metar/pgstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// recordingDriver is a database/sql driver recording executed statements.
+// Queries return the configured schema version or no rows.
+type recordingDriver struct {
+	mu      sync.Mutex
+	execs   []string
+	args    [][]driver.Value
+	version *int64
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return recordingConn{d}, nil }
+
+func (d *recordingDriver) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.execs, d.args, d.version = nil, nil, nil
+}
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c recordingConn) Prepare(query string) (driver.Stmt, error) {
+	return recordingStmt{c.d, query}, nil
+}
+func (c recordingConn) Close() error              { return nil }
+func (c recordingConn) Begin() (driver.Tx, error) { return recordingTx{}, nil }
+
+type recordingTx struct{}
+
+func (recordingTx) Commit() error   { return nil }
+func (recordingTx) Rollback() error { return nil }
+
+type recordingStmt struct {
+	d     *recordingDriver
+	query string
+}
+
+func (s recordingStmt) Close() error  { return nil }
+func (s recordingStmt) NumInput() int { return -1 }
+
+func (s recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.execs = append(s.d.execs, s.query)
+	s.d.args = append(s.d.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &versionRows{version: s.d.version}, nil
+}
+
+type versionRows struct {
+	version *int64
+	done    bool
+}
+
+func (r *versionRows) Columns() []string { return []string{"version"} }
+func (r *versionRows) Close() error      { return nil }
+
+func (r *versionRows) Next(dest []driver.Value) error {
+	if r.version == nil || r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = *r.version
+	return nil
+}
+
+var recorder = &recordingDriver{}
+
+func init() {
+	sql.Register("pgstore-recording", recorder)
+}
+
+var _ = Describe("Pgstore", func() {
+	var db *sql.DB
+
+	BeforeEach(func() {
+		recorder.reset()
+
+		var err error
+		db, err = sql.Open("pgstore-recording", "")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db.Close()
+	})
+
+	It("should create the schema on a fresh database", func() {
+		Expect(Migrate(context.Background(), db)).To(Succeed())
+
+		stmts := strings.Join(recorder.execs, "\n")
+		Expect(stmts).To(ContainSubstring("INSERT INTO metar_schema_version (version) VALUES (0)"))
+		Expect(stmts).To(ContainSubstring("CREATE TABLE IF NOT EXISTS " + Table))
+		Expect(stmts).To(ContainSubstring("PRIMARY KEY (station, obs_time)"))
+		Expect(recorder.args[len(recorder.args)-1]).To(Equal([]driver.Value{int64(1)}))
+	})
+
+	It("should not reapply migrations", func() {
+		v := int64(1)
+		recorder.version = &v
+		Expect(Migrate(context.Background(), db)).To(Succeed())
+		Expect(strings.Join(recorder.execs, "\n")).NotTo(ContainSubstring("CREATE TABLE IF NOT EXISTS " + Table))
+	})
+
+	It("should upsert results storing missing values as NULL", func() {
+		obs := time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)
+		r := metar.Result{StationID: "eddh", ObservationTime: obs, RawText: "EDDH 211020Z ...", Temperature: 17, FlightCategory: metar.FlightCategoryVFR}
+		r.Present.Add(metar.FieldStationID)
+		r.Present.Add(metar.FieldTemperature)
+
+		Expect(New(db).Write(context.Background(), []metar.Result{r})).To(Succeed())
+		Expect(recorder.execs).To(HaveLen(1))
+		Expect(recorder.execs[0]).To(HavePrefix("INSERT INTO " + Table))
+		Expect(recorder.execs[0]).To(ContainSubstring("ON CONFLICT (station, obs_time) DO UPDATE SET raw_text = EXCLUDED.raw_text"))
+
+		args := recorder.args[0]
+		Expect(args).To(HaveLen(20))
+		Expect(args[0]).To(Equal("EDDH"))
+		Expect(args[1]).To(Equal(obs))
+		Expect(args[4]).To(BeNil())
+		Expect(args[7]).To(Equal(17.0))
+		Expect(args[17]).To(Equal("VFR"))
+		Expect(args[19]).To(BeNil())
+	})
+
+})
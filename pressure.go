@@ -0,0 +1,64 @@
+package metar
+
+import "math"
+
+const (
+	isaSeaLevelTempK = 288.15  // ISA temperature at mean sea level (kelvin)
+	isaLapseRate     = 0.0065  // ISA temperature lapse rate (kelvin per meter)
+	gravity          = 9.80665 // Standard gravity (m/s²)
+	gasConstantAir   = 287.053 // Specific gas constant of dry air (J/(kg·K))
+)
+
+// isaPressureExponent is g / (R * L) used by the barometric formula
+var isaPressureExponent = gravity / (gasConstantAir * isaLapseRate)
+
+// QNHToQFE converts the QNH (hPa) into the pressure at an aerodrome
+// elevation (meters) using the standard atmosphere as QNH is defined by
+func QNHToQFE(qnh, elevation float64) float64 {
+	return qnh * math.Pow(1-isaLapseRate*elevation/isaSeaLevelTempK, isaPressureExponent)
+}
+
+// QFEToQNH converts the pressure at an aerodrome elevation (meters) into
+// the QNH (hPa) using the standard atmosphere
+func QFEToQNH(qfe, elevation float64) float64 {
+	return qfe / math.Pow(1-isaLapseRate*elevation/isaSeaLevelTempK, isaPressureExponent)
+}
+
+// SeaLevelToStationPressure reduces a sea level pressure (hPa) to the
+// station elevation (meters) using the actual station temperature
+// (celsius) instead of the standard atmosphere
+func SeaLevelToStationPressure(seaLevel, elevation, tempC float64) float64 {
+	return seaLevel * math.Exp(-gravity*elevation/(gasConstantAir*meanColumnTemp(elevation, tempC)))
+}
+
+// StationToSeaLevelPressure reduces a station pressure (hPa) at the
+// station elevation (meters) to sea level using the actual station
+// temperature (celsius)
+func StationToSeaLevelPressure(station, elevation, tempC float64) float64 {
+	return station * math.Exp(gravity*elevation/(gasConstantAir*meanColumnTemp(elevation, tempC)))
+}
+
+// meanColumnTemp estimates the mean temperature (kelvin) of the air column
+// between sea level and the station using the standard lapse rate
+func meanColumnTemp(elevation, tempC float64) float64 {
+	return tempC + 273.15 + isaLapseRate*elevation/2
+}
+
+// QNH returns the altimeter setting in hPa
+func (r Result) QNH() (float64, bool) {
+	if !r.Present.Has(FieldAltimeter) {
+		return 0, false
+	}
+	return InHgTohPa(r.Altimeter), true
+}
+
+// QFE returns the pressure in hPa at the station elevation derived from
+// the altimeter setting. It requires the report to contain the station
+// elevation.
+func (r Result) QFE() (float64, bool) {
+	qnh, ok := r.QNH()
+	if !ok || !r.Present.Has(FieldElevation) {
+		return 0, false
+	}
+	return QNHToQFE(qnh, r.Elevation), true
+}
@@ -0,0 +1,40 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pressure", func() {
+
+	It("should convert between QNH and QFE", func() {
+		Expect(QNHToQFE(1013.25, 0)).To(Equal(1013.25))
+		Expect(QNHToQFE(1013.25, 1000)).To(BeNumerically("~", 898.75, 0.01))
+		Expect(QFEToQNH(QNHToQFE(1020, 450), 450)).To(BeNumerically("~", 1020, 1e-9))
+	})
+
+	It("should reduce pressures using the station temperature", func() {
+		station := SeaLevelToStationPressure(1013.25, 300, 15)
+		Expect(station).To(BeNumerically("~", 977.9, 0.1))
+		Expect(StationToSeaLevelPressure(station, 300, 15)).To(BeNumerically("~", 1013.25, 1e-9))
+
+		// Cold air is denser, the pressure decreases faster with height
+		Expect(SeaLevelToStationPressure(1013.25, 300, -20)).To(BeNumerically("<", station))
+	})
+
+	It("should compute the QFE of a result", func() {
+		r := Result{Altimeter: 30.12, Elevation: 253}
+		r.Present.Add(FieldAltimeter)
+
+		_, ok := r.QFE()
+		Expect(ok).To(BeFalse())
+
+		r.Present.Add(FieldElevation)
+		qfe, ok := r.QFE()
+		Expect(ok).To(BeTrue())
+		Expect(qfe).To(BeNumerically("~", 989.75, 0.01))
+	})
+
+})
@@ -0,0 +1,117 @@
+package metar
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PrometheusSink is a Sink keeping the newest observation of every station
+// and serving them as gauges in the Prometheus text exposition format.
+// Values not reported by a station are omitted.
+//
+//	prom := metar.NewPrometheusSink()
+//	collector := metar.NewCollector(client, stations, 10*time.Minute, prom)
+//	http.Handle("/metrics", prom)
+type PrometheusSink struct {
+	mu     sync.Mutex
+	latest map[string]Result
+}
+
+type promGauge struct {
+	name, help string
+	value      func(Result) (float64, bool)
+}
+
+var promGauges = []promGauge{
+	{"metar_observation_timestamp_seconds", "Observation time of the newest report", func(r Result) (float64, bool) {
+		return float64(r.ObservationTime.Unix()), !r.ObservationTime.IsZero()
+	}},
+	{"metar_temperature_celsius", "Air temperature", func(r Result) (float64, bool) {
+		return r.Temperature, r.Present.Has(FieldTemperature)
+	}},
+	{"metar_dewpoint_celsius", "Dewpoint temperature", func(r Result) (float64, bool) {
+		return r.Dewpoint, r.Present.Has(FieldDewpoint)
+	}},
+	{"metar_wind_direction_degrees", "Direction the wind is blowing from, 0 for variable wind", func(r Result) (float64, bool) {
+		return float64(r.WindDirDegrees), r.Present.Has(FieldWindDirDegrees)
+	}},
+	{"metar_wind_speed_knots", "Wind speed", func(r Result) (float64, bool) {
+		return float64(r.WindSpeed), r.Present.Has(FieldWindSpeed)
+	}},
+	{"metar_wind_gust_knots", "Wind gusts", func(r Result) (float64, bool) {
+		return float64(r.WindGust), r.Present.Has(FieldWindGust)
+	}},
+	{"metar_visibility_statute_miles", "Horizontal visibility", func(r Result) (float64, bool) {
+		return r.VisibilityStatute, r.Present.Has(FieldVisibilityStatute)
+	}},
+	{"metar_altimeter_inhg", "Altimeter setting", func(r Result) (float64, bool) {
+		return r.Altimeter, r.Present.Has(FieldAltimeter)
+	}},
+}
+
+// NewPrometheusSink creates an empty PrometheusSink
+func NewPrometheusSink() *PrometheusSink {
+	return &PrometheusSink{latest: make(map[string]Result)}
+}
+
+// Write implements Sink and keeps the newest observation of every station
+func (p *PrometheusSink) Write(ctx context.Context, results []Result) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, r := range results {
+		key := strings.ToUpper(r.StationID)
+		if last, ok := p.latest[key]; ok && last.ObservationTime.After(r.ObservationTime) {
+			continue
+		}
+		p.latest[key] = r
+	}
+	return nil
+}
+
+// ServeHTTP serves the metrics of the kept observations
+func (p *PrometheusSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	p.WriteMetrics(w)
+}
+
+// WriteMetrics writes the metrics in the Prometheus text exposition format
+func (p *PrometheusSink) WriteMetrics(w io.Writer) error {
+	p.mu.Lock()
+	stations := make([]string, 0, len(p.latest))
+	for st := range p.latest {
+		stations = append(stations, st)
+	}
+	sort.Strings(stations)
+	results := make([]Result, len(stations))
+	for i, st := range stations {
+		results[i] = p.latest[st]
+	}
+	p.mu.Unlock()
+
+	var buf strings.Builder
+	for _, g := range promGauges {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+		for i, r := range results {
+			if v, ok := g.value(r); ok {
+				fmt.Fprintf(&buf, "%s{station=%q} %s\n", g.name, stations[i], strconv.FormatFloat(v, 'f', -1, 64))
+			}
+		}
+	}
+
+	fmt.Fprintf(&buf, "# HELP metar_flight_category Flight category of the newest report (1 for the reported category)\n# TYPE metar_flight_category gauge\n")
+	for i, r := range results {
+		if r.Present.Has(FieldFlightCategory) {
+			fmt.Fprintf(&buf, "metar_flight_category{station=%q,category=%q} 1\n", stations[i], string(r.FlightCategory))
+		}
+	}
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
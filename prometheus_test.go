@@ -0,0 +1,74 @@
+package metar_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusSink", func() {
+	var (
+		sink *PrometheusSink
+		obs  time.Time
+	)
+
+	BeforeEach(func() {
+		sink = NewPrometheusSink()
+		obs = time.Date(2016, 12, 27, 12, 50, 0, 0, time.UTC)
+	})
+
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		Expect(rec.Header().Get("Content-Type")).To(HavePrefix("text/plain; version=0.0.4"))
+		body, err := ioutil.ReadAll(rec.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return string(body)
+	}
+
+	It("should export the reported values of the newest observations", func() {
+		r := Result{StationID: "eddh", ObservationTime: obs, Temperature: 3.5, WindSpeed: 12, FlightCategory: FlightCategoryVFR}
+		r.Present.Add(FieldTemperature)
+		r.Present.Add(FieldWindSpeed)
+		r.Present.Add(FieldFlightCategory)
+		older := Result{StationID: "EDDH", ObservationTime: obs.Add(-time.Hour), Temperature: 1}
+		older.Present.Add(FieldTemperature)
+
+		Expect(sink.Write(context.Background(), []Result{r})).To(Succeed())
+		Expect(sink.Write(context.Background(), []Result{older})).To(Succeed())
+
+		body := scrape()
+		Expect(body).To(ContainSubstring("# TYPE metar_temperature_celsius gauge\n"))
+		Expect(body).To(ContainSubstring(`metar_temperature_celsius{station="EDDH"} 3.5` + "\n"))
+		Expect(body).To(ContainSubstring(`metar_wind_speed_knots{station="EDDH"} 12` + "\n"))
+		Expect(body).To(ContainSubstring(fmt.Sprintf("metar_observation_timestamp_seconds{station=\"EDDH\"} %d\n", obs.Unix())))
+		Expect(body).To(ContainSubstring(`metar_flight_category{station="EDDH",category="VFR"} 1` + "\n"))
+	})
+
+	It("should omit values not reported", func() {
+		r := Result{StationID: "EDDW", ObservationTime: obs}
+		Expect(sink.Write(context.Background(), []Result{r})).To(Succeed())
+
+		body := scrape()
+		Expect(body).To(ContainSubstring(`metar_observation_timestamp_seconds{station="EDDW"}`))
+		Expect(body).NotTo(ContainSubstring(`metar_temperature_celsius{station="EDDW"}`))
+		Expect(body).NotTo(ContainSubstring(`metar_wind_gust_knots{station="EDDW"}`))
+	})
+
+	It("should sort the stations", func() {
+		Expect(sink.Write(context.Background(), []Result{
+			{StationID: "EDDW", ObservationTime: obs},
+			{StationID: "EDDH", ObservationTime: obs},
+		})).To(Succeed())
+
+		body := scrape()
+		Expect(body).To(MatchRegexp(`(?s)station="EDDH".*station="EDDW"`))
+	})
+})
@@ -0,0 +1,98 @@
+package metar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Publisher sends a message to a message broker. The kafkasink and
+// natssink packages implement it for Kafka and NATS.
+type Publisher interface {
+	Publish(ctx context.Context, topic, key string, value []byte) error
+}
+
+// BatchPublisher is implemented by Publishers sending multiple messages
+// at once more efficiently than one by one (kafkasink). PublishSink sends
+// all observations of a Write using a single PublishBatch call.
+type BatchPublisher interface {
+	Publisher
+	PublishBatch(ctx context.Context, messages []PublishMessage) error
+}
+
+// PublishMessage is a message sent by a BatchPublisher
+type PublishMessage struct {
+	Topic string
+	Key   string
+	Value []byte
+}
+
+// PublishSink is a Sink publishing every observation as JSON message
+// keyed by its station identifier
+type PublishSink struct {
+	publisher Publisher
+	topic     string
+
+	discoveryPrefix string
+	announced       map[string]bool
+	mu              sync.Mutex
+}
+
+// PublishSinkOption configures a PublishSink
+type PublishSinkOption func(*PublishSink)
+
+// NewPublishSink creates a sink publishing to the topic (Kafka) or subject
+// (NATS). The placeholder "{station}" within the topic is replaced by the
+// lower-case station identifier to publish every station on a topic of
+// its own ("metar.{station}" publishes EDDH to "metar.eddh").
+func NewPublishSink(p Publisher, topic string, opts ...PublishSinkOption) *PublishSink {
+	s := &PublishSink{publisher: p, topic: topic, announced: map[string]bool{}}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// Write publishes the results, failing publications do not prevent the
+// remaining results from being published
+func (s *PublishSink) Write(ctx context.Context, results []Result) error {
+	var (
+		errs  []string
+		batch []PublishMessage
+	)
+
+	bp, isBatch := s.publisher.(BatchPublisher)
+	for _, r := range results {
+		value, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+
+		station := strings.ToUpper(r.StationID)
+		topic := strings.ReplaceAll(s.topic, "{station}", strings.ToLower(station))
+		if err := s.announce(ctx, station, topic); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", station, err))
+			continue
+		}
+		if isBatch {
+			batch = append(batch, PublishMessage{Topic: topic, Key: station, Value: value})
+			continue
+		}
+		if err := s.publisher.Publish(ctx, topic, station, value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", station, err))
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := bp.PublishBatch(ctx, batch); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Publishing observations failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package metar_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type publishedMessage struct {
+	topic, key string
+	value      []byte
+}
+
+type recordingPublisher struct {
+	messages []publishedMessage
+	fail     string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic, key string, value []byte) error {
+	if key == p.fail {
+		return errors.New("broker unavailable")
+	}
+	p.messages = append(p.messages, publishedMessage{topic, key, value})
+	return nil
+}
+
+type batchPublisher struct {
+	recordingPublisher
+	batches [][]PublishMessage
+}
+
+func (p *batchPublisher) PublishBatch(ctx context.Context, messages []PublishMessage) error {
+	p.batches = append(p.batches, messages)
+	return nil
+}
+
+var _ = Describe("PublishSink", func() {
+
+	It("should publish observations keyed by station", func() {
+		p := &recordingPublisher{}
+		err := NewPublishSink(p, "metar.{station}").Write(context.Background(), []Result{{StationID: "eddh"}, {StationID: "EDDW"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(p.messages).To(HaveLen(2))
+		Expect(p.messages[0].topic).To(Equal("metar.eddh"))
+		Expect(p.messages[0].key).To(Equal("EDDH"))
+		Expect(p.messages[1].topic).To(Equal("metar.eddw"))
+
+		var r Result
+		Expect(json.Unmarshal(p.messages[1].value, &r)).To(Succeed())
+		Expect(r.StationID).To(Equal("EDDW"))
+	})
+
+	It("should publish the remaining observations on failures", func() {
+		p := &recordingPublisher{fail: "EDDH"}
+		err := NewPublishSink(p, "observations").Write(context.Background(), []Result{{StationID: "EDDH"}, {StationID: "EDDW"}})
+		Expect(err).To(MatchError("Publishing observations failed: EDDH: broker unavailable"))
+		Expect(p.messages).To(HaveLen(1))
+		Expect(p.messages[0].topic).To(Equal("observations"))
+	})
+
+	It("should publish all observations at once using a BatchPublisher", func() {
+		p := &batchPublisher{}
+		err := NewPublishSink(p, "metar.{station}").Write(context.Background(), []Result{{StationID: "EDDH"}, {StationID: "EDDW"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(p.messages).To(BeEmpty())
+		Expect(p.batches).To(HaveLen(1))
+		Expect(p.batches[0]).To(HaveLen(2))
+		Expect(p.batches[0][1].Topic).To(Equal("metar.eddw"))
+		Expect(p.batches[0][1].Key).To(Equal("EDDW"))
+	})
+
+})
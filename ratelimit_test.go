@@ -0,0 +1,42 @@
+package metar_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+
+	It("should allow a burst without waiting", func() {
+		l := NewRateLimiter(1, 3)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			Expect(l.Wait(context.Background())).To(Succeed())
+		}
+		Expect(time.Since(start)).To(BeNumerically("<", 50*time.Millisecond))
+	})
+
+	It("should throttle requests exceeding the burst", func() {
+		l := NewRateLimiter(20, 1)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			Expect(l.Wait(context.Background())).To(Succeed())
+		}
+		Expect(time.Since(start)).To(BeNumerically(">=", 90*time.Millisecond))
+	})
+
+	It("should stop waiting when the context is done", func() {
+		l := NewRateLimiter(0.1, 1)
+		Expect(l.Wait(context.Background())).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		Expect(l.Wait(ctx)).To(MatchError(context.DeadlineExceeded))
+	})
+
+})
@@ -0,0 +1,371 @@
+package metar
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMissingStation is returned when a raw METAR does not start with a station identifier
+	ErrMissingStation = errors.New("Raw METAR does not contain a station identifier")
+	// ErrMissingTime is returned when a raw METAR does not contain an observation time group
+	ErrMissingTime = errors.New("Raw METAR does not contain an observation time")
+	// ErrNilReport is returned for reports marked as NIL (missing observation)
+	ErrNilReport = errors.New("Raw METAR is a NIL report")
+
+	stationRegex     = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	timeRegex        = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	windRegex        = regexp.MustCompile(`^(\d{3}|VRB|///)(\d{2,3}|//)(?:G(\d{2,3}))?(KT|MPS|KMH)$`)
+	windVarRegex     = regexp.MustCompile(`^\d{3}V\d{3}$`)
+	dirVisRegex      = regexp.MustCompile(`^\d{4}(N|NE|E|SE|S|SW|W|NW)$`)
+	rvrRegex         = regexp.MustCompile(`^R\d{2}[LCR]?/`)
+	weatherRegex     = regexp.MustCompile(`^(?:\+|-|VC)?(?:MI|PR|BC|DR|BL|SH|TS|FZ)?(?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*$`)
+	cloudRegex       = regexp.MustCompile(`^(FEW|SCT|BKN|OVC|VV)(\d{3}|///)(CB|TCU|///)?$`)
+	tempRegex        = regexp.MustCompile(`^(M?\d{2})/(M?\d{2})?$`)
+	altimeterRegex   = regexp.MustCompile(`^([AQ])(\d{4})$`)
+	seaLevelPresRegx = regexp.MustCompile(`^SLP(\d{3})$`)
+)
+
+// Parser decodes raw METAR reports into Results
+type Parser struct {
+	// Reference is the time the report was issued around. Raw METARs only
+	// contain day of month and time so year and month are taken from the
+	// latest matching time not after Reference. Defaults to now.
+	Reference time.Time
+	// Strict makes the parser fail on the first group it does not
+	// understand instead of ignoring it
+	Strict bool
+}
+
+// ParseWarning describes a group of a raw METAR the parser ignored. In
+// strict mode it is returned as the error, except for unrecognized groups
+// which are returned as ErrUnknownGroup.
+type ParseWarning struct {
+	Group   string
+	Offset  int // Byte offset of the group within the raw report
+	Message string
+}
+
+func (w ParseWarning) Error() string {
+	return fmt.Sprintf("%s: %q at offset %d", w.Message, w.Group, w.Offset)
+}
+
+// ErrUnknownGroup is returned by strict parsers for a group of the raw
+// METAR they do not understand
+type ErrUnknownGroup struct {
+	Token string
+	Pos   int // Byte offset of the token within the raw report
+}
+
+func (e ErrUnknownGroup) Error() string {
+	return fmt.Sprintf("Unrecognized group: %q at offset %d", e.Token, e.Pos)
+}
+
+// ParseError locates the token a raw METAR was rejected at. Err is one of
+// ErrMissingStation, ErrMissingTime and ErrNilReport and can be checked
+// using errors.Is.
+type ParseError struct {
+	Token string // Offending token, empty if the report ended early
+	Pos   int    // Byte offset of the token within the raw report
+	Err   error
+}
+
+func (e ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s at offset %d", e.Err, e.Pos)
+	}
+	return fmt.Sprintf("%s: %q at offset %d", e.Err, e.Token, e.Pos)
+}
+
+func (e ParseError) Unwrap() error { return e.Err }
+
+// parseError creates the ParseError for the first of the remaining tokens
+// or the end of the raw report
+func parseError(err error, tokens []Token, raw string) ParseError {
+	if len(tokens) == 0 {
+		return ParseError{Pos: len(raw), Err: err}
+	}
+	return ParseError{Token: tokens[0].Text, Pos: tokens[0].Offset, Err: err}
+}
+
+// ParseRaw decodes a raw METAR report issued within the last month
+func ParseRaw(raw string) (*Result, error) {
+	return Parser{}.Parse(raw)
+}
+
+// Parse decodes a raw METAR report. Groups which are not understood by
+// the parser are ignored unless the parser is strict.
+func (p Parser) Parse(raw string) (*Result, error) {
+	r, _, err := p.ParseWithWarnings(raw)
+	return r, err
+}
+
+// ParseWithWarnings decodes a raw METAR report and returns the groups
+// which were ignored. In strict mode the first of them is returned as
+// error instead.
+func (p Parser) ParseWithWarnings(raw string) (*Result, []ParseWarning, error) {
+	raw = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(raw), "="))
+	all := Tokenize(raw)
+	tokens := all
+
+	var warnings []ParseWarning
+	warn := func(t Token, msg string) error {
+		w := ParseWarning{Group: t.Text, Offset: t.Offset, Message: msg}
+		if p.Strict {
+			return w
+		}
+		warnings = append(warnings, w)
+		return nil
+	}
+
+	r := &Result{RawText: raw, MetarType: "METAR"}
+	r.Present.Add(FieldRawText)
+	r.Present.Add(FieldMetarType)
+
+	var header []Token
+	for len(tokens) > 0 && (tokens[0].Kind == TokenType || tokens[0].Kind == TokenModifier) {
+		header, tokens = append(header, tokens[0]), tokens[1:]
+	}
+	for _, t := range header {
+		if t.Kind == TokenType {
+			r.MetarType = t.Text
+		}
+	}
+
+	if len(tokens) == 0 || tokens[0].Kind != TokenStation {
+		return nil, nil, parseError(ErrMissingStation, tokens, raw)
+	}
+	r.StationID, tokens = tokens[0].Text, tokens[1:]
+	r.Present.Add(FieldStationID)
+
+	if len(tokens) == 0 || tokens[0].Kind != TokenTime {
+		return nil, nil, parseError(ErrMissingTime, tokens, raw)
+	}
+	r.ObservationTime, tokens = p.observationTime(tokens[0].Text), tokens[1:]
+	r.Present.Add(FieldObservationTime)
+
+	var (
+		weather []string
+		ceiling = -1
+		vis     *Visibility
+	)
+
+	for _, t := range tokens {
+		f := t.Text
+
+		switch t.Kind {
+		case TokenModifier:
+			if f == "NIL" {
+				return nil, nil, ParseError{Token: f, Pos: t.Offset, Err: ErrNilReport}
+			}
+
+		case TokenUnknown:
+			if p.Strict {
+				return nil, nil, ErrUnknownGroup{Token: f, Pos: t.Offset}
+			}
+			warn(t, "Unrecognized group")
+
+		case TokenRemarks:
+			p.parseRemarks(r, strings.Fields(f))
+
+		case TokenWind:
+			parseWind(r, windRegex.FindStringSubmatch(f))
+
+		case TokenVisibility:
+			if vis != nil {
+				if err := warn(t, "Additional visibility group ignored"); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			v, _ := ParseVisibility(f)
+			vis = &v
+			if f == "CAVOK" {
+				r.SkyCondition.SkyCover = SkyCoverCAVOK
+				r.Present.Add(FieldSkyCondition)
+			}
+
+		case TokenCloud:
+			if f == "SKC" || f == "CLR" || f == "NSC" || f == "NCD" {
+				cover := SkyCover(f)
+				if f == "NCD" {
+					cover = SkyCoverCLR
+				}
+				r.SkyCondition.SkyCover = cover
+				r.Present.Add(FieldSkyCondition)
+				continue
+			}
+
+			coverText, baseText := splitCloudGroup(f)
+			cover := SkyCover(coverText)
+			if coverText == "VV" {
+				cover = SkyCoverOVX
+				if vv, err := strconv.ParseInt(baseText, 10, 64); err == nil {
+					r.VerticalVisibilityFt = vv * 100
+					r.Present.Add(FieldVerticalVisibility)
+				}
+			}
+			r.SkyCondition.SkyCover = cover
+			r.Present.Add(FieldSkyCondition)
+
+			if base, err := strconv.Atoi(baseText); err == nil && (cover == SkyCoverBKN || cover == SkyCoverOVC || cover == SkyCoverOVX) {
+				if ceiling < 0 || base*100 < ceiling {
+					ceiling = base * 100
+				}
+			}
+
+		case TokenTemperature:
+			temp, dew, _ := strings.Cut(f, "/")
+			r.Temperature = parseTemperature(temp)
+			r.Present.Add(FieldTemperature)
+			if dew != "" {
+				r.Dewpoint = parseTemperature(dew)
+				r.Present.Add(FieldDewpoint)
+			}
+
+		case TokenPressure:
+			v, _ := strconv.ParseFloat(f[1:], 64)
+			if f[0] == 'A' {
+				r.Altimeter = v / 100
+			} else {
+				r.Altimeter = HPaToInHg(v)
+			}
+			r.Present.Add(FieldAltimeter)
+
+		case TokenWeather:
+			weather = append(weather, f)
+		}
+	}
+
+	if len(weather) > 0 {
+		r.WXString = strings.Join(weather, " ")
+		r.Present.Add(FieldWXString)
+	}
+
+	if vis != nil {
+		r.VisibilityStatute = vis.StatuteMiles()
+		r.Present.Add(FieldVisibilityStatute)
+	}
+
+	if vis != nil || r.Present.Has(FieldSkyCondition) {
+		r.FlightCategory = flightCategory(ceiling, r.VisibilityStatute, vis != nil)
+		r.Present.Add(FieldFlightCategory)
+	}
+
+	setTokenMarkers(r, all)
+	return r, warnings, nil
+}
+
+// observationTime resolves a DDHHMMZ group relative to the reference time
+func (p Parser) observationTime(group string) time.Time {
+	ref := p.Reference
+	if ref.IsZero() {
+		ref = time.Now()
+	}
+	ref = ref.UTC()
+
+	// The group was validated by the tokenizer (DDHHMMZ)
+	day, _ := strconv.Atoi(group[0:2])
+	hour, _ := strconv.Atoi(group[2:4])
+	min, _ := strconv.Atoi(group[4:6])
+
+	t := time.Date(ref.Year(), ref.Month(), day, hour, min, 0, 0, time.UTC)
+	for i := 0; i < 12 && (t.After(ref) || t.Day() != day); i++ {
+		t = time.Date(ref.Year(), ref.Month()-time.Month(i+1), day, hour, min, 0, 0, time.UTC)
+	}
+	return t
+}
+
+func (p Parser) parseRemarks(r *Result, fields []string) {
+	for _, f := range fields {
+		if m := seaLevelPresRegx.FindStringSubmatch(f); m != nil {
+			v, _ := strconv.ParseFloat(m[1], 64)
+			// SLP only contains the last three digits in tenths of hPa
+			if v < 500 {
+				v += 10000
+			} else {
+				v += 9000
+			}
+			r.SeaLevelPressure = v / 10
+			r.Present.Add(FieldSeaLevelPressure)
+		}
+	}
+}
+
+func parseWind(r *Result, m []string) {
+	factor := 1.0
+	switch m[4] {
+	case "MPS":
+		factor = 1 / KtsToMs(1)
+	case "KMH":
+		factor = KmhToKts(1)
+	}
+
+	if dir, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+		r.WindDirDegrees = dir
+		r.Present.Add(FieldWindDirDegrees)
+	} else if m[1] == "VRB" {
+		r.WindDirDegrees = 0
+		r.Present.Add(FieldWindDirDegrees)
+	}
+
+	if speed, err := strconv.ParseFloat(m[2], 64); err == nil {
+		r.WindSpeed = int64(speed*factor + 0.5)
+		r.Present.Add(FieldWindSpeed)
+	}
+
+	if gust, err := strconv.ParseFloat(m[3], 64); err == nil {
+		r.WindGust = int64(gust*factor + 0.5)
+		r.Present.Add(FieldWindGust)
+	}
+}
+
+func parseTemperature(s string) float64 {
+	neg := strings.HasPrefix(s, "M")
+	v, _ := strconv.ParseFloat(strings.TrimPrefix(s, "M"), 64)
+	if neg {
+		return -v
+	}
+	return v
+}
+
+// splitCloudGroup splits a cloud group validated by the tokenizer into
+// cover and base (BKN030CB is BKN and 030)
+func splitCloudGroup(f string) (string, string) {
+	n := 3
+	if strings.HasPrefix(f, "VV") {
+		n = 2
+	}
+	return f[:n], f[n : n+3]
+}
+
+func isVisibilityGroup(f string) bool {
+	if f == "CAVOK" || visibilityMetersRegex.MatchString(f) {
+		return true
+	}
+	if !strings.HasSuffix(f, "SM") || !visibilityMilesRegex.MatchString(f) {
+		return false
+	}
+	// Fractions like 1/0SM match the expression but fail to parse
+	_, err := ParseVisibility(f)
+	return err == nil
+}
+
+// flightCategory derives the category from ceiling (feet, -1 if none) and
+// visibility (statute miles)
+func flightCategory(ceiling int, vis float64, hasVis bool) FlightCategory {
+	switch {
+	case (ceiling >= 0 && ceiling < 500) || (hasVis && vis < 1):
+		return FlightCategoryLIFR
+	case (ceiling >= 0 && ceiling < 1000) || (hasVis && vis < 3):
+		return FlightCategoryIFR
+	case (ceiling >= 0 && ceiling <= 3000) || (hasVis && vis <= 5):
+		return FlightCategoryMVFR
+	default:
+		return FlightCategoryVFR
+	}
+}
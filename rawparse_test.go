@@ -0,0 +1,140 @@
+package metar_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Raw parser", func() {
+	var parser = Parser{Reference: time.Date(2016, 7, 20, 0, 0, 0, 0, time.UTC)}
+
+	It("should decode an international METAR", func() {
+		r, err := parser.Parse("METAR EDDH 151020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG=")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.RawText).To(Equal("METAR EDDH 151020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG"))
+		Expect(r.MetarType).To(Equal("METAR"))
+		Expect(r.StationID).To(Equal("EDDH"))
+		Expect(r.ObservationTime).To(Equal(time.Date(2016, 7, 15, 10, 20, 0, 0, time.UTC)))
+		Expect(r.WindDirDegrees).To(Equal(int64(270)))
+		Expect(r.WindSpeed).To(Equal(int64(8)))
+		Expect(r.Present.Has(FieldWindGust)).To(BeFalse())
+		Expect(r.VisibilityStatute).To(BeNumerically("~", 6.21, 0.01))
+		Expect(r.SkyCondition.SkyCover).To(Equal(SkyCoverFEW))
+		Expect(r.Temperature).To(Equal(17.0))
+		Expect(r.Dewpoint).To(Equal(9.0))
+		Expect(r.Altimeter).To(BeNumerically("~", 30.06, 0.01))
+		Expect(r.FlightCategory).To(Equal(FlightCategoryVFR))
+	})
+
+	It("should decode a US METAR with weather and remarks", func() {
+		r, err := parser.Parse("KMIA 151853Z 09015G28KT 2SM +TSRA BR SCT015 BKN025CB OVC050 24/22 A2990 RMK AO2 PK WND 10032/1840 SLP125 T02440222")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.WindGust).To(Equal(int64(28)))
+		Expect(r.VisibilityStatute).To(Equal(2.0))
+		Expect(r.WXString).To(Equal("+TSRA BR"))
+		Expect(r.SkyCondition.SkyCover).To(Equal(SkyCoverOVC))
+		Expect(r.Altimeter).To(Equal(29.9))
+		Expect(r.SeaLevelPressure).To(Equal(1012.5))
+		Expect(r.FlightCategory).To(Equal(FlightCategoryIFR))
+	})
+
+	It("should decode calm winds, fog and vertical visibility", func() {
+		r, err := parser.Parse("EGLL 020520Z 00000KT 0100 FG VV001 M01/M01 Q1025")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.ObservationTime).To(Equal(time.Date(2016, 7, 2, 5, 20, 0, 0, time.UTC)))
+		Expect(r.WindSpeed).To(Equal(int64(0)))
+		Expect(r.Present.Has(FieldWindSpeed)).To(BeTrue())
+		Expect(r.WXString).To(Equal("FG"))
+		Expect(r.SkyCondition.SkyCover).To(Equal(SkyCoverOVX))
+		Expect(r.Temperature).To(Equal(-1.0))
+		Expect(r.FlightCategory).To(Equal(FlightCategoryLIFR))
+	})
+
+	It("should convert winds reported in meters per second", func() {
+		r, err := parser.Parse("UUEE 151000Z 24005G10MPS CAVOK 25/10 Q1012")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.WindSpeed).To(Equal(int64(10)))
+		Expect(r.WindGust).To(Equal(int64(19)))
+		Expect(r.SkyCondition.SkyCover).To(Equal(SkyCoverCAVOK))
+	})
+
+	It("should resolve observation times in the previous month", func() {
+		r, err := parser.Parse("EDDH 251020Z 27008KT 9999 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.ObservationTime).To(Equal(time.Date(2016, 6, 25, 10, 20, 0, 0, time.UTC)))
+	})
+
+	It("should reject reports without station or time", func() {
+		_, err := parser.Parse("")
+		Expect(err).To(MatchError(ErrMissingStation))
+		_, err = parser.Parse("EDDH 27008KT")
+		Expect(err).To(MatchError(ErrMissingTime))
+		_, err = parser.Parse("EDDH 251020Z NIL=")
+		Expect(err).To(MatchError(ErrNilReport))
+	})
+
+	It("should locate the offending token of rejected reports", func() {
+		_, err := parser.Parse("METAR EDDH Q10A5 27008KT")
+		Expect(err).To(Equal(ParseError{Token: "Q10A5", Pos: 11, Err: ErrMissingTime}))
+		Expect(err.Error()).To(Equal(`Raw METAR does not contain an observation time: "Q10A5" at offset 11`))
+
+		_, err = parser.Parse("METAR 12345 211020Z")
+		Expect(err).To(Equal(ParseError{Token: "12345", Pos: 6, Err: ErrMissingStation}))
+
+		_, err = parser.Parse("METAR EDDH")
+		Expect(err).To(Equal(ParseError{Pos: 10, Err: ErrMissingTime}))
+		Expect(err.Error()).To(Equal("Raw METAR does not contain an observation time at offset 10"))
+
+		_, err = parser.Parse("EDDH 251020Z NIL=")
+		Expect(err).To(Equal(ParseError{Token: "NIL", Pos: 13, Err: ErrNilReport}))
+	})
+
+	It("should report ignored groups as warnings", func() {
+		r, warnings, err := parser.ParseWithWarnings("EDDH 211020Z 27008KT 9999 8000 XYZ123 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.VisibilityStatute).To(BeNumerically("~", 6.21, 0.01))
+		Expect(warnings).To(Equal([]ParseWarning{
+			{Group: "8000", Offset: 26, Message: "Additional visibility group ignored"},
+			{Group: "XYZ123", Offset: 31, Message: "Unrecognized group"},
+		}))
+	})
+
+	It("should fail on ignored groups in strict mode", func() {
+		strict := parser
+		strict.Strict = true
+
+		_, err := strict.Parse("EDDH 211020Z 27008KT 9999 XYZ123 FEW030 17/09 Q1018")
+		Expect(err).To(Equal(ErrUnknownGroup{Token: "XYZ123", Pos: 26}))
+		Expect(err.Error()).To(Equal(`Unrecognized group: "XYZ123" at offset 26`))
+
+		_, err = strict.Parse("EDDH 211020Z 27008KT 9999 8000 FEW030 17/09 Q1018")
+		Expect(err).To(Equal(ParseWarning{Group: "8000", Offset: 26, Message: "Additional visibility group ignored"}))
+
+		_, err = strict.Parse("EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+})
+
+var benchReports = []string{
+	"EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG",
+	"EDDW 211020Z 26010KT CAVOK 18/08 Q1017",
+	"KBOS 211054Z 19012G22KT 10SM -RA BKN040 OVC080 12/10 A2999 RMK AO2 SLP155 T01220100",
+	"LFPG 211030Z 24015KT 200V280 4000 R27L/1200U BR SCT008 BKN012 14/13 Q1012 TEMPO 2000",
+	"CYYZ 211100Z AUTO 31020G35KT 1 1/2SM -SHSN BLSN VV008 M05/M07 A2975 RMK SN4 SLP087",
+}
+
+func BenchmarkParse(b *testing.B) {
+	p := Parser{Reference: time.Date(2016, 5, 21, 12, 0, 0, 0, time.UTC)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := p.Parse(benchReports[i%len(benchReports)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
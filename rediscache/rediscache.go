@@ -0,0 +1,145 @@
+// Package rediscache provides a metar.Cache backed by Redis to share the
+// last known results between multiple instances of a service.
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	client := metar.NewClient(metar.WithCache(rediscache.New(rdb, rediscache.WithNamespace("metar:adds")), time.Hour))
+package rediscache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	metar "github.com/Luzifer/go-metar"
+)
+
+// Defaults of the stampede protection
+const (
+	DefaultLockTTL  = 10 * time.Second
+	DefaultLockWait = 2 * time.Second
+)
+
+// lockPollInterval is the interval to check for a value filled by the
+// instance holding the lock
+const lockPollInterval = 50 * time.Millisecond
+
+var _ metar.Cache = (*Cache)(nil)
+
+// Cache implements metar.Cache using Redis. It protects the upstream API
+// against stampedes: when a key is missing only the first instance
+// receives a miss and fetches the result, other instances wait up to the
+// lock wait time for the value to be set before fetching themselves.
+type Cache struct {
+	rdb       redis.Cmdable
+	namespace string
+	lockTTL   time.Duration
+	lockWait  time.Duration
+	token     string // Identifies the locks held by this instance
+}
+
+// Option configures a Cache
+type Option func(*Cache)
+
+// WithNamespace prefixes all keys with the namespace (default "go-metar")
+// to separate multiple clients or sources using the same Redis
+func WithNamespace(namespace string) Option {
+	return func(c *Cache) { c.namespace = namespace }
+}
+
+// WithStampedeProtection sets how long the instance fetching a missing
+// key holds its lock and how long other instances wait for the value.
+// A lockWait of zero disables the protection.
+func WithStampedeProtection(lockTTL, lockWait time.Duration) Option {
+	return func(c *Cache) {
+		c.lockTTL = lockTTL
+		c.lockWait = lockWait
+	}
+}
+
+// New creates a Cache using the Redis client (redis.Client,
+// redis.ClusterClient or redis.Ring)
+func New(rdb redis.Cmdable, opts ...Option) *Cache {
+	token := make([]byte, 16)
+	rand.Read(token)
+
+	c := &Cache{
+		token:     hex.EncodeToString(token),
+		rdb:       rdb,
+		namespace: "go-metar",
+		lockTTL:   DefaultLockTTL,
+		lockWait:  DefaultLockWait,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Get implements metar.Cache
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, ok, err := c.get(ctx, key)
+	if err != nil || ok || c.lockWait <= 0 {
+		return value, ok, err
+	}
+
+	lockKey := c.key(key) + ":lock"
+	locked, err := c.rdb.SetNX(ctx, lockKey, c.token, c.lockTTL).Result()
+	if err != nil || locked {
+		return nil, false, err
+	}
+
+	// Locks of this instance do not block it, the Client reads the key
+	// again before storing the result it fetched
+	if owner, err := c.rdb.Get(ctx, lockKey).Bytes(); err == nil && string(owner) == c.token {
+		return nil, false, nil
+	}
+
+	// Another instance is fetching the value
+	deadline := time.NewTimer(c.lockWait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-deadline.C:
+			return nil, false, nil
+		case <-ticker.C:
+			if value, ok, err := c.get(ctx, key); err != nil || ok {
+				return value, ok, err
+			}
+		}
+	}
+}
+
+// Set implements metar.Cache and releases the lock of the key
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.rdb.Set(ctx, c.key(key), value, ttl).Err(); err != nil {
+		return err
+	}
+	return c.rdb.Del(ctx, c.key(key)+":lock").Err()
+}
+
+func (c *Cache) get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.rdb.Get(ctx, c.key(key)).Bytes()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *Cache) key(key string) string {
+	if c.namespace == "" {
+		return key
+	}
+	return c.namespace + ":" + key
+}
@@ -0,0 +1,13 @@
+package rediscache_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestRediscache(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rediscache Suite")
+}
@@ -0,0 +1,141 @@
+package rediscache_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	. "github.com/Luzifer/go-metar/rediscache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		ctx = context.Background()
+		mr  *miniredis.Miniredis
+		rdb *redis.Client
+	)
+
+	BeforeEach(func() {
+		var err error
+		mr, err = miniredis.Run()
+		Expect(err).NotTo(HaveOccurred())
+		rdb = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	})
+
+	AfterEach(func() {
+		rdb.Close()
+		mr.Close()
+	})
+
+	It("should store values within the namespace", func() {
+		c := New(rdb, WithNamespace("metar:adds"))
+
+		_, ok, err := c.Get(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		Expect(c.Set(ctx, "EDDH", []byte("result"), time.Hour)).To(Succeed())
+		Expect(mr.Exists("metar:adds:EDDH")).To(BeTrue())
+		Expect(mr.Exists("metar:adds:EDDH:lock")).To(BeFalse())
+
+		v, ok, err := c.Get(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal([]byte("result")))
+	})
+
+	It("should not block the instance holding the lock", func() {
+		c := New(rdb, WithStampedeProtection(time.Minute, 5*time.Second))
+
+		_, ok, _ := c.Get(ctx, "EDDH")
+		Expect(ok).To(BeFalse())
+
+		start := time.Now()
+		_, ok, err := c.Get(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+
+	It("should make other instances wait for the value of the lock holder", func() {
+		holder := New(rdb, WithStampedeProtection(time.Minute, 5*time.Second))
+		waiter := New(rdb, WithStampedeProtection(time.Minute, 5*time.Second))
+
+		_, ok, _ := holder.Get(ctx, "EDDH")
+		Expect(ok).To(BeFalse())
+		Expect(mr.Exists("go-metar:EDDH:lock")).To(BeTrue())
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(200 * time.Millisecond)
+			Expect(holder.Set(ctx, "EDDH", []byte("result"), time.Hour)).To(Succeed())
+		}()
+
+		start := time.Now()
+		v, ok, err := waiter.Get(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal([]byte("result")))
+		Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	It("should give up waiting after the lock wait time", func() {
+		holder := New(rdb, WithStampedeProtection(time.Minute, 300*time.Millisecond))
+		waiter := New(rdb, WithStampedeProtection(time.Minute, 300*time.Millisecond))
+
+		_, ok, _ := holder.Get(ctx, "EDDH")
+		Expect(ok).To(BeFalse())
+
+		start := time.Now()
+		_, ok, err := waiter.Get(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(time.Since(start)).To(BeNumerically(">=", 300*time.Millisecond))
+	})
+
+	It("should stop waiting when the context is cancelled", func() {
+		holder := New(rdb)
+		waiter := New(rdb)
+
+		_, ok, _ := holder.Get(ctx, "EDDH")
+		Expect(ok).To(BeFalse())
+
+		cctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		_, _, err := waiter.Get(cctx, "EDDH")
+		Expect(err).To(Equal(context.DeadlineExceeded))
+	})
+
+	It("should let another instance take over an expired lock", func() {
+		holder := New(rdb, WithStampedeProtection(time.Second, 5*time.Second))
+		waiter := New(rdb, WithStampedeProtection(time.Second, 5*time.Second))
+
+		_, ok, _ := holder.Get(ctx, "EDDH")
+		Expect(ok).To(BeFalse())
+		mr.FastForward(2 * time.Second)
+		Expect(mr.Exists("go-metar:EDDH:lock")).To(BeFalse())
+
+		start := time.Now()
+		_, ok, err := waiter.Get(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(mr.Exists("go-metar:EDDH:lock")).To(BeTrue())
+	})
+
+	It("should not lock without stampede protection", func() {
+		c := New(rdb, WithStampedeProtection(time.Second, 0))
+
+		_, ok, err := c.Get(ctx, "EDDH")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(mr.Exists("go-metar:EDDH:lock")).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,136 @@
+package metar
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	hourlyPrecipRegex = regexp.MustCompile(`^P(\d{4})$`)
+	snowDepthRegex    = regexp.MustCompile(`^4/(\d{3})$`)
+	tGroupRegex       = regexp.MustCompile(`^T([01])(\d{3})(?:([01])(\d{3}))?$`)
+)
+
+// Remarks returns the groups following RMK in the raw report
+func (r Result) Remarks() []string {
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(r.RawText), "="))
+	for i, f := range fields {
+		if f == "RMK" {
+			return fields[i+1:]
+		}
+	}
+	return nil
+}
+
+// HourlyPrecipitation returns the precipitation amount in inches reported
+// in the Pnnnn remark group of North American reports
+func (r Result) HourlyPrecipitation() (float64, bool) {
+	for _, g := range r.Remarks() {
+		if m := hourlyPrecipRegex.FindStringSubmatch(g); m != nil {
+			v, _ := strconv.Atoi(m[1])
+			return float64(v) / 100, true
+		}
+	}
+	return 0, false
+}
+
+// SnowDepth returns the snow depth on the ground in inches reported in the
+// 4/sss remark group of North American reports
+func (r Result) SnowDepth() (float64, bool) {
+	for _, g := range r.Remarks() {
+		if m := snowDepthRegex.FindStringSubmatch(g); m != nil {
+			v, _ := strconv.Atoi(m[1])
+			return float64(v), true
+		}
+	}
+	return 0, false
+}
+
+// PreciseTemperature returns the temperature with a precision of 0.1 °C
+// reported in the T-group remark (T01830122) of North American reports
+func (r Result) PreciseTemperature() (float64, bool) {
+	m := r.tGroup()
+	if m == nil {
+		return 0, false
+	}
+	return tGroupValue(m[1], m[2]), true
+}
+
+// PreciseDewpoint returns the dewpoint with a precision of 0.1 °C reported
+// in the T-group remark
+func (r Result) PreciseDewpoint() (float64, bool) {
+	m := r.tGroup()
+	if m == nil || m[3] == "" {
+		return 0, false
+	}
+	return tGroupValue(m[3], m[4]), true
+}
+
+// BodyTemperature returns the whole degree temperature of the report body
+// (17/09). Temperature holds the precise value if a T-group was reported.
+func (r Result) BodyTemperature() (float64, bool) {
+	temp, _, ok := r.bodyTemperatures()
+	return temp, ok
+}
+
+// BodyDewpoint returns the whole degree dewpoint of the report body
+func (r Result) BodyDewpoint() (float64, bool) {
+	_, dew, ok := r.bodyTemperatures()
+	if !ok || dew == "" {
+		return 0, false
+	}
+	return parseTemperature(dew), true
+}
+
+func (r Result) bodyTemperatures() (float64, string, bool) {
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind == TokenTemperature {
+			temp, dew, _ := strings.Cut(t.Text, "/")
+			return parseTemperature(temp), dew, true
+		}
+	}
+	return 0, "", false
+}
+
+func (r Result) tGroup() []string {
+	for _, g := range r.Remarks() {
+		if m := tGroupRegex.FindStringSubmatch(g); m != nil {
+			return m
+		}
+	}
+	return nil
+}
+
+// tGroupValue decodes sign (1 is negative) and tenths of a T-group value
+func tGroupValue(sign, tenths string) float64 {
+	v, _ := strconv.Atoi(tenths)
+	if sign == "1" {
+		v = -v
+	}
+	return float64(v) / 10
+}
+
+// setRemarkValues fills the precipitation and snow depth from the remarks
+// if the source did not report them as dedicated values. Temperature and
+// dewpoint are replaced by the more precise values of the T-group.
+func setRemarkValues(r *Result) {
+	if v, ok := r.PreciseTemperature(); ok {
+		r.Temperature = v
+		r.Present.Add(FieldTemperature)
+	}
+	if v, ok := r.PreciseDewpoint(); ok {
+		r.Dewpoint = v
+		r.Present.Add(FieldDewpoint)
+	}
+
+	if v, ok := r.HourlyPrecipitation(); ok && !r.Present.Has(FieldPrecipitation) {
+		r.PrecipitationIn = v
+		r.Present.Add(FieldPrecipitation)
+	}
+
+	if v, ok := r.SnowDepth(); ok && !r.Present.Has(FieldSnowDepth) {
+		r.SnowDepthIn = v
+		r.Present.Add(FieldSnowDepth)
+	}
+}
@@ -0,0 +1,78 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Remarks", func() {
+
+	It("should return the groups following RMK", func() {
+		r := Result{RawText: "KBOS 210754Z 19012KT 10SM BKN040 12/10 A2999 RMK AO2 SLP155 P0004="}
+		Expect(r.Remarks()).To(Equal([]string{"AO2", "SLP155", "P0004"}))
+
+		p, ok := r.HourlyPrecipitation()
+		Expect(ok).To(BeTrue())
+		Expect(p).To(Equal(0.04))
+	})
+
+	It("should handle reports without remarks", func() {
+		r := Result{RawText: "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG"}
+		Expect(r.Remarks()).To(BeEmpty())
+
+		_, ok := r.HourlyPrecipitation()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should fill precipitation and snow depth from the remarks", func() {
+		r, err := Parser{}.Parse("KBTV 211754Z 36008KT 1SM -SN BR OVC008 M03/M04 A3002 RMK AO2 SLP180 P0012 4/014")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Present.Has(FieldPrecipitation)).To(BeTrue())
+		Expect(r.PrecipitationIn).To(Equal(0.12))
+		Expect(r.Present.Has(FieldSnowDepth)).To(BeTrue())
+		Expect(r.SnowDepthIn).To(Equal(14.0))
+	})
+
+	It("should prefer the precise temperature of the T-group", func() {
+		r, err := Parser{}.Parse("KBOS 210754Z 19012KT 10SM BKN040 18/12 A2999 RMK AO2 SLP155 T01830122")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.Temperature).To(Equal(18.3))
+		Expect(r.Dewpoint).To(Equal(12.2))
+
+		t, ok := r.BodyTemperature()
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(18.0))
+		d, ok := r.BodyDewpoint()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(Equal(12.0))
+	})
+
+	It("should decode negative T-group values", func() {
+		r := Result{RawText: "KBTV 211754Z 36008KT 1SM -SN OVC008 M03/M04 A3002 RMK AO2 T10281039"}
+
+		t, ok := r.PreciseTemperature()
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(-2.8))
+		d, ok := r.PreciseDewpoint()
+		Expect(ok).To(BeTrue())
+		Expect(d).To(Equal(-3.9))
+
+		t, ok = r.BodyTemperature()
+		Expect(ok).To(BeTrue())
+		Expect(t).To(Equal(-3.0))
+	})
+
+	It("should handle a T-group without dewpoint", func() {
+		r := Result{RawText: "KBOS 210754Z 19012KT 10SM BKN040 18/ A2999 RMK AO2 T0183"}
+
+		_, ok := r.PreciseTemperature()
+		Expect(ok).To(BeTrue())
+		_, ok = r.PreciseDewpoint()
+		Expect(ok).To(BeFalse())
+	})
+
+})
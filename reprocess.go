@@ -0,0 +1,201 @@
+package metar
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+)
+
+// Formats detected by Reprocess
+const (
+	FormatXML     = "xml"     // Dataserver XML (ADDS)
+	FormatCSV     = "csv"     // Comma separated ASOS archive (IEM)
+	FormatOgimet  = "ogimet"  // Ogimet "ICAO,YYYY,MM,DD,HH,mm,METAR ..." lines
+	FormatRaw     = "raw"     // Raw reports as in NOAA station and cycle files
+	FormatCheckWX = "checkwx" // Decoded METAR JSON response of CheckWX
+	FormatAVWX    = "avwx"    // METAR JSON report of AVWX, a single report or an array of reports
+
+	// formatJSON is detected for JSON payloads which are told apart while
+	// decoding them
+	formatJSON = "json"
+)
+
+var ogimetLineRegex = regexp.MustCompile(`^[A-Z0-9]{4},\d{4},\d{2},`)
+
+// Reprocess decodes a previously archived upstream payload (see
+// WithRawPayload) using the current data model. The format is detected
+// from the content, gzip compressed payloads are decompressed. Raw reports
+// failing to parse are skipped. JSON payloads of other sources than CheckWX
+// and AVWX are rejected with an error.
+func Reprocess(r io.Reader) ([]Result, error) {
+	results, _, err := ReprocessFormat(r)
+	return results, err
+}
+
+// ReprocessFormat decodes the payload like Reprocess and additionally
+// returns the detected format
+func ReprocessFormat(r io.Reader) ([]Result, string, error) {
+	br := bufio.NewReader(r)
+
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, "", err
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	format, err := detectFormat(br)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var results []Result
+	switch format {
+	case FormatXML:
+		results, err = DecodeXML(br)
+	case FormatCSV:
+		results, err = decodeIEM(br)
+	case FormatOgimet:
+		results, err = decodeOgimet(bufio.NewScanner(br))
+	case formatJSON:
+		results, format, err = decodeJSONPayload(br)
+	default:
+		results, err = parseRawReports(context.Background(), br)
+	}
+	if err != nil {
+		return nil, format, err
+	}
+	return results, format, nil
+}
+
+// detectFormat determines the format from the first line of the payload
+func detectFormat(br *bufio.Reader) (string, error) {
+	// Skip a byte order mark and leading whitespace
+	for {
+		b, err := br.Peek(1)
+		if err == io.EOF {
+			return FormatRaw, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if bom, _ := br.Peek(3); bytes.Equal(bom, []byte{0xef, 0xbb, 0xbf}) {
+			br.Discard(3)
+			continue
+		}
+		if b[0] != ' ' && b[0] != '\t' && b[0] != '\r' && b[0] != '\n' {
+			break
+		}
+		br.Discard(1)
+	}
+
+	// Shorter payloads return what is available
+	head, _ := br.Peek(256)
+	if i := bytes.IndexByte(head, '\n'); i >= 0 {
+		head = head[:i]
+	}
+
+	switch {
+	case head[0] == '<':
+		return FormatXML, nil
+	case head[0] == '{' || head[0] == '[':
+		return formatJSON, nil
+	case bytes.HasPrefix(head, []byte("station,")):
+		return FormatCSV, nil
+	case ogimetLineRegex.Match(head):
+		return FormatOgimet, nil
+	default:
+		return FormatRaw, nil
+	}
+}
+
+// decodeJSONPayload decodes a CheckWX response or one or more AVWX reports
+func decodeJSONPayload(r io.Reader) ([]Result, string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var reports []avwxReport
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &reports); err != nil {
+			return nil, "", err
+		}
+	} else {
+		var probe struct {
+			Data json.RawMessage `json:"data"`
+			Raw  *string         `json:"raw"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return nil, "", err
+		}
+
+		switch {
+		case probe.Data != nil:
+			cr := checkWXResponse{}
+			if err := json.Unmarshal(data, &cr); err != nil {
+				return nil, FormatCheckWX, err
+			}
+			results := make([]Result, 0, len(cr.Data))
+			for _, d := range cr.Data {
+				result, err := d.result()
+				if err != nil {
+					return nil, FormatCheckWX, err
+				}
+				results = append(results, result)
+			}
+			return results, FormatCheckWX, nil
+
+		case probe.Raw != nil:
+			reports = make([]avwxReport, 1)
+			if err := json.Unmarshal(data, &reports[0]); err != nil {
+				return nil, FormatAVWX, err
+			}
+
+		default:
+			return nil, "", errors.New("Unsupported JSON payload")
+		}
+	}
+
+	results := make([]Result, 0, len(reports))
+	for _, rep := range reports {
+		if rep.Raw == "" {
+			return nil, "", errors.New("Unsupported JSON payload")
+		}
+		results = append(results, rep.result())
+	}
+	return results, FormatAVWX, nil
+}
+
+// parseRawReports parses raw reports using ParseBulk, skipping those
+// failing to parse, sorted by station and observation time
+func parseRawReports(ctx context.Context, r io.Reader) ([]Result, error) {
+	var results []Result
+	for pr := range (Parser{}).ParseBulk(ctx, r, 0) {
+		switch {
+		case pr.Err != nil && pr.Raw == "":
+			return nil, pr.Err
+		case pr.Err == nil:
+			results = append(results, *pr.Result)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].StationID != results[j].StationID {
+			return results[i].StationID < results[j].StationID
+		}
+		return results[i].ObservationTime.Before(results[j].ObservationTime)
+	})
+	return results, nil
+}
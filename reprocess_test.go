@@ -0,0 +1,106 @@
+package metar_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reprocess", func() {
+
+	reprocess := func(file string) ([]Result, string) {
+		f, err := os.Open(filepath.Join("testdata", file))
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+
+		results, format, err := ReprocessFormat(f)
+		Expect(err).NotTo(HaveOccurred())
+		return results, format
+	}
+
+	It("should detect dataserver XML", func() {
+		results, format := reprocess("multi.xml")
+		Expect(format).To(Equal(FormatXML))
+		Expect(results).NotTo(BeEmpty())
+	})
+
+	It("should detect IEM CSV", func() {
+		results, format := reprocess("iem.csv")
+		Expect(format).To(Equal(FormatCSV))
+		Expect(results).NotTo(BeEmpty())
+		Expect(results[0].StationID).To(Equal("EDDH"))
+	})
+
+	It("should detect Ogimet lines", func() {
+		results, format := reprocess("ogimet.txt")
+		Expect(format).To(Equal(FormatOgimet))
+		Expect(results[0].RawText).To(HavePrefix("METAR EDDH 200620Z"))
+	})
+
+	It("should parse raw reports", func() {
+		results, format := reprocess("cycle.txt")
+		Expect(format).To(Equal(FormatRaw))
+		Expect(results[0].StationID).To(Equal("EDDH"))
+		Expect(results[0].ObservationTime.Year()).To(Equal(2016))
+
+		results, err := Reprocess(strings.NewReader("\ufeff\n  EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+	})
+
+	It("should decode CheckWX and AVWX payloads", func() {
+		results, format := reprocess("checkwx.json")
+		Expect(format).To(Equal(FormatCheckWX))
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].StationID).To(Equal("EDDH"))
+
+		results, format = reprocess("avwx.json")
+		Expect(format).To(Equal(FormatAVWX))
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].StationID).To(Equal("EDDH"))
+
+		data, err := os.ReadFile(filepath.Join("testdata", "avwx.json"))
+		Expect(err).NotTo(HaveOccurred())
+		results, format, err = ReprocessFormat(strings.NewReader("[" + string(data) + "," + string(data) + "]"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(format).To(Equal(FormatAVWX))
+		Expect(results).To(HaveLen(2))
+	})
+
+	It("should reject unsupported JSON payloads", func() {
+		_, err := Reprocess(strings.NewReader(`{"features": []}`))
+		Expect(err).To(MatchError("Unsupported JSON payload"))
+
+		_, err = Reprocess(strings.NewReader(`[{"id": 1}]`))
+		Expect(err).To(MatchError("Unsupported JSON payload"))
+	})
+
+	It("should decompress gzip payloads", func() {
+		data, err := os.ReadFile(filepath.Join("testdata", "multi.xml"))
+		Expect(err).NotTo(HaveOccurred())
+
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write(data)
+		gz.Close()
+
+		results, format, err := ReprocessFormat(&buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(format).To(Equal(FormatXML))
+		Expect(results).NotTo(BeEmpty())
+	})
+
+	It("should handle empty payloads", func() {
+		results, err := Reprocess(strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(BeEmpty())
+	})
+
+})
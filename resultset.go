@@ -0,0 +1,70 @@
+package metar
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ResultSet is a list of results with chainable filters and sorts. All
+// methods return a new ResultSet and leave the receiver unchanged.
+type ResultSet []Result
+
+// Filter returns the results for which keep returns true
+func (s ResultSet) Filter(keep func(Result) bool) ResultSet {
+	out := make(ResultSet, 0, len(s))
+	for _, r := range s {
+		if keep(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// ByFlightCategory returns the results having one of the categories
+func (s ResultSet) ByFlightCategory(categories ...FlightCategory) ResultSet {
+	return s.Filter(func(r Result) bool {
+		for _, c := range categories {
+			if r.FlightCategory == c {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ByMaxAge returns the results observed at most maxAge ago
+func (s ResultSet) ByMaxAge(maxAge time.Duration) ResultSet {
+	return s.Filter(func(r Result) bool { return !r.IsStale(maxAge) })
+}
+
+// WithinRadius returns the results of stations within radiusKm around the
+// position. Results without station position are dropped.
+func (s ResultSet) WithinRadius(lat, lon, radiusKm float64) ResultSet {
+	return s.Filter(func(r Result) bool {
+		d, ok := r.DistanceTo(lat, lon)
+		return ok && d <= radiusKm
+	})
+}
+
+// ByDistance sorts the results nearest to the position first. Results
+// without station position are moved to the end.
+func (s ResultSet) ByDistance(lat, lon float64) ResultSet {
+	out := append(ResultSet(nil), s...)
+	dist := func(r Result) float64 {
+		if d, ok := r.DistanceTo(lat, lon); ok {
+			return d
+		}
+		return math.Inf(1)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return dist(out[i]) < dist(out[j]) })
+	return out
+}
+
+// ByObservationTime sorts the results newest first
+func (s ResultSet) ByObservationTime() ResultSet {
+	out := append(ResultSet(nil), s...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ObservationTime.After(out[j].ObservationTime) })
+	return out
+}
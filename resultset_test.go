@@ -0,0 +1,73 @@
+package metar_test
+
+import (
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResultSet", func() {
+
+	var set ResultSet
+
+	station := func(id string, category FlightCategory, age time.Duration, lat, lon float64) Result {
+		r := Result{
+			StationID:       id,
+			FlightCategory:  category,
+			ObservationTime: time.Now().Add(-age),
+			Latitude:        lat,
+			Longitude:       lon,
+		}
+		r.Present.Add(FieldLatitude)
+		r.Present.Add(FieldLongitude)
+		return r
+	}
+
+	ids := func(s ResultSet) []string {
+		var out []string
+		for _, r := range s {
+			out = append(out, r.StationID)
+		}
+		return out
+	}
+
+	BeforeEach(func() {
+		set = ResultSet{
+			station("AAAA", FlightCategoryVFR, 10*time.Minute, 0, 2),
+			station("BBBB", FlightCategoryIFR, 50*time.Minute, 0, 0.5),
+			station("CCCC", FlightCategoryLIFR, 3*time.Hour, 0, 1),
+			{StationID: "DDDD", FlightCategory: FlightCategoryIFR, ObservationTime: time.Now()},
+		}
+	})
+
+	It("should filter by flight category", func() {
+		Expect(ids(set.ByFlightCategory(FlightCategoryIFR, FlightCategoryLIFR))).To(Equal([]string{"BBBB", "CCCC", "DDDD"}))
+		Expect(set.ByFlightCategory()).To(BeEmpty())
+	})
+
+	It("should filter by age", func() {
+		Expect(ids(set.ByMaxAge(time.Hour))).To(Equal([]string{"AAAA", "BBBB", "DDDD"}))
+	})
+
+	It("should filter by radius", func() {
+		Expect(ids(set.WithinRadius(0, 0, 120))).To(Equal([]string{"BBBB", "CCCC"}))
+	})
+
+	It("should sort by distance", func() {
+		Expect(ids(set.ByDistance(0, 0))).To(Equal([]string{"BBBB", "CCCC", "AAAA", "DDDD"}))
+	})
+
+	It("should sort by observation time", func() {
+		Expect(ids(set.ByObservationTime())).To(Equal([]string{"DDDD", "AAAA", "BBBB", "CCCC"}))
+	})
+
+	It("should chain without modifying the set", func() {
+		out := set.ByMaxAge(time.Hour).ByFlightCategory(FlightCategoryIFR).ByDistance(0, 0)
+		Expect(ids(out)).To(Equal([]string{"BBBB", "DDDD"}))
+		Expect(ids(set)).To(Equal([]string{"AAAA", "BBBB", "CCCC", "DDDD"}))
+	})
+
+})
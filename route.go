@@ -0,0 +1,63 @@
+package metar
+
+import (
+	"context"
+	"strings"
+)
+
+// RouteWeather is the weather along a route of stations
+type RouteWeather struct {
+	Stations []RouteStation // Stations in the order of the route
+
+	// Worst flight category reported along the route and the first station
+	// reporting it, empty if no station reported a category
+	WorstCategory FlightCategory
+	WorstStation  string
+}
+
+// RouteStation is the weather at one station of a route
+type RouteStation struct {
+	Station string
+	Result  *Result // Last result of the station, nil if it did not report
+}
+
+// Missing returns the stations of the route without a report
+func (w RouteWeather) Missing() []string {
+	var out []string
+	for _, s := range w.Stations {
+		if s.Result == nil {
+			out = append(out, s.Station)
+		}
+	}
+	return out
+}
+
+// FetchRouteWeather fetches the last result of every station of a route
+// (departure, en-route stations, destination and alternates) using a
+// single upstream request and determines the worst flight category along
+// the route. Stations may occur more than once. TAFs are not provided by
+// the supported sources and therefore not part of the route weather.
+func (c *Client) FetchRouteWeather(ctx context.Context, stations []string) (*RouteWeather, error) {
+	results, err := c.FetchStationsWeather(ctx, stations)
+	if err != nil {
+		return nil, err
+	}
+
+	byStation := map[string]*Result{}
+	for i := range results {
+		byStation[strings.ToUpper(results[i].StationID)] = &results[i]
+	}
+
+	w := &RouteWeather{}
+	for _, station := range stations {
+		r := byStation[c.stationKey(station)]
+		w.Stations = append(w.Stations, RouteStation{Station: station, Result: r})
+
+		if r != nil && r.FlightCategory.WorseThan(w.WorstCategory) {
+			w.WorstCategory = r.FlightCategory
+			w.WorstStation = r.StationID
+		}
+	}
+
+	return w, nil
+}
@@ -0,0 +1,57 @@
+package metar_test
+
+import (
+	"context"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Route weather", func() {
+
+	var client *Client
+
+	BeforeEach(func() {
+		client = NewClient(WithSource(&staticSource{name: "static", results: []Result{
+			{StationID: "EDDH", FlightCategory: FlightCategoryVFR},
+			{StationID: "EDDV", FlightCategory: FlightCategoryIFR},
+			{StationID: "EDDW", FlightCategory: FlightCategoryMVFR},
+			{StationID: "EDDK", FlightCategory: FlightCategoryIFR},
+		}}))
+	})
+
+	It("should report the stations in route order", func() {
+		w, err := client.FetchRouteWeather(context.Background(), []string{"EDDH", "EDDW", "EDDF", "EDDH"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(w.Stations).To(HaveLen(4))
+		Expect(w.Stations[1].Result.StationID).To(Equal("EDDW"))
+		Expect(w.Stations[2].Result).To(BeNil())
+		Expect(w.Stations[3].Result.StationID).To(Equal("EDDH"))
+		Expect(w.Missing()).To(Equal([]string{"EDDF"}))
+	})
+
+	It("should resolve station aliases", func() {
+		client = NewClient(WithStationAliases(nil), WithSource(&staticSource{name: "static", results: []Result{
+			{StationID: "EDDH", FlightCategory: FlightCategoryVFR},
+			{StationID: "EDDW", FlightCategory: FlightCategoryMVFR},
+		}}))
+
+		w, err := client.FetchRouteWeather(context.Background(), []string{"HAM", "bre"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Missing()).To(BeEmpty())
+		Expect(w.Stations[0].Station).To(Equal("HAM"))
+		Expect(w.Stations[0].Result.StationID).To(Equal("EDDH"))
+		Expect(w.Stations[1].Result.StationID).To(Equal("EDDW"))
+	})
+
+	It("should determine the worst flight category", func() {
+		w, err := client.FetchRouteWeather(context.Background(), []string{"EDDH", "EDDW", "EDDV", "EDDK"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.WorstCategory).To(Equal(FlightCategoryIFR))
+		Expect(w.WorstStation).To(Equal("EDDV"))
+	})
+
+})
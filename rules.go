@@ -0,0 +1,145 @@
+package metar
+
+import "strings"
+
+// Condition is a predicate evaluated against new observations
+type Condition func(Result) bool
+
+// Rule is a named Condition evaluated by a Watcher
+type Rule struct {
+	Name      string
+	Stations  []string // Stations the rule applies to, all watched stations when empty
+	Condition Condition
+}
+
+// RuleEvent is emitted when a Rule starts or stops matching the
+// observations of a station
+type RuleEvent struct {
+	Rule    string
+	Station string
+	Tripped bool   // true when the condition started to hold, false when it cleared
+	Result  Result // Observation causing the change
+}
+
+// RuleHandler is called for every RuleEvent
+type RuleHandler func(RuleEvent)
+
+type ruleKey struct {
+	rule, station string
+}
+
+// AddRule registers a rule to be evaluated against new observations
+func (w *Watcher) AddRule(r Rule) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.rules = append(w.rules, r)
+}
+
+// OnRuleEvent registers a handler for rules being tripped or cleared
+func (w *Watcher) OnRuleEvent(h RuleHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.ruleHandlers = append(w.ruleHandlers, h)
+}
+
+// RuleEvents returns a channel receiving all rule events. Events are
+// dropped when the channel buffer is full.
+func (w *Watcher) RuleEvents(buffer int) <-chan RuleEvent {
+	ch := make(chan RuleEvent, buffer)
+	w.OnRuleEvent(func(ev RuleEvent) {
+		select {
+		case ch <- ev:
+		default:
+		}
+	})
+	return ch
+}
+
+// evaluateRules returns the events caused by the observation, the caller
+// must hold the lock
+func (w *Watcher) evaluateRules(station string, r Result) []RuleEvent {
+	var events []RuleEvent
+	for _, rule := range w.rules {
+		if !rule.appliesTo(station) {
+			continue
+		}
+
+		key := ruleKey{rule.Name, station}
+		matches := rule.Condition(r)
+		if matches == w.ruleState[key] {
+			continue
+		}
+
+		w.ruleState[key] = matches
+		events = append(events, RuleEvent{Rule: rule.Name, Station: station, Tripped: matches, Result: r})
+	}
+	return events
+}
+
+func (r Rule) appliesTo(station string) bool {
+	if len(r.Stations) == 0 {
+		return true
+	}
+	for _, s := range r.Stations {
+		if strings.EqualFold(s, station) {
+			return true
+		}
+	}
+	return false
+}
+
+// WindSpeedAbove matches observations with a wind speed above kt knots
+func WindSpeedAbove(kt int64) Condition {
+	return func(r Result) bool { return r.WindSpeed > kt }
+}
+
+// WindGustAbove matches observations with gusts above kt knots
+func WindGustAbove(kt int64) Condition {
+	return func(r Result) bool { return r.WindGust > kt }
+}
+
+// TemperatureBelow matches observations reporting a temperature below c degrees celsius
+func TemperatureBelow(c float64) Condition {
+	return func(r Result) bool { return r.Present.Has(FieldTemperature) && r.Temperature < c }
+}
+
+// TemperatureAbove matches observations reporting a temperature above c degrees celsius
+func TemperatureAbove(c float64) Condition {
+	return func(r Result) bool { return r.Present.Has(FieldTemperature) && r.Temperature > c }
+}
+
+// FlightCategoryWorseThan matches observations with a flight category worse than cat
+func FlightCategoryWorseThan(cat FlightCategory) Condition {
+	return func(r Result) bool { return r.FlightCategory.WorseThan(cat) }
+}
+
+// WeatherContains matches observations whose weather string contains the given phenomenon (e.g. "TS")
+func WeatherContains(phenomenon string) Condition {
+	return func(r Result) bool { return strings.Contains(r.WXString, phenomenon) }
+}
+
+// All matches when all conditions match
+func All(conds ...Condition) Condition {
+	return func(r Result) bool {
+		for _, c := range conds {
+			if !c(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any matches when at least one of the conditions matches
+func Any(conds ...Condition) Condition {
+	return func(r Result) bool {
+		for _, c := range conds {
+			if c(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+package metar_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+func withTemperature(t float64) Result {
+	r := Result{Temperature: t}
+	r.Present.Add(FieldTemperature)
+	return r
+}
+
+var _ = Describe("Rules", func() {
+
+	It("should emit events when a rule trips and clears", func() {
+		src := &staticSource{name: "static", results: []Result{{StationID: "EDDH", WindGust: 20}}}
+		watcher := NewWatcher(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour)
+		watcher.AddRule(Rule{Name: "gusts", Condition: WindGustAbove(25)})
+		watcher.AddRule(Rule{Name: "other station", Stations: []string{"EDDW"}, Condition: WindGustAbove(0)})
+		events := watcher.RuleEvents(10)
+
+		poll := func(gust int64) {
+			src.results[0].WindGust = gust
+			src.results[0].ObservationTime = src.results[0].ObservationTime.Add(time.Minute)
+			Expect(watcher.Poll(context.Background())).To(Succeed())
+		}
+
+		poll(20)
+		Consistently(events).ShouldNot(Receive())
+
+		poll(30)
+		var ev RuleEvent
+		Eventually(events).Should(Receive(&ev))
+		Expect(ev.Rule).To(Equal("gusts"))
+		Expect(ev.Station).To(Equal("EDDH"))
+		Expect(ev.Tripped).To(BeTrue())
+		Expect(ev.Result.WindGust).To(Equal(int64(30)))
+
+		poll(35)
+		Consistently(events).ShouldNot(Receive())
+
+		poll(10)
+		Eventually(events).Should(Receive(&ev))
+		Expect(ev.Tripped).To(BeFalse())
+	})
+
+	table.DescribeTable("conditions",
+		func(cond Condition, r Result, expected bool) {
+			Expect(cond(r)).To(Equal(expected))
+		},
+		table.Entry("gust above", WindGustAbove(25), Result{WindGust: 26}, true),
+		table.Entry("gust not above", WindGustAbove(25), Result{WindGust: 25}, false),
+		table.Entry("speed above", WindSpeedAbove(10), Result{WindSpeed: 12}, true),
+		table.Entry("freezing", TemperatureBelow(0), withTemperature(-2), true),
+		table.Entry("missing temperature", TemperatureBelow(0), Result{}, false),
+		table.Entry("warm", TemperatureAbove(25), withTemperature(28), true),
+		table.Entry("IFR worse than MVFR", FlightCategoryWorseThan(FlightCategoryMVFR), Result{FlightCategory: FlightCategoryIFR}, true),
+		table.Entry("MVFR not worse than MVFR", FlightCategoryWorseThan(FlightCategoryMVFR), Result{FlightCategory: FlightCategoryMVFR}, false),
+		table.Entry("unknown category", FlightCategoryWorseThan(FlightCategoryMVFR), Result{}, false),
+		table.Entry("thunderstorm", WeatherContains("TS"), Result{WXString: "+TSRA"}, true),
+		table.Entry("all", All(WindGustAbove(25), WeatherContains("TS")), Result{WindGust: 30}, false),
+		table.Entry("any", Any(WindGustAbove(25), WeatherContains("TS")), Result{WindGust: 30}, true),
+	)
+
+})
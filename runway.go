@@ -0,0 +1,209 @@
+package metar
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// RunwayDeposit is the type of deposit reported on a runway
+type RunwayDeposit int
+
+// Runway deposits as coded in runway state groups
+const (
+	RunwayDepositUnknown       RunwayDeposit = iota - 1 // Deposit not reported (/)
+	RunwayDepositClearDry                               // 0: Clear and dry
+	RunwayDepositDamp                                   // 1: Damp
+	RunwayDepositWet                                    // 2: Wet or water patches
+	RunwayDepositFrost                                  // 3: Rime or frost covered
+	RunwayDepositDrySnow                                // 4: Dry snow
+	RunwayDepositWetSnow                                // 5: Wet snow
+	RunwayDepositSlush                                  // 6: Slush
+	RunwayDepositIce                                    // 7: Ice
+	RunwayDepositCompactedSnow                          // 8: Compacted or rolled snow
+	RunwayDepositFrozenRuts                             // 9: Frozen ruts or ridges
+)
+
+var runwayDepositNames = []string{
+	"clear and dry", "damp", "wet", "rime or frost", "dry snow", "wet snow",
+	"slush", "ice", "compacted snow", "frozen ruts",
+}
+
+func (d RunwayDeposit) String() string {
+	if d < 0 || int(d) >= len(runwayDepositNames) {
+		return "unknown"
+	}
+	return runwayDepositNames[d]
+}
+
+// BrakingAction is the estimated braking action on a runway
+type BrakingAction string
+
+// Braking actions as coded in runway state groups (91 to 95, 99)
+const (
+	BrakingActionUnknown    BrakingAction = ""
+	BrakingActionPoor       BrakingAction = "POOR"
+	BrakingActionMediumPoor BrakingAction = "MEDIUM/POOR"
+	BrakingActionMedium     BrakingAction = "MEDIUM"
+	BrakingActionMediumGood BrakingAction = "MEDIUM/GOOD"
+	BrakingActionGood       BrakingAction = "GOOD"
+	BrakingActionUnreliable BrakingAction = "UNRELIABLE"
+)
+
+// RunwayState is the decoded state of a runway reported for winter
+// operations either in the MOTNE format (88290592) or the ICAO format
+// (R24/290592, R24/CLRD62, R/SNOCLO)
+type RunwayState struct {
+	// Runway designator (24, 24L), "ALL" for all runways of the aerodrome
+	Runway string
+	// Deposit on the runway
+	Deposit RunwayDeposit
+	// ExtentPercent is the upper bound of the contaminated part of the
+	// runway (10, 25, 50 or 100), zero if not reported
+	ExtentPercent int
+	// DepthMm is the depth of the deposit in millimeters, -1 if not
+	// reported. Depths of 400mm and more are reported as 400.
+	DepthMm int
+	// FrictionCoefficient is the measured friction coefficient, zero if
+	// only the braking action was estimated
+	FrictionCoefficient float64
+	// BrakingAction is the estimated braking action, derived from the
+	// friction coefficient if that was measured
+	BrakingAction BrakingAction
+	// NotOperational is set when the runway is reported not operational
+	// due to snow, slush, ice or other reasons (depth coded as 99)
+	NotOperational bool
+	// Cleared is set when contamination has ceased to exist (CLRD)
+	Cleared bool
+	// SnowClosed is set when the aerodrome is closed due to snow (SNOCLO)
+	SnowClosed bool
+}
+
+var (
+	runwayStateRegex   = regexp.MustCompile(`^(?:R(\d{2}[LCR]?)/|(\d{2}))([0-9/])([1259/])(\d{2}|//)(\d{2}|//)$`)
+	runwayClearedRegex = regexp.MustCompile(`^(?:R(\d{2}[LCR]?)/|(\d{2}))CLRD(\d{2}|//)$`)
+)
+
+func isRunwayStateGroup(group string) bool {
+	return group == "SNOCLO" || group == "R/SNOCLO" ||
+		runwayStateRegex.MatchString(group) || runwayClearedRegex.MatchString(group)
+}
+
+// ParseRunwayState decodes a runway state group like "88290592",
+// "R24/290592", "R24/CLRD62" or "R/SNOCLO"
+func ParseRunwayState(group string) (RunwayState, error) {
+	if group == "SNOCLO" || group == "R/SNOCLO" {
+		return RunwayState{Runway: "ALL", Deposit: RunwayDepositUnknown, DepthMm: -1, SnowClosed: true}, nil
+	}
+
+	if m := runwayClearedRegex.FindStringSubmatch(group); m != nil {
+		s := RunwayState{Runway: runwayDesignator(m[1], m[2]), Deposit: RunwayDepositUnknown, DepthMm: -1, Cleared: true}
+		s.FrictionCoefficient, s.BrakingAction = decodeBraking(m[3])
+		return s, nil
+	}
+
+	m := runwayStateRegex.FindStringSubmatch(group)
+	if m == nil {
+		return RunwayState{}, fmt.Errorf("Invalid runway state group %q", group)
+	}
+
+	s := RunwayState{Runway: runwayDesignator(m[1], m[2]), Deposit: RunwayDepositUnknown, DepthMm: -1}
+	if m[3] != "/" {
+		s.Deposit = RunwayDeposit(m[3][0] - '0')
+	}
+
+	switch m[4] {
+	case "1":
+		s.ExtentPercent = 10
+	case "2":
+		s.ExtentPercent = 25
+	case "5":
+		s.ExtentPercent = 50
+	case "9":
+		s.ExtentPercent = 100
+	}
+
+	if d, err := strconv.Atoi(m[5]); err == nil {
+		switch {
+		case d <= 90:
+			s.DepthMm = d
+		case d >= 92 && d <= 98:
+			s.DepthMm = (d - 90) * 50
+		case d == 99:
+			s.NotOperational = true
+		}
+	}
+
+	s.FrictionCoefficient, s.BrakingAction = decodeBraking(m[6])
+	return s, nil
+}
+
+// runwayDesignator converts the runway of the ICAO (R24L/) or MOTNE (74)
+// format into a designator. MOTNE adds 50 for right runways and uses 88
+// for all runways.
+func runwayDesignator(icao, motne string) string {
+	rwy := icao
+	if rwy == "" {
+		rwy = motne
+	}
+
+	switch n, err := strconv.Atoi(rwy); {
+	case err != nil:
+		return rwy
+	case n == 88:
+		return "ALL"
+	case icao == "" && n > 50 && n <= 86:
+		return fmt.Sprintf("%02dR", n-50)
+	}
+	return rwy
+}
+
+func decodeBraking(code string) (float64, BrakingAction) {
+	b, err := strconv.Atoi(code)
+	if err != nil {
+		return 0, BrakingActionUnknown
+	}
+
+	switch {
+	case b >= 1 && b <= 90:
+		f := float64(b) / 100
+		switch {
+		case f >= 0.40:
+			return f, BrakingActionGood
+		case f >= 0.36:
+			return f, BrakingActionMediumGood
+		case f >= 0.30:
+			return f, BrakingActionMedium
+		case f >= 0.26:
+			return f, BrakingActionMediumPoor
+		}
+		return f, BrakingActionPoor
+	case b == 91:
+		return 0, BrakingActionPoor
+	case b == 92:
+		return 0, BrakingActionMediumPoor
+	case b == 93:
+		return 0, BrakingActionMedium
+	case b == 94:
+		return 0, BrakingActionMediumGood
+	case b == 95:
+		return 0, BrakingActionGood
+	case b == 99:
+		return 0, BrakingActionUnreliable
+	}
+	return 0, BrakingActionUnknown
+}
+
+// RunwayStates returns the runway state groups decoded from the raw report
+func (r Result) RunwayStates() []RunwayState {
+	var states []RunwayState
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind != TokenRunwayState {
+			continue
+		}
+		if s, err := ParseRunwayState(t.Text); err == nil {
+			states = append(states, s)
+		}
+	}
+	return states
+}
@@ -0,0 +1,60 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Runway state", func() {
+
+	table.DescribeTable("runway state groups",
+		func(group string, expected RunwayState) {
+			s, err := ParseRunwayState(group)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(expected))
+		},
+		table.Entry("MOTNE all runways", "88290592", RunwayState{
+			Runway: "ALL", Deposit: RunwayDepositWet, ExtentPercent: 100, DepthMm: 5, BrakingAction: BrakingActionMediumPoor,
+		}),
+		table.Entry("MOTNE right runway", "74490135", RunwayState{
+			Runway: "24R", Deposit: RunwayDepositDrySnow, ExtentPercent: 100, DepthMm: 1, FrictionCoefficient: 0.35, BrakingAction: BrakingActionMedium,
+		}),
+		table.Entry("ICAO format", "R24L/8592//", RunwayState{
+			Runway: "24L", Deposit: RunwayDepositCompactedSnow, ExtentPercent: 50, DepthMm: 100, BrakingAction: BrakingActionUnknown,
+		}),
+		table.Entry("not operational", "R06/7/9991", RunwayState{
+			Runway: "06", Deposit: RunwayDepositIce, DepthMm: -1, NotOperational: true, BrakingAction: BrakingActionPoor,
+		}),
+		table.Entry("cleared", "R24/CLRD62", RunwayState{
+			Runway: "24", Deposit: RunwayDepositUnknown, DepthMm: -1, Cleared: true, FrictionCoefficient: 0.62, BrakingAction: BrakingActionGood,
+		}),
+		table.Entry("closed due to snow", "R/SNOCLO", RunwayState{
+			Runway: "ALL", Deposit: RunwayDepositUnknown, DepthMm: -1, SnowClosed: true,
+		}),
+	)
+
+	It("should reject other groups", func() {
+		_, err := ParseRunwayState("R24/1200U")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should name deposits", func() {
+		Expect(RunwayDepositSlush.String()).To(Equal("slush"))
+		Expect(RunwayDepositUnknown.String()).To(Equal("unknown"))
+	})
+
+	It("should decode runway states of a report", func() {
+		r := Result{RawText: "METAR EFHK 211020Z 34012KT 4000 -SN BKN012 M04/M06 Q1002 R04L/1200 R04L/590395 R15/CLRD70 NOSIG"}
+		states := r.RunwayStates()
+		Expect(states).To(HaveLen(2))
+		Expect(states[0].Runway).To(Equal("04L"))
+		Expect(states[0].Deposit).To(Equal(RunwayDepositWetSnow))
+		Expect(states[0].DepthMm).To(Equal(3))
+		Expect(states[0].BrakingAction).To(Equal(BrakingActionGood))
+		Expect(states[1].Cleared).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,170 @@
+package metar
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schedule decides when a Collector fetches each of its stations
+type Schedule interface {
+	// Next returns when the station is to be fetched after the fetch at
+	// the given time. For the first fetch the time is zero.
+	Next(station string, fetched time.Time) time.Time
+	// Observed is called for every result fetched by the Collector
+	Observed(r Result)
+}
+
+// FixedSchedule fetches all stations every interval. It is used by
+// Collectors unless another Schedule is set.
+type FixedSchedule time.Duration
+
+// Next implements Schedule
+func (f FixedSchedule) Next(station string, fetched time.Time) time.Time {
+	if fetched.IsZero() {
+		return fetched
+	}
+	return fetched.Add(time.Duration(f))
+}
+
+// Observed implements Schedule
+func (FixedSchedule) Observed(Result) {}
+
+// cadenceHistory is the number of report intervals used to estimate the
+// reporting cadence of a station
+const cadenceHistory = 6
+
+// minCadence is the shortest interval considered a regular report cycle,
+// shorter intervals are caused by special reports (SPECI)
+const minCadence = 10 * time.Minute
+
+// AdaptiveSchedule adapts the fetches to the reporting cadence of every
+// station (typically 20, 30 or 60 minutes). Until the next regular report
+// is expected a station is fetched every SpeciCheck to catch special
+// reports, once the report is due it is fetched every Poll until it
+// arrives. Stations with unknown cadence are fetched every Poll.
+type AdaptiveSchedule struct {
+	Poll       time.Duration
+	SpeciCheck time.Duration
+
+	mu       sync.Mutex
+	stations map[string]*cadenceState
+}
+
+type cadenceState struct {
+	last time.Time
+	gaps []time.Duration
+}
+
+// NewAdaptiveSchedule creates an AdaptiveSchedule
+func NewAdaptiveSchedule(poll, speciCheck time.Duration) *AdaptiveSchedule {
+	return &AdaptiveSchedule{
+		Poll:       poll,
+		SpeciCheck: speciCheck,
+		stations:   make(map[string]*cadenceState),
+	}
+}
+
+// Next implements Schedule
+func (a *AdaptiveSchedule) Next(station string, fetched time.Time) time.Time {
+	if fetched.IsZero() {
+		return fetched
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	st, ok := a.stations[strings.ToUpper(station)]
+	cadence, known := st.cadence()
+	if !ok || !known {
+		return fetched.Add(a.Poll)
+	}
+
+	expected := st.last.Add(cadence)
+	if !fetched.Before(expected) {
+		return fetched.Add(a.Poll)
+	}
+	if check := fetched.Add(a.SpeciCheck); check.Before(expected) {
+		return check
+	}
+	return expected
+}
+
+// Observed implements Schedule and records the report times
+func (a *AdaptiveSchedule) Observed(r Result) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := strings.ToUpper(r.StationID)
+	st, ok := a.stations[key]
+	if !ok {
+		st = &cadenceState{}
+		a.stations[key] = st
+	}
+	if !r.ObservationTime.After(st.last) {
+		return
+	}
+
+	if gap := r.ObservationTime.Sub(st.last); !st.last.IsZero() && gap >= minCadence {
+		st.gaps = append(st.gaps, gap)
+		if len(st.gaps) > cadenceHistory {
+			st.gaps = st.gaps[1:]
+		}
+	}
+	st.last = r.ObservationTime
+}
+
+// Cadence returns the estimated interval between the regular reports of
+// the station, ok is false until two regular reports were observed
+func (a *AdaptiveSchedule) Cadence(station string) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.stations[strings.ToUpper(station)].cadence()
+}
+
+// cadence returns the median of the recorded report intervals
+func (s *cadenceState) cadence() (time.Duration, bool) {
+	if s == nil || len(s.gaps) == 0 {
+		return 0, false
+	}
+
+	gaps := append([]time.Duration(nil), s.gaps...)
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2], true
+}
+
+// JitterSchedule fetches every station once per interval at an offset
+// derived from its identifier, spreading the fetches of many stations
+// evenly over the interval instead of firing them all at once. The
+// offsets are deterministic so restarts and multiple instances keep
+// their slots. An interval of zero or less disables the offsets and
+// behaves like a FixedSchedule.
+type JitterSchedule time.Duration
+
+// Next implements Schedule, the first fetch happens in the next slot of
+// the station
+func (j JitterSchedule) Next(station string, fetched time.Time) time.Time {
+	interval := time.Duration(j)
+	if interval <= 0 {
+		return FixedSchedule(interval).Next(station, fetched)
+	}
+	if fetched.IsZero() {
+		fetched = time.Now()
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(strings.ToUpper(station)))
+	offset := time.Duration(h.Sum64() % uint64(interval))
+
+	slot := fetched.Truncate(interval).Add(offset)
+	if !slot.After(fetched) {
+		slot = slot.Add(interval)
+	}
+	return slot
+}
+
+// Observed implements Schedule
+func (JitterSchedule) Observed(Result) {}
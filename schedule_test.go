@@ -0,0 +1,100 @@
+package metar_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// staggeredSchedule fetches EDDH every 10ms and all other stations once
+type staggeredSchedule struct{}
+
+func (staggeredSchedule) Next(station string, fetched time.Time) time.Time {
+	if station == "EDDH" {
+		return fetched.Add(10 * time.Millisecond)
+	}
+	return fetched.Add(time.Hour)
+}
+
+func (staggeredSchedule) Observed(Result) {}
+
+var _ = Describe("Schedule", func() {
+	base := time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)
+
+	It("should fetch at a fixed interval", func() {
+		s := FixedSchedule(time.Minute)
+		Expect(s.Next("EDDH", time.Time{}).IsZero()).To(BeTrue())
+		Expect(s.Next("EDDH", base)).To(Equal(base.Add(time.Minute)))
+	})
+
+	It("should spread the stations over the interval", func() {
+		s := JitterSchedule(10 * time.Minute)
+
+		eddh := s.Next("EDDH", base)
+		Expect(eddh).To(BeTemporally(">", base))
+		Expect(eddh).To(BeTemporally("<=", base.Add(10*time.Minute)))
+		Expect(s.Next("eddh", base)).To(Equal(eddh))
+		Expect(s.Next("EDDH", eddh)).To(Equal(eddh.Add(10 * time.Minute)))
+		Expect(s.Next("EDDW", base)).NotTo(Equal(eddh))
+
+		Expect(s.Next("EDDH", time.Time{})).To(BeTemporally("~", time.Now(), 10*time.Minute))
+	})
+
+	It("should not jitter without interval", func() {
+		s := JitterSchedule(0)
+		Expect(s.Next("EDDH", time.Time{}).IsZero()).To(BeTrue())
+		Expect(s.Next("EDDH", base)).To(Equal(base))
+	})
+
+	It("should estimate the reporting cadence", func() {
+		s := NewAdaptiveSchedule(time.Minute, 10*time.Minute)
+		_, ok := s.Cadence("EDDH")
+		Expect(ok).To(BeFalse())
+
+		for _, offset := range []time.Duration{0, 30, 42, 60, 90} {
+			s.Observed(Result{StationID: "EDDH", ObservationTime: base.Add(offset * time.Minute)})
+		}
+
+		cadence, ok := s.Cadence("eddh")
+		Expect(ok).To(BeTrue())
+		Expect(cadence).To(Equal(30 * time.Minute))
+	})
+
+	It("should fetch stations when their report is due", func() {
+		s := NewAdaptiveSchedule(time.Minute, 10*time.Minute)
+		Expect(s.Next("EDDH", base).Sub(base)).To(Equal(time.Minute))
+
+		s.Observed(Result{StationID: "EDDH", ObservationTime: base})
+		s.Observed(Result{StationID: "EDDH", ObservationTime: base.Add(30 * time.Minute)})
+
+		// Waiting for the report expected at +60m, checking for SPECIs
+		Expect(s.Next("EDDH", base.Add(32*time.Minute))).To(Equal(base.Add(42 * time.Minute)))
+		Expect(s.Next("EDDH", base.Add(55*time.Minute))).To(Equal(base.Add(60 * time.Minute)))
+		// Report overdue
+		Expect(s.Next("EDDH", base.Add(61*time.Minute))).To(Equal(base.Add(62 * time.Minute)))
+	})
+
+	It("should run the collector on the schedule", func() {
+		src := &staticSource{name: "static", results: []Result{{StationID: "EDDH"}, {StationID: "EDDW"}}}
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH", "EDDW"}, time.Hour, SinkFunc(func(context.Context, []Result) error { return nil }))
+		c.SetSchedule(staggeredSchedule{})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 55*time.Millisecond)
+		defer cancel()
+		Expect(c.Run(ctx)).To(Equal(context.DeadlineExceeded))
+
+		fetched := map[string]int{}
+		for _, q := range src.queries {
+			for _, st := range q.Stations {
+				fetched[st]++
+			}
+		}
+		Expect(fetched["EDDW"]).To(Equal(1))
+		Expect(fetched["EDDH"]).To(BeNumerically(">=", 3))
+	})
+
+})
@@ -0,0 +1,79 @@
+package metar
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SeaState holds the sea surface temperature and state of the sea
+// reported by offshore stations in the W-group (W15/S4, W12/H75)
+type SeaState struct {
+	// WaterTemperature is the sea surface temperature (celsius), only
+	// valid if HasWaterTemperature is set
+	WaterTemperature    float64
+	HasWaterTemperature bool
+	// State is the state of the sea (WMO code table 3700), -1 if not
+	// reported
+	State int
+	// WaveHeightM is the significant wave height (meters), -1 if not
+	// reported
+	WaveHeightM float64
+}
+
+var (
+	seaStateRegex = regexp.MustCompile(`^W(M?\d{2}|//)/(?:S(\d|/)|H(\d{1,3}|///))$`)
+
+	seaStateDescriptions = []string{
+		"Calm (glassy)", "Calm (rippled)", "Smooth (wavelets)", "Slight", "Moderate",
+		"Rough", "Very rough", "High", "Very high", "Phenomenal",
+	}
+)
+
+// ParseSeaState decodes a W-group like "W15/S4", "WM01/S2" or "W12/H75"
+func ParseSeaState(group string) (SeaState, error) {
+	m := seaStateRegex.FindStringSubmatch(group)
+	if m == nil {
+		return SeaState{}, fmt.Errorf("Invalid sea state group %q", group)
+	}
+
+	s := SeaState{State: -1, WaveHeightM: -1}
+	if t, err := strconv.Atoi(strings.Replace(m[1], "M", "-", 1)); err == nil {
+		s.WaterTemperature = float64(t)
+		s.HasWaterTemperature = true
+	}
+
+	if v, err := strconv.Atoi(m[2]); err == nil {
+		s.State = v
+	}
+
+	if v, err := strconv.Atoi(m[3]); err == nil {
+		// Wave height is reported in decimeters
+		s.WaveHeightM = float64(v) / 10
+	}
+
+	return s, nil
+}
+
+// Description returns the WMO descriptive term of the state of the sea or
+// an empty string if the state was not reported
+func (s SeaState) Description() string {
+	if s.State < 0 || s.State >= len(seaStateDescriptions) {
+		return ""
+	}
+	return seaStateDescriptions[s.State]
+}
+
+// SeaState returns the sea state reported in the raw report
+func (r Result) SeaState() (SeaState, bool) {
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind != TokenSeaState {
+			continue
+		}
+		if s, err := ParseSeaState(t.Text); err == nil {
+			return s, true
+		}
+	}
+	return SeaState{}, false
+}
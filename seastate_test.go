@@ -0,0 +1,41 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sea state", func() {
+
+	table.DescribeTable("sea state groups",
+		func(group string, expected SeaState) {
+			s, err := ParseSeaState(group)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(expected))
+		},
+		table.Entry("state of the sea", "W15/S4", SeaState{WaterTemperature: 15, HasWaterTemperature: true, State: 4, WaveHeightM: -1}),
+		table.Entry("negative temperature", "WM01/S2", SeaState{WaterTemperature: -1, HasWaterTemperature: true, State: 2, WaveHeightM: -1}),
+		table.Entry("wave height", "W12/H75", SeaState{WaterTemperature: 12, HasWaterTemperature: true, State: -1, WaveHeightM: 7.5}),
+		table.Entry("missing temperature", "W///S3", SeaState{State: 3, WaveHeightM: -1}),
+	)
+
+	It("should reject other groups", func() {
+		_, err := ParseSeaState("W15")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should decode the sea state of a report", func() {
+		r := Result{RawText: "METAR ENLE 211020Z 27032KT 9999 FEW015 08/04 Q0998 W09/S6"}
+		s, ok := r.SeaState()
+		Expect(ok).To(BeTrue())
+		Expect(s.WaterTemperature).To(Equal(9.0))
+		Expect(s.Description()).To(Equal("Very rough"))
+
+		_, ok = Result{RawText: "METAR EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018"}.SeaState()
+		Expect(ok).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,169 @@
+package metar
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// WriteXPlaneRWX writes the results in the format of the METAR.rwx file
+// read by X-Plane for real weather: the NOAA cycle file layout with the
+// observation time preceding every report. Results without raw report
+// are skipped.
+func WriteXPlaneRWX(w io.Writer, results []Result) error {
+	bw := bufio.NewWriter(w)
+	for _, r := range results {
+		if r.RawText == "" {
+			continue
+		}
+		fmt.Fprintf(bw, "%s\n%s\n\n", r.ObservationTime.UTC().Format(noaaTimeLayout), r.RawText)
+	}
+	return bw.Flush()
+}
+
+// msfsPreset is the weather preset (.WPR) file format of Microsoft Flight
+// Simulator
+type msfsPreset struct {
+	XMLName xml.Name `xml:"SimBase.Document"`
+	Type    string   `xml:"Type,attr"`
+	Version string   `xml:"version,attr"`
+	Descr   string   `xml:"Descr"`
+	Preset  struct {
+		Name                  string           `xml:"Name"`
+		Order                 int              `xml:"Order"`
+		IsAltitudeAMGL        string           `xml:"IsAltitudeAMGL"`
+		MSLPressure           msfsValue        `xml:"MSLPressure"`
+		MSLTemperature        msfsValue        `xml:"MSLTemperature"`
+		Precipitations        msfsValue        `xml:"Precipitations"`
+		SnowCover             msfsValue        `xml:"SnowCover"`
+		ThunderstormIntensity msfsValue        `xml:"ThunderstormIntensity"`
+		CloudLayers           []msfsCloudLayer `xml:"CloudLayer"`
+		WindLayers            []msfsWindLayer  `xml:"WindLayer"`
+	} `xml:"WeatherPreset.Preset"`
+}
+
+type msfsValue struct {
+	Pa      string `xml:"Pa,attr,omitempty"`
+	Kelvin  string `xml:"Kelvin,attr,omitempty"`
+	Meters  string `xml:"Meters,attr,omitempty"`
+	Degrees string `xml:"Degrees,attr,omitempty"`
+	Knots   string `xml:"Knots,attr,omitempty"`
+	Seconds string `xml:"Seconds,attr,omitempty"`
+	Value   string `xml:"Value,attr,omitempty"`
+}
+
+type msfsCloudLayer struct {
+	Density     msfsValue `xml:"CloudLayerDensity"`
+	AltitudeBot msfsValue `xml:"CloudLayerAltitudeBot"`
+	AltitudeTop msfsValue `xml:"CloudLayerAltitudeTop"`
+}
+
+type msfsWindLayer struct {
+	Altitude msfsValue     `xml:"WindLayerAltitude"`
+	Angle    msfsValue     `xml:"WindLayerAngle"`
+	Speed    msfsValue     `xml:"WindLayerSpeed"`
+	Gust     *msfsGustWave `xml:"GustWave,omitempty"`
+}
+
+type msfsGustWave struct {
+	Interval msfsValue `xml:"GustWaveInterval"`
+	Speed    msfsValue `xml:"GustWaveSpeed"`
+	Angle    msfsValue `xml:"GustAngle"`
+}
+
+// msfsCloudDensity maps the cloud cover to the density of the layer
+var msfsCloudDensity = map[string]float64{"FEW": 0.2, "SCT": 0.4, "BKN": 0.7, "OVC": 1, "VV": 1}
+
+// MSFSPreset translates the result into a Microsoft Flight Simulator
+// weather preset (.WPR) named name. Cloud layers are taken from the raw
+// report, cumulonimbus layers are made 8000 m thick, all others 1000 m.
+func MSFSPreset(name string, r Result) ([]byte, error) {
+	p := msfsPreset{Type: "WeatherPlan", Version: "1,3", Descr: "AceXML Document"}
+	p.Preset.Name = name
+	p.Preset.IsAltitudeAMGL = "True"
+
+	pressure := 1013.25
+	switch {
+	case r.Altimeter > 0:
+		pressure = InHgTohPa(r.Altimeter)
+	case r.SeaLevelPressure > 0:
+		pressure = r.SeaLevelPressure
+	}
+	p.Preset.MSLPressure = msfsValue{Pa: formatFloat(pressure * 100)}
+	// Reduce the station temperature to sea level using the ISA lapse rate
+	p.Preset.MSLTemperature = msfsValue{Kelvin: formatFloat(r.Temperature + 273.15 + 0.0065*r.Elevation)}
+	p.Preset.Precipitations = msfsValue{Value: formatFloat(msfsPrecipitation(r))}
+	p.Preset.SnowCover = msfsValue{Value: formatFloat(r.SnowDepthIn * 0.0254)}
+	p.Preset.ThunderstormIntensity = msfsValue{Value: "0"}
+	if r.HasThunderstorm() {
+		p.Preset.ThunderstormIntensity.Value = "1"
+	}
+
+	for _, l := range r.CloudLayers() {
+		if l.BaseFt < 0 {
+			continue
+		}
+
+		bottom := float64(l.BaseFt) * 0.3048
+		thickness := 1000.0
+		if l.Type == CloudTypeCumulonimbus {
+			thickness = 8000
+		}
+		p.Preset.CloudLayers = append(p.Preset.CloudLayers, msfsCloudLayer{
+			Density:     msfsValue{Value: formatFloat(msfsCloudDensity[l.group()])},
+			AltitudeBot: msfsValue{Meters: formatFloat(bottom)},
+			AltitudeTop: msfsValue{Meters: formatFloat(bottom + thickness)},
+		})
+	}
+
+	wind := msfsWindLayer{
+		Altitude: msfsValue{Meters: "0"},
+		Angle:    msfsValue{Degrees: strconv.FormatInt(r.WindDirDegrees, 10)},
+		Speed:    msfsValue{Knots: strconv.FormatInt(r.WindSpeed, 10)},
+	}
+	if gust, ok := r.GustFactor(); ok {
+		wind.Gust = &msfsGustWave{
+			Interval: msfsValue{Seconds: "10"},
+			Speed:    msfsValue{Knots: strconv.FormatInt(gust, 10)},
+			Angle:    msfsValue{Degrees: "0"},
+		}
+	}
+	p.Preset.WindLayers = []msfsWindLayer{wind}
+
+	out, err := xml.MarshalIndent(p, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// msfsPrecipitation estimates the precipitation rate (mm/h) from the
+// intensity of the precipitation reported at the station
+func msfsPrecipitation(r Result) float64 {
+	var rate float64
+	for _, w := range r.Weather() {
+		if w.Vicinity || !(w.Has("RA") || w.Has("DZ") || w.Has("SN") || w.Has("SG") || w.Has("PL") || w.Has("GR") || w.Has("GS") || w.Has("UP")) {
+			continue
+		}
+
+		v := 4.0
+		switch w.Intensity {
+		case "-":
+			v = 1
+		case "+":
+			v = 16
+		}
+		if v > rate {
+			rate = v
+		}
+	}
+	return rate
+}
+
+// formatFloat formats the value rounded to two decimals
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
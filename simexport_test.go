@@ -0,0 +1,80 @@
+package metar_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Simulator export", func() {
+
+	It("should write the X-Plane METAR.rwx format", func() {
+		buf := new(bytes.Buffer)
+		err := WriteXPlaneRWX(buf, []Result{
+			{RawText: "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG", ObservationTime: time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)},
+			{StationID: "EDDW"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.String()).To(Equal("2016/05/21 10:20\nEDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG\n\n"))
+	})
+
+	It("should translate the result into a MSFS weather preset", func() {
+		r, err := ParseRaw("EDDH 211020Z 27008G20KT 9999 -TSRA FEW030CB BKN080 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := MSFSPreset("EDDH", *r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(HavePrefix(xml.Header))
+
+		var doc struct {
+			Preset struct {
+				Name        string `xml:"Name"`
+				MSLPressure struct {
+					Pa string `xml:"Pa,attr"`
+				} `xml:"MSLPressure"`
+				Precipitations struct {
+					Value string `xml:"Value,attr"`
+				} `xml:"Precipitations"`
+				Thunderstorm struct {
+					Value string `xml:"Value,attr"`
+				} `xml:"ThunderstormIntensity"`
+				CloudLayers []struct {
+					Bottom struct {
+						Meters string `xml:"Meters,attr"`
+					} `xml:"CloudLayerAltitudeBot"`
+					Top struct {
+						Meters string `xml:"Meters,attr"`
+					} `xml:"CloudLayerAltitudeTop"`
+				} `xml:"CloudLayer"`
+				Wind struct {
+					Speed struct {
+						Knots string `xml:"Knots,attr"`
+					} `xml:"WindLayerSpeed"`
+					Gust struct {
+						Speed struct {
+							Knots string `xml:"Knots,attr"`
+						} `xml:"GustWaveSpeed"`
+					} `xml:"GustWave"`
+				} `xml:"WindLayer"`
+			} `xml:"WeatherPreset.Preset"`
+		}
+		Expect(xml.Unmarshal(out, &doc)).To(Succeed())
+
+		Expect(doc.Preset.Name).To(Equal("EDDH"))
+		Expect(doc.Preset.MSLPressure.Pa).To(Equal("101800"))
+		Expect(doc.Preset.Precipitations.Value).To(Equal("1"))
+		Expect(doc.Preset.Thunderstorm.Value).To(Equal("1"))
+		Expect(doc.Preset.CloudLayers).To(HaveLen(2))
+		Expect(doc.Preset.CloudLayers[0].Bottom.Meters).To(Equal("914.4"))
+		Expect(doc.Preset.CloudLayers[0].Top.Meters).To(Equal("8914.4"))
+		Expect(doc.Preset.CloudLayers[1].Top.Meters).To(Equal("3438.4"))
+		Expect(doc.Preset.Wind.Speed.Knots).To(Equal("8"))
+		Expect(doc.Preset.Wind.Gust.Speed.Knots).To(Equal("12"))
+	})
+
+})
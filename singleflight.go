@@ -0,0 +1,53 @@
+package metar
+
+import "sync"
+
+// flightGroup collapses concurrent fetches for the same key into one
+// upstream request (semantics of golang.org/x/sync/singleflight)
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	wg  sync.WaitGroup
+	res []Result
+	err error
+}
+
+// do executes fn once for all callers sharing the same key while a call is
+// in flight. Every caller receives its own copy of the result.
+func (g *flightGroup) do(key string, fn func() ([]Result, error)) ([]Result, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.result()
+	}
+
+	c := &flightCall{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.res, c.err = fn()
+	return c.result()
+}
+
+func (c *flightCall) result() ([]Result, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return append([]Result(nil), c.res...), nil
+}
@@ -0,0 +1,102 @@
+package metar_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const singleResultXML = `<?xml version="1.0" encoding="UTF-8"?>
+<response version="1.2">
+  <data num_results="1">
+    <METAR>
+      <raw_text>EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG</raw_text>
+      <station_id>EDDH</station_id>
+      <observation_time>2016-05-21T10:20:00Z</observation_time>
+      <latitude>53.63</latitude>
+      <longitude>10.0</longitude>
+      <temp_c>17.0</temp_c>
+      <dewpoint_c>9.0</dewpoint_c>
+      <wind_dir_degrees>270</wind_dir_degrees>
+      <wind_speed_kt>8</wind_speed_kt>
+      <visibility_statute_mi>6.21</visibility_statute_mi>
+      <altim_in_hg>30.059055</altim_in_hg>
+      <sky_condition sky_cover="FEW" cloud_base_ft_agl="3000" />
+      <flight_category>VFR</flight_category>
+      <metar_type>METAR</metar_type>
+      <elevation_m>15.0</elevation_m>
+    </METAR>
+  </data>
+</response>`
+
+type blockingTransport struct {
+	requests int32
+	release  chan struct{}
+}
+
+func (b *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(&b.requests, 1)
+	<-b.release
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(singleResultXML)),
+		Request:    req,
+	}, nil
+}
+
+var _ = Describe("Singleflight", func() {
+	var (
+		transport  *blockingTransport
+		origClient *http.Client
+	)
+
+	BeforeEach(func() {
+		transport = &blockingTransport{release: make(chan struct{})}
+		origClient = HTTPClient
+		HTTPClient = &http.Client{Transport: transport}
+	})
+
+	AfterEach(func() {
+		HTTPClient = origClient
+	})
+
+	It("should collapse concurrent requests for the same station", func() {
+		var (
+			started sync.WaitGroup
+			wg      sync.WaitGroup
+			results = make([]*Result, 10)
+			errs    = make([]error, 10)
+		)
+
+		for i := range results {
+			started.Add(1)
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				started.Done()
+				results[i], errs[i] = FetchCurrentStationWeather("EDDH")
+			}(i)
+		}
+
+		started.Wait()
+		Eventually(func() int32 { return atomic.LoadInt32(&transport.requests) }).Should(Equal(int32(1)))
+		time.Sleep(50 * time.Millisecond)
+		close(transport.release)
+		wg.Wait()
+
+		Expect(atomic.LoadInt32(&transport.requests)).To(Equal(int32(1)))
+		for i := range results {
+			Expect(errs[i]).NotTo(HaveOccurred())
+			Expect(results[i].StationID).To(Equal("EDDH"))
+		}
+		Expect(results[0]).NotTo(BeIdenticalTo(results[1]))
+	})
+})
@@ -0,0 +1,177 @@
+package metar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Source retrieves METAR observations from a data provider
+type Source interface {
+	// Fetch returns the observations matching the query. Stations without
+	// observations are omitted from the result instead of causing an error.
+	Fetch(ctx context.Context, q Query) ([]Result, error)
+}
+
+// Query describes which observations to retrieve from a Source
+type Query struct {
+	Stations  []string        // Station identifiers (ICAO)
+	Selection ReportSelection // Which of the reports in the time window to return
+	Fields    []Field         // Limit the returned attributes to these fields, all fields if empty
+
+	// Time window to search for reports. If Start and End are not set the
+	// window ends now and spans HoursBeforeNow hours (defaults to 2).
+	Start, End     time.Time
+	HoursBeforeNow float64
+
+	// Area limits the results to stations within the bounding box, only
+	// supported by ADDS. Stations may be left empty for area queries.
+	Area *BoundingBox
+}
+
+// BoundingBox is an area between two latitudes and two longitudes (in
+// decimal degrees)
+type BoundingBox struct {
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+}
+
+// ReportSelection defines which reports are returned for a Query
+type ReportSelection int
+
+// Possible selections of reports
+const (
+	// MostRecent returns only the single newest report of all requested stations
+	MostRecent ReportSelection = iota
+	// MostRecentForEachStation returns the newest report of every requested station
+	MostRecentForEachStation
+	// AllReports returns every report within the time window
+	AllReports
+)
+
+// key identifies the query for deduplication of concurrent requests
+func (q Query) key() string {
+	return fmt.Sprintf("%s|%d|%v|%d|%d|%v|%v",
+		strings.ToUpper(strings.Join(q.Stations, ",")), q.Selection, q.Fields,
+		q.Start.Unix(), q.End.Unix(), q.HoursBeforeNow, q.Area)
+}
+
+// WithSource sets the Source to retrieve observations from (defaults to ADDS)
+func WithSource(s Source) ClientOption {
+	return func(c *Client) { c.source = s }
+}
+
+type requestContextKey struct{}
+
+// requestContext is passed to the sources of this package through the
+// context so their requests are made using the settings of the Client
+type requestContext struct {
+	client *Client
+	info   *FetchInfo
+}
+
+func withRequestContext(ctx context.Context, c *Client, info *FetchInfo) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, requestContext{c, info})
+}
+
+// get executes a GET request using the Client found in the context or the
+// DefaultClient if the Source is used on its own. The header is sent in
+// addition to the headers configured on the Client.
+func get(ctx context.Context, url string, header http.Header) (*http.Response, error) {
+	rc, ok := ctx.Value(requestContextKey{}).(requestContext)
+	if !ok {
+		rc = requestContext{client: DefaultClient}
+	}
+
+	req, err := rc.client.newRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if rc.client.conditional != nil {
+		rc.client.conditional.prepare(req)
+	}
+
+	res, err := rc.client.http().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if rc.info != nil {
+		rc.info.StatusCode = res.StatusCode
+	}
+	if rc.client.maxResponseSize > 0 {
+		res.Body = &limitedBody{ReadCloser: res.Body, remaining: rc.client.maxResponseSize}
+	}
+	if rc.client.conditional != nil {
+		status := res.StatusCode
+		if res, err = rc.client.conditional.handle(req, res); err != nil {
+			return nil, err
+		}
+		if rc.info != nil && status == http.StatusNotModified && res.StatusCode != status {
+			rc.info.NotModified = true
+		}
+	}
+	if rc.client.rawPayload {
+		return retainPayload(res)
+	}
+	return res, nil
+}
+
+// setOrigin records the response the results were decoded from on them
+func setOrigin(results []Result, res *http.Response) {
+	for i := range results {
+		setResultOrigin(&results[i], res)
+	}
+}
+
+func setResultOrigin(r *Result, res *http.Response) {
+	if res.Request != nil {
+		r.SourceURL = res.Request.URL.String()
+	}
+	r.StatusCode = res.StatusCode
+	r.Payload = responsePayload(res)
+}
+
+// selectReports reduces the results to those requested by the selection
+// for sources not supporting the selection upstream
+func selectReports(results []Result, sel ReportSelection) []Result {
+	switch sel {
+	case MostRecent:
+		var newest *Result
+		for i := range results {
+			if newest == nil || results[i].ObservationTime.After(newest.ObservationTime) {
+				newest = &results[i]
+			}
+		}
+		if newest == nil {
+			return nil
+		}
+		return []Result{*newest}
+
+	case MostRecentForEachStation:
+		var (
+			out   []Result
+			index = make(map[string]int)
+		)
+		for _, r := range results {
+			key := strings.ToUpper(r.StationID)
+			i, ok := index[key]
+			switch {
+			case !ok:
+				index[key] = len(out)
+				out = append(out, r)
+			case r.ObservationTime.After(out[i].ObservationTime):
+				out[i] = r
+			}
+		}
+		return out
+	}
+
+	return results
+}
@@ -0,0 +1,173 @@
+package metar
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// StationAliases maps IATA codes to the ICAO identifier of the airport.
+// It is filled from the embedded station database and may be extended.
+// Three character identifiers not listed here are taken as FAA location
+// identifiers of the contiguous US by ResolveStation.
+var StationAliases = make(map[string]string)
+
+// StationInfo describes an airport of the embedded station database
+type StationInfo struct {
+	ICAO      string
+	IATA      string
+	Name      string
+	City      string
+	Country   string  // ISO 3166-1 alpha-2 country code
+	Latitude  float64 // decimal degrees
+	Longitude float64 // decimal degrees
+}
+
+// ErrEmptySearch is returned by SearchStations for an empty query
+var ErrEmptySearch = errors.New("Search query must not be empty")
+
+// searchFolder removes diacritics so "Zurich" finds "Zürich"
+var searchFolder = strings.NewReplacer(
+	"ä", "a", "ö", "o", "ü", "u", "ß", "ss", "å", "a",
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e",
+	"í", "i", "ó", "o", "ô", "o", "ú", "u",
+	"ç", "c", "ñ", "n",
+)
+
+func init() {
+	for _, s := range stationDatabase {
+		if s.IATA != "" {
+			StationAliases[s.IATA] = s.ICAO
+		}
+	}
+}
+
+// SearchStations finds airports of the embedded station database whose
+// identifiers, name or city contain all words of the query, ignoring case
+// and diacritics. Exact identifier matches come first, followed by
+// matches at the start of the city or name.
+func SearchStations(query string) ([]StationInfo, error) {
+	words := strings.Fields(searchFolder.Replace(strings.ToLower(query)))
+	if len(words) == 0 {
+		return nil, ErrEmptySearch
+	}
+
+	type match struct {
+		station StationInfo
+		score   int
+	}
+
+	var matches []match
+	for _, s := range stationDatabase {
+		if score, ok := searchScore(s, words); ok {
+			matches = append(matches, match{s, score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].station.ICAO < matches[j].station.ICAO
+	})
+
+	out := make([]StationInfo, len(matches))
+	for i, m := range matches {
+		out[i] = m.station
+	}
+	return out, nil
+}
+
+// searchScore checks whether all words match the station and rates the
+// quality of the match
+func searchScore(s StationInfo, words []string) (int, bool) {
+	var (
+		ids   = []string{strings.ToLower(s.ICAO), strings.ToLower(s.IATA)}
+		texts = []string{
+			searchFolder.Replace(strings.ToLower(s.City)),
+			searchFolder.Replace(strings.ToLower(s.Name)),
+		}
+		score int
+	)
+
+	for _, w := range words {
+		switch {
+		case w == ids[0] || w == ids[1]:
+			score += 3
+		case strings.HasPrefix(texts[0], w) || strings.HasPrefix(texts[1], w):
+			score += 2
+		case strings.Contains(texts[0], w) || strings.Contains(texts[1], w) || strings.HasPrefix(ids[0], w):
+			score++
+		default:
+			return 0, false
+		}
+	}
+	return score, true
+}
+
+// ResolveStation translates IATA codes (HAM) and US three character
+// identifiers (JFK, 1O5) into the ICAO identifier used by the upstream
+// sources. ICAO identifiers and unknown identifiers are returned upper-cased.
+func ResolveStation(id string) string {
+	return resolveStation(id, nil)
+}
+
+// WithStationAliases makes the client resolve IATA codes and US
+// identifiers using ResolveStation before querying. The given aliases are
+// checked before StationAliases and may be nil.
+func WithStationAliases(aliases map[string]string) ClientOption {
+	return func(c *Client) {
+		c.aliases = make(map[string]string, len(aliases))
+		for k, v := range aliases {
+			c.aliases[strings.ToUpper(k)] = strings.ToUpper(v)
+		}
+	}
+}
+
+func resolveStation(id string, aliases map[string]string) string {
+	id = strings.ToUpper(strings.TrimSpace(id))
+
+	if icao, ok := aliases[id]; ok {
+		return icao
+	}
+	if icao, ok := StationAliases[id]; ok {
+		return icao
+	}
+
+	if len(id) == 3 && strings.IndexFunc(id, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) < 0 {
+		return "K" + id
+	}
+	return id
+}
+
+// resolve translates the station identifier if aliasing is enabled
+func (c *Client) resolve(station string) string {
+	if c.aliases == nil {
+		return station
+	}
+	return resolveStation(station, c.aliases)
+}
+
+// stationKey returns the upper-cased identifier results of the station
+// are reported with (see Result.StationID)
+func (c *Client) stationKey(station string) string {
+	return strings.ToUpper(c.resolve(station))
+}
+
+// resolveQuery returns the query with all its stations resolved
+func (c *Client) resolveQuery(q Query) Query {
+	if c.aliases == nil || len(q.Stations) == 0 {
+		return q
+	}
+
+	stations := make([]string, len(q.Stations))
+	for i, st := range q.Stations {
+		stations[i] = c.resolve(st)
+	}
+	q.Stations = stations
+	return q
+}
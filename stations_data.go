@@ -0,0 +1,106 @@
+package metar
+
+// stationDatabase lists the airports known to SearchStations. The IATA
+// codes are used to fill StationAliases.
+var stationDatabase = []StationInfo{
+	// Germany
+	{"EDDB", "BER", "Berlin Brandenburg", "Berlin", "DE", 52.362, 13.501},
+	{"EDDF", "FRA", "Frankfurt am Main", "Frankfurt", "DE", 50.033, 8.571},
+	{"EDDH", "HAM", "Hamburg", "Hamburg", "DE", 53.630, 9.988},
+	{"EDHI", "XFW", "Hamburg Finkenwerder", "Hamburg", "DE", 53.535, 9.835},
+	{"EDHL", "LBC", "Lübeck Blankensee", "Lübeck", "DE", 53.805, 10.719},
+	{"EDDK", "CGN", "Köln/Bonn", "Köln", "DE", 50.866, 7.143},
+	{"EDDL", "DUS", "Düsseldorf", "Düsseldorf", "DE", 51.289, 6.767},
+	{"EDDM", "MUC", "München", "München", "DE", 48.354, 11.786},
+	{"EDDN", "NUE", "Nürnberg", "Nürnberg", "DE", 49.499, 11.078},
+	{"EDDS", "STR", "Stuttgart", "Stuttgart", "DE", 48.690, 9.222},
+	{"EDDV", "HAJ", "Hannover", "Hannover", "DE", 52.461, 9.685},
+	{"EDDW", "BRE", "Bremen", "Bremen", "DE", 53.048, 8.787},
+
+	// Europe
+	{"EBBR", "BRU", "Brussels", "Brussels", "BE", 50.901, 4.484},
+	{"EFHK", "HEL", "Helsinki-Vantaa", "Helsinki", "FI", 60.317, 24.963},
+	{"EGCC", "MAN", "Manchester", "Manchester", "GB", 53.354, -2.275},
+	{"EGKK", "LGW", "London Gatwick", "London", "GB", 51.148, -0.190},
+	{"EGLL", "LHR", "London Heathrow", "London", "GB", 51.471, -0.461},
+	{"EGPH", "EDI", "Edinburgh", "Edinburgh", "GB", 55.950, -3.373},
+	{"EHAM", "AMS", "Amsterdam Schiphol", "Amsterdam", "NL", 52.309, 4.764},
+	{"EIDW", "DUB", "Dublin", "Dublin", "IE", 53.421, -6.270},
+	{"EKCH", "CPH", "Copenhagen Kastrup", "Copenhagen", "DK", 55.618, 12.656},
+	{"ENGM", "OSL", "Oslo Gardermoen", "Oslo", "NO", 60.194, 11.100},
+	{"EPWA", "WAW", "Warsaw Chopin", "Warsaw", "PL", 52.166, 20.967},
+	{"ESSA", "ARN", "Stockholm Arlanda", "Stockholm", "SE", 59.652, 17.919},
+	{"LEBL", "BCN", "Barcelona El Prat", "Barcelona", "ES", 41.297, 2.078},
+	{"LEMD", "MAD", "Madrid Barajas", "Madrid", "ES", 40.472, -3.561},
+	{"LEPA", "PMI", "Palma de Mallorca", "Palma", "ES", 39.552, 2.739},
+	{"LFMN", "NCE", "Nice Côte d'Azur", "Nice", "FR", 43.658, 7.216},
+	{"LFPG", "CDG", "Paris Charles de Gaulle", "Paris", "FR", 49.010, 2.548},
+	{"LFPO", "ORY", "Paris Orly", "Paris", "FR", 48.723, 2.379},
+	{"LGAV", "ATH", "Athens Eleftherios Venizelos", "Athens", "GR", 37.936, 23.944},
+	{"LHBP", "BUD", "Budapest Liszt Ferenc", "Budapest", "HU", 47.439, 19.262},
+	{"LIMC", "MXP", "Milano Malpensa", "Milano", "IT", 45.630, 8.723},
+	{"LIRF", "FCO", "Roma Fiumicino", "Roma", "IT", 41.800, 12.239},
+	{"LKPR", "PRG", "Prague Václav Havel", "Prague", "CZ", 50.101, 14.260},
+	{"LOWW", "VIE", "Wien-Schwechat", "Wien", "AT", 48.110, 16.570},
+	{"LPPT", "LIS", "Lisboa Humberto Delgado", "Lisboa", "PT", 38.781, -9.136},
+	{"LSGG", "GVA", "Genève", "Genève", "CH", 46.238, 6.109},
+	{"LSZH", "ZRH", "Zürich", "Zürich", "CH", 47.465, 8.549},
+	{"LTFM", "IST", "Istanbul", "Istanbul", "TR", 41.262, 28.742},
+
+	// Asia, Middle East and Oceania
+	{"NZAA", "AKL", "Auckland", "Auckland", "NZ", -37.008, 174.792},
+	{"OMDB", "DXB", "Dubai International", "Dubai", "AE", 25.253, 55.364},
+	{"OTHH", "DOH", "Hamad International", "Doha", "QA", 25.273, 51.608},
+	{"RCTP", "TPE", "Taiwan Taoyuan", "Taipei", "TW", 25.077, 121.233},
+	{"RJAA", "NRT", "Narita International", "Tokyo", "JP", 35.765, 140.386},
+	{"RJBB", "KIX", "Kansai International", "Osaka", "JP", 34.427, 135.244},
+	{"RJTT", "HND", "Tokyo Haneda", "Tokyo", "JP", 35.552, 139.780},
+	{"RKSI", "ICN", "Incheon International", "Seoul", "KR", 37.463, 126.440},
+	{"VHHH", "HKG", "Hong Kong International", "Hong Kong", "HK", 22.309, 113.915},
+	{"VIDP", "DEL", "Indira Gandhi International", "Delhi", "IN", 28.566, 77.103},
+	{"VTBS", "BKK", "Suvarnabhumi", "Bangkok", "TH", 13.681, 100.747},
+	{"WSSS", "SIN", "Singapore Changi", "Singapore", "SG", 1.350, 103.994},
+	{"YMML", "MEL", "Melbourne", "Melbourne", "AU", -37.673, 144.843},
+	{"YSSY", "SYD", "Sydney Kingsford Smith", "Sydney", "AU", -33.946, 151.177},
+	{"ZBAA", "PEK", "Beijing Capital", "Beijing", "CN", 40.080, 116.585},
+	{"ZSPD", "PVG", "Shanghai Pudong", "Shanghai", "CN", 31.143, 121.805},
+
+	// Africa and the Americas outside the US
+	{"CYUL", "YUL", "Montréal-Trudeau", "Montréal", "CA", 45.470, -73.741},
+	{"CYVR", "YVR", "Vancouver International", "Vancouver", "CA", 49.195, -123.184},
+	{"CYYC", "YYC", "Calgary International", "Calgary", "CA", 51.131, -114.010},
+	{"CYYZ", "YYZ", "Toronto Pearson", "Toronto", "CA", 43.677, -79.631},
+	{"FACT", "CPT", "Cape Town International", "Cape Town", "ZA", -33.965, 18.602},
+	{"FAOR", "JNB", "O. R. Tambo International", "Johannesburg", "ZA", -26.139, 28.246},
+	{"HECA", "CAI", "Cairo International", "Cairo", "EG", 30.122, 31.406},
+	{"MMMX", "MEX", "Mexico City International", "Mexico City", "MX", 19.436, -99.072},
+	{"SAEZ", "EZE", "Ministro Pistarini", "Buenos Aires", "AR", -34.822, -58.536},
+	{"SBGR", "GRU", "São Paulo Guarulhos", "São Paulo", "BR", -23.432, -46.470},
+	{"SCEL", "SCL", "Arturo Merino Benítez", "Santiago", "CL", -33.393, -70.786},
+	{"SKBO", "BOG", "El Dorado", "Bogotá", "CO", 4.702, -74.147},
+	{"SPJC", "LIM", "Jorge Chávez", "Lima", "PE", -12.022, -77.114},
+
+	// United States
+	{"KATL", "ATL", "Hartsfield-Jackson Atlanta", "Atlanta", "US", 33.637, -84.428},
+	{"KBOS", "BOS", "Boston Logan", "Boston", "US", 42.364, -71.005},
+	{"KDEN", "DEN", "Denver International", "Denver", "US", 39.862, -104.673},
+	{"KDFW", "DFW", "Dallas/Fort Worth", "Dallas", "US", 32.897, -97.038},
+	{"KJFK", "JFK", "John F. Kennedy", "New York", "US", 40.640, -73.779},
+	{"KLAS", "LAS", "Harry Reid", "Las Vegas", "US", 36.080, -115.152},
+	{"KLAX", "LAX", "Los Angeles International", "Los Angeles", "US", 33.943, -118.408},
+	{"KLGA", "LGA", "LaGuardia", "New York", "US", 40.777, -73.873},
+	{"KMIA", "MIA", "Miami International", "Miami", "US", 25.793, -80.291},
+	{"KORD", "ORD", "Chicago O'Hare", "Chicago", "US", 41.979, -87.904},
+	{"KSEA", "SEA", "Seattle-Tacoma", "Seattle", "US", 47.449, -122.309},
+	{"KSFO", "SFO", "San Francisco International", "San Francisco", "US", 37.619, -122.375},
+	{"PAFA", "FAI", "Fairbanks International", "Fairbanks", "US", 64.815, -147.856},
+	{"PAJN", "JNU", "Juneau International", "Juneau", "US", 58.355, -134.576},
+	{"PANC", "ANC", "Ted Stevens Anchorage", "Anchorage", "US", 61.174, -149.998},
+	{"PGUM", "GUM", "Antonio B. Won Pat", "Hagåtña", "GU", 13.484, 144.796},
+	{"PHKO", "KOA", "Ellison Onizuka Kona", "Kailua-Kona", "US", 19.739, -156.046},
+	{"PHLI", "LIH", "Lihue", "Lihue", "US", 21.976, -159.339},
+	{"PHNL", "HNL", "Daniel K. Inouye", "Honolulu", "US", 21.318, -157.922},
+	{"PHOG", "OGG", "Kahului", "Kahului", "US", 20.899, -156.430},
+	{"PHTO", "ITO", "Hilo International", "Hilo", "US", 19.721, -155.048},
+	{"TJSJ", "SJU", "Luis Muñoz Marín", "San Juan", "PR", 18.439, -66.002},
+}
@@ -0,0 +1,93 @@
+package metar_test
+
+import (
+	"context"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Station aliases", func() {
+
+	It("should resolve station identifiers", func() {
+		Expect(ResolveStation("HAM")).To(Equal("EDDH"))
+		Expect(ResolveStation("lhr")).To(Equal("EGLL"))
+		Expect(ResolveStation("JFK")).To(Equal("KJFK"))
+		Expect(ResolveStation("1O5")).To(Equal("K1O5"))
+		Expect(ResolveStation("ANC")).To(Equal("PANC"))
+		Expect(ResolveStation("eddh")).To(Equal("EDDH"))
+	})
+
+	It("should query resolved stations when enabled", func() {
+		src := &staticSource{name: "static", results: []Result{{StationID: "EDDH"}, {StationID: "KJFK"}}}
+		client := NewClient(WithSource(src), WithStationAliases(nil))
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "HAM")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.StationID).To(Equal("EDDH"))
+
+		results, err := client.FetchStationsWeather(context.Background(), []string{"ham", "JFK"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+		Expect(src.queries[1].Stations).To(Equal([]string{"EDDH", "KJFK"}))
+	})
+
+	It("should prefer custom aliases", func() {
+		src := &staticSource{name: "static", results: []Result{{StationID: "EDHL"}}}
+		client := NewClient(WithSource(src), WithStationAliases(map[string]string{"lbc": "edhl"}))
+
+		r, err := client.FetchCurrentStationWeather(context.Background(), "LBC")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.StationID).To(Equal("EDHL"))
+	})
+
+	It("should not resolve stations by default", func() {
+		src := &staticSource{name: "static"}
+		NewClient(WithSource(src)).FetchCurrentStationWeather(context.Background(), "HAM")
+		Expect(src.queries[0].Stations).To(Equal([]string{"HAM"}))
+	})
+
+})
+
+var _ = Describe("Station search", func() {
+
+	icaos := func(stations []StationInfo) []string {
+		var out []string
+		for _, s := range stations {
+			out = append(out, s.ICAO)
+		}
+		return out
+	}
+
+	It("should find stations by city", func() {
+		stations, err := SearchStations("hamburg")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(icaos(stations)).To(Equal([]string{"EDDH", "EDHI"}))
+	})
+
+	It("should require all words to match", func() {
+		stations, err := SearchStations("Hamburg Finkenwerder")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(icaos(stations)).To(Equal([]string{"EDHI"}))
+	})
+
+	It("should ignore diacritics", func() {
+		stations, err := SearchStations("Zurich")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(icaos(stations)).To(Equal([]string{"LSZH"}))
+	})
+
+	It("should rank identifier matches first", func() {
+		stations, err := SearchStations("lax")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(icaos(stations)[0]).To(Equal("KLAX"))
+	})
+
+	It("should reject empty queries", func() {
+		_, err := SearchStations("  ")
+		Expect(err).To(Equal(ErrEmptySearch))
+	})
+
+})
@@ -0,0 +1,75 @@
+package metar
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// cacheKeyPrefix is prepended to the station identifiers to form the keys
+// of the results within the Cache
+const cacheKeyPrefix = "metar:"
+
+// resultStore keeps the last successfully fetched result per station in
+// the configured Cache
+type resultStore struct {
+	cache Cache
+	ttl   time.Duration
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+}
+
+func newResultStore() *resultStore {
+	return &resultStore{
+		cache:      NewMemoryCache(),
+		refreshing: make(map[string]bool),
+	}
+}
+
+func (s *resultStore) get(ctx context.Context, key string) (*Result, bool, error) {
+	value, ok, err := s.cache.Get(ctx, cacheKeyPrefix+key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	var r Result
+	if err := json.Unmarshal(value, &r); err != nil {
+		return nil, false, err
+	}
+	return &r, true, nil
+}
+
+// set stores the result unless a newer observation is already known
+func (s *resultStore) set(ctx context.Context, key string, r Result) error {
+	if known, ok, err := s.get(ctx, key); err == nil && ok && known.ObservationTime.After(r.ObservationTime) {
+		return nil
+	}
+
+	value, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, cacheKeyPrefix+key, value, s.ttl)
+}
+
+// startRefresh marks a background refresh for the key as running and
+// reports false if there already is one
+func (s *resultStore) startRefresh(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refreshing[key] {
+		return false
+	}
+	s.refreshing[key] = true
+	return true
+}
+
+func (s *resultStore) endRefresh(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.refreshing, key)
+}
@@ -0,0 +1,179 @@
+package metar
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// StreamingSource is implemented by sources able to decode their results
+// one at a time while iterating
+type StreamingSource interface {
+	Source
+	Stream(ctx context.Context, q Query) (*ResultIterator, error)
+}
+
+// ResultIterator returns results one at a time. Results of dataserver XML
+// responses are decoded on every call to Next so only a single result is
+// held in memory at once.
+type ResultIterator struct {
+	dec        *xml.Decoder
+	body       io.Closer
+	prepare    func(*Result)
+	numResults int
+	count      int
+	maxResults int
+	logger     *slog.Logger
+
+	results []Result
+	err     error
+	lenient bool
+}
+
+// NewResultIterator creates an iterator over the METAR elements of a
+// dataserver XML response
+func NewResultIterator(r io.Reader) *ResultIterator {
+	return &ResultIterator{dec: newXMLDecoder(r)}
+}
+
+// setLenient switches the iterator to lenient decoding (see WithLenientXML)
+func (it *ResultIterator) setLenient() {
+	it.lenient = true
+	makeLenient(it.dec)
+}
+
+// Next returns the next result or io.EOF after the last result. The
+// number of results is validated against the count reported by the
+// response when the end is reached.
+func (it *ResultIterator) Next() (*Result, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+
+	if it.dec == nil {
+		if it.count >= len(it.results) {
+			it.err = io.EOF
+			return nil, it.err
+		}
+		it.count++
+		return &it.results[it.count-1], nil
+	}
+
+	r, err := it.decode()
+	if err != nil {
+		it.err = err
+		return nil, err
+	}
+	return r, nil
+}
+
+func (it *ResultIterator) decode() (*Result, error) {
+	for {
+		tok, err := it.dec.Token()
+		if err == io.EOF {
+			if it.numResults != it.count {
+				if it.logger != nil {
+					it.logger.Warn("inconsistent response", "num_results", it.numResults, "delivered", it.count)
+				}
+				return nil, ErrInconsistentResults
+			}
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch se.Name.Local {
+		case "data":
+			for _, a := range se.Attr {
+				if a.Name.Local == "num_results" {
+					if it.numResults, err = strconv.Atoi(a.Value); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+		case "METAR":
+			if it.maxResults > 0 && it.count >= it.maxResults {
+				return nil, ErrTooManyResults
+			}
+
+			r := &Result{}
+			if err := r.decodeXML(it.dec, se, it.lenient); err != nil {
+				return nil, err
+			}
+			if it.prepare != nil {
+				it.prepare(r)
+			}
+			it.count++
+			return r, nil
+		}
+	}
+}
+
+// Close releases the underlying response
+func (it *ResultIterator) Close() error {
+	if it.body == nil {
+		return nil
+	}
+	return it.body.Close()
+}
+
+// all collects the remaining results into a slice
+func (it *ResultIterator) all() ([]Result, error) {
+	var results []Result
+	for {
+		r, err := it.Next()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *r)
+	}
+}
+
+// Stream executes the query and returns an iterator over its results. If
+// the Source is a StreamingSource results are decoded while iterating,
+// bypassing the deduplication, caching and circuit breaker of the client.
+// Other sources are queried like Fetch.
+func (c *Client) Stream(ctx context.Context, q Query) (*ResultIterator, error) {
+	s, ok := c.source.(StreamingSource)
+	if !ok {
+		results, err := c.query(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		return &ResultIterator{results: results}, nil
+	}
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	it, err := s.Stream(withRequestContext(ctx, c, &FetchInfo{}), q)
+	if err != nil {
+		return nil, err
+	}
+
+	source, prepare, now := SourceName(s), it.prepare, time.Now().UTC().Round(0)
+	it.prepare = func(r *Result) {
+		if prepare != nil {
+			prepare(r)
+		}
+		r.Source = source
+		r.FetchedAt = now
+	}
+	return it, nil
+}
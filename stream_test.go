@@ -0,0 +1,117 @@
+package metar_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream", func() {
+
+	It("should decode results while iterating", func() {
+		var query url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			http.ServeFile(w, r, filepath.Join("testdata", "multi.xml"))
+		}))
+		defer server.Close()
+
+		client := NewClient(WithBaseURL(server.URL))
+		it, err := client.Stream(context.Background(), Query{
+			Selection: AllReports,
+			Area:      &BoundingBox{MinLat: 47, MinLon: 5, MaxLat: 55, MaxLon: 15},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer it.Close()
+
+		Expect(query.Get("minLat")).To(Equal("47"))
+		Expect(query.Get("maxLon")).To(Equal("15"))
+		Expect(query.Get("stationString")).To(Equal(""))
+
+		var stations []string
+		for {
+			r, err := it.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Source).To(Equal("adds"))
+			Expect(r.StatusCode).To(Equal(http.StatusOK))
+			stations = append(stations, r.StationID)
+		}
+		Expect(stations).To(Equal([]string{"EDDH", "EDDF"}))
+
+		_, err = it.Next()
+		Expect(err).To(Equal(io.EOF))
+	})
+
+	It("should detect inconsistent result counts at the end", func() {
+		it := NewResultIterator(strings.NewReader(`<response><data num_results="2"><METAR><station_id>EDDH</station_id></METAR></data></response>`))
+
+		r, err := it.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.StationID).To(Equal("EDDH"))
+
+		_, err = it.Next()
+		Expect(err).To(Equal(ErrInconsistentResults))
+	})
+
+	It("should iterate results of other sources", func() {
+		src := &staticSource{name: "static", results: []Result{{StationID: "EDDH"}, {StationID: "EDDW"}}}
+		it, err := NewClient(WithSource(src)).Stream(context.Background(), Query{Stations: []string{"EDDH", "EDDW"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		r, err := it.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.StationID).To(Equal("EDDH"))
+		r, err = it.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.StationID).To(Equal("EDDW"))
+		_, err = it.Next()
+		Expect(err).To(Equal(io.EOF))
+		Expect(it.Close()).To(Succeed())
+	})
+
+})
+
+// benchAreaXML returns a dataserver response with n results
+func benchAreaXML(n int) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<?xml version="1.0" encoding="UTF-8"?><response><data num_results="%d">`, n)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, `<METAR><raw_text>%s</raw_text><station_id>%s</station_id>`+
+			`<observation_time>2016-05-21T10:20:00Z</observation_time><latitude>53.63</latitude><longitude>9.98</longitude>`+
+			`<temp_c>17.0</temp_c><dewpoint_c>9.0</dewpoint_c><wind_dir_degrees>270</wind_dir_degrees><wind_speed_kt>8</wind_speed_kt>`+
+			`<visibility_statute_mi>6.21</visibility_statute_mi><altim_in_hg>30.06</altim_in_hg>`+
+			`<sky_condition sky_cover="FEW" cloud_base_ft_agl="3000"/><flight_category>VFR</flight_category>`+
+			`<metar_type>METAR</metar_type><elevation_m>15.0</elevation_m></METAR>`,
+			benchReports[i%len(benchReports)], benchReports[i%len(benchReports)][:4])
+	}
+	sb.WriteString(`</data></response>`)
+	return sb.String()
+}
+
+func BenchmarkDecodeXMLArea(b *testing.B) {
+	doc := benchAreaXML(1000)
+	b.SetBytes(int64(len(doc)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		results, err := DecodeXML(strings.NewReader(doc))
+		if err != nil || len(results) != 1000 {
+			b.Fatal(len(results), err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package metar
+
+// Weather glyphs returned by Result.Emoji
+const (
+	EmojiClear        = "☀️"
+	EmojiClearNight   = "🌙"
+	EmojiFewClouds    = "🌤️"
+	EmojiScattered    = "⛅"
+	EmojiBroken       = "🌥️"
+	EmojiOvercast     = "☁️"
+	EmojiShowers      = "🌦️"
+	EmojiRain         = "🌧️"
+	EmojiSnow         = "❄️"
+	EmojiThunderstorm = "⛈️"
+	EmojiFog          = "🌫️"
+	EmojiWind         = "💨"
+	EmojiTornado      = "🌪️"
+)
+
+// Wind speeds (knots) shown as EmojiWind when no weather is reported
+const (
+	emojiWindSpeed = 25
+	emojiWindGust  = 35
+)
+
+// cloudCoverRank orders the layer covers of the raw report by amount
+var cloudCoverRank = map[string]int{"FEW": 1, "SCT": 2, "BKN": 3, "OVC": 4, "VV": 4}
+
+// Emoji returns a single glyph summarizing the conditions for chatbots and
+// compact dashboards. Weather at the station takes precedence over strong
+// winds which take precedence over the cloud cover.
+func (r Result) Emoji() string {
+	weather := r.Weather()
+	has := func(codes ...string) bool {
+		for _, w := range weather {
+			for _, c := range codes {
+				if !w.Vicinity && (w.Has(c) || w.Descriptor == c) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	cover := r.cloudCover()
+	switch {
+	case has("TS"):
+		return EmojiThunderstorm
+	case has("FC"):
+		return EmojiTornado
+	case has("SN", "SG", "PL", "IC"):
+		return EmojiSnow
+	case has("RA", "DZ", "GR", "GS", "UP"):
+		if cover < cloudCoverRank["BKN"] {
+			return EmojiShowers
+		}
+		return EmojiRain
+	case has("FG", "BR", "HZ", "FU", "DU", "SA", "VA"):
+		return EmojiFog
+	case r.WindSpeed >= emojiWindSpeed || r.WindGust >= emojiWindGust:
+		return EmojiWind
+	}
+
+	switch cover {
+	case 0, 1:
+		if r.Daylight() == DaylightNight {
+			return EmojiClearNight
+		}
+		if cover == 0 {
+			return EmojiClear
+		}
+		return EmojiFewClouds
+	case 2:
+		return EmojiScattered
+	case 3:
+		return EmojiBroken
+	default:
+		return EmojiOvercast
+	}
+}
+
+// cloudCover returns the rank of the largest cloud cover of the raw report
+// falling back to the reported sky cover
+func (r Result) cloudCover() int {
+	cover := -1
+	for _, l := range r.CloudLayers() {
+		if rank := cloudCoverRank[l.group()]; rank > cover {
+			cover = rank
+		}
+	}
+
+	if cover < 0 {
+		cover = cloudCoverRank[string(r.SkyCondition.SkyCover)]
+		if r.SkyCondition.SkyCover == SkyCoverOVX {
+			cover = cloudCoverRank["OVC"]
+		}
+	}
+	return cover
+}
+
+// WMOWeatherCode returns the present weather code (ww, WMO code table
+// 4677) of the weather group, 0 is returned for groups without a code
+func WMOWeatherCode(w WeatherPhenomenon) int {
+	heavy := w.Intensity == "+"
+	light := w.Intensity == "-"
+	grade := func(l, m, h int) int {
+		switch {
+		case light:
+			return l
+		case heavy:
+			return h
+		}
+		return m
+	}
+	precip := w.Has("RA") || w.Has("DZ") || w.Has("SN") || w.Has("GR") || w.Has("GS") || w.Has("PL") || w.Has("SG") || w.Has("UP")
+
+	switch {
+	case w.Descriptor == "TS" && w.Vicinity:
+		return 13
+	case w.Descriptor == "TS" && (w.Has("SS") || w.Has("DS")):
+		return 98
+	case w.Descriptor == "TS" && (w.Has("GR") || w.Has("GS")):
+		return grade(96, 96, 99)
+	case w.Descriptor == "TS" && precip:
+		return grade(95, 95, 97)
+	case w.Descriptor == "TS":
+		return 17
+	case w.Has("FC"):
+		return 19
+	case w.Has("SQ"):
+		return 18
+	case w.Descriptor == "SH" && (w.Has("GR") || w.Has("GS")):
+		if w.Has("GR") {
+			return grade(89, 89, 90)
+		}
+		return grade(87, 87, 88)
+	case w.Descriptor == "SH" && w.Has("RA") && w.Has("SN"):
+		return grade(83, 83, 84)
+	case w.Descriptor == "SH" && w.Has("SN"):
+		return grade(85, 85, 86)
+	case w.Descriptor == "SH":
+		return grade(80, 81, 82)
+	case w.Descriptor == "FZ" && w.Has("DZ"):
+		return grade(56, 57, 57)
+	case w.Descriptor == "FZ" && w.Has("RA"):
+		return grade(66, 67, 67)
+	case w.Has("RA") && w.Has("SN"):
+		return grade(68, 69, 69)
+	case w.Has("SN") && w.Descriptor == "DR":
+		return grade(36, 36, 37)
+	case w.Has("SN") && w.Descriptor == "BL":
+		return grade(38, 38, 39)
+	case w.Has("PL"):
+		return 79
+	case w.Has("SG"):
+		return 77
+	case w.Has("IC"):
+		return 76
+	case w.Has("SN"):
+		return grade(71, 73, 75)
+	case w.Has("RA"):
+		return grade(61, 63, 65)
+	case w.Has("DZ"):
+		return grade(51, 53, 55)
+	case w.Has("FG") && w.Descriptor == "FZ":
+		return 49
+	case w.Has("FG") && w.Vicinity:
+		return 40
+	case w.Has("FG") && (w.Descriptor == "BC" || w.Descriptor == "PR"):
+		return 41
+	case w.Has("FG") && w.Descriptor == "MI":
+		return 11
+	case w.Has("FG"):
+		return 45
+	case w.Has("SS") || w.Has("DS"):
+		return grade(31, 31, 34)
+	case w.Has("BR"):
+		return 10
+	case w.Has("PO"):
+		return 8
+	case w.Has("SA") || (w.Has("DU") && w.Descriptor == "BL"):
+		return 7
+	case w.Has("DU"):
+		return 6
+	case w.Has("HZ"):
+		return 5
+	case w.Has("FU") || w.Has("VA"):
+		return 4
+	}
+	return 0
+}
+
+// WMOWeatherCode returns the present weather code (ww, WMO code table
+// 4677) of the report. When several groups are reported the highest code
+// is returned as the table is ordered by significance.
+func (r Result) WMOWeatherCode() int {
+	var code int
+	for _, w := range r.Weather() {
+		if c := WMOWeatherCode(w); c > code {
+			code = c
+		}
+	}
+	return code
+}
@@ -0,0 +1,58 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Symbols", func() {
+
+	DescribeTable("should map the conditions to an emoji",
+		func(raw, expected string) {
+			r, err := ParseRaw(raw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Emoji()).To(Equal(expected))
+		},
+		Entry("clear", "EDDH 211020Z 27008KT 9999 SKC 17/09 Q1018", EmojiClear),
+		Entry("few", "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018", EmojiFewClouds),
+		Entry("overcast", "EDDH 211020Z 27008KT 9999 SCT020 OVC040 17/09 Q1018", EmojiOvercast),
+		Entry("showers", "EDDH 211020Z 27008KT 9999 -SHRA SCT030 17/09 Q1018", EmojiShowers),
+		Entry("rain", "EDDH 211020Z 27008KT 6000 RA OVC010 17/09 Q1018", EmojiRain),
+		Entry("snow", "EDDH 211020Z 27008KT 2000 SN OVC010 M02/M03 Q1018", EmojiSnow),
+		Entry("thunderstorm", "EDDH 211020Z 27008KT 9999 TSRA FEW030CB 17/09 Q1018", EmojiThunderstorm),
+		Entry("fog", "EDDH 211020Z 00000KT 0200 FG VV001 07/07 Q1018", EmojiFog),
+		Entry("wind", "EDDH 211020Z 27028G40KT 9999 SCT030 17/09 Q1018", EmojiWind),
+		Entry("vicinity only", "EDDH 211020Z 27008KT 9999 VCSH FEW030 17/09 Q1018", EmojiFewClouds),
+	)
+
+	DescribeTable("should map weather groups to WMO codes",
+		func(group string, expected int) {
+			w, ok := ParseWeather(group)
+			Expect(ok).To(BeTrue())
+			Expect(WMOWeatherCode(w)).To(Equal(expected))
+		},
+		Entry("light rain", "-RA", 61),
+		Entry("heavy rain", "+RA", 65),
+		Entry("moderate showers", "SHRA", 81),
+		Entry("heavy snow showers", "+SHSN", 86),
+		Entry("freezing drizzle", "-FZDZ", 56),
+		Entry("fog", "FG", 45),
+		Entry("freezing fog", "FZFG", 49),
+		Entry("mist", "BR", 10),
+		Entry("thunderstorm with rain", "TSRA", 95),
+		Entry("heavy thunderstorm with hail", "+TSGR", 99),
+		Entry("thunderstorm in the vicinity", "VCTS", 13),
+		Entry("blowing snow", "BLSN", 38),
+	)
+
+	It("should report the most significant code of the result", func() {
+		r, err := ParseRaw("EDDH 211020Z 27008KT 3000 -RA BR OVC010 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.WMOWeatherCode()).To(Equal(61))
+		Expect((Result{}).WMOWeatherCode()).To(Equal(0))
+	})
+
+})
@@ -0,0 +1,81 @@
+package metar
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// PressureTendency describes the pressure change over the last three hours
+// using the terms of the WMO/Met Office classification
+type PressureTendency string
+
+// Pressure tendencies ordered by the magnitude of the change
+const (
+	PressureSteady             PressureTendency = "steady"              // Less than 0.1 hPa
+	PressureRisingSlowly       PressureTendency = "rising slowly"       // 0.1 to 1.5 hPa
+	PressureRising             PressureTendency = "rising"              // 1.6 to 3.5 hPa
+	PressureRisingQuickly      PressureTendency = "rising quickly"      // 3.6 to 6.0 hPa
+	PressureRisingVeryRapidly  PressureTendency = "rising very rapidly" // More than 6.0 hPa
+	PressureFallingSlowly      PressureTendency = "falling slowly"
+	PressureFalling            PressureTendency = "falling"
+	PressureFallingQuickly     PressureTendency = "falling quickly"
+	PressureFallingVeryRapidly PressureTendency = "falling very rapidly"
+)
+
+var pressureTendencyRegex = regexp.MustCompile(`^5([0-8])(\d{3})$`)
+
+// ClassifyPressureTendency converts the pressure change over three hours
+// in hPa (for example from StationHistory.PressureTendency or
+// Result.PressureChange) into its tendency
+func ClassifyPressureTendency(change float64) PressureTendency {
+	rising := change > 0
+	if change < 0 {
+		change = -change
+	}
+
+	// Values are reported in tenths of hPa, round to avoid float artifacts
+	tenths := int(change*10 + 0.5)
+	switch {
+	case tenths < 1:
+		return PressureSteady
+	case tenths <= 15 && rising:
+		return PressureRisingSlowly
+	case tenths <= 15:
+		return PressureFallingSlowly
+	case tenths <= 35 && rising:
+		return PressureRising
+	case tenths <= 35:
+		return PressureFalling
+	case tenths <= 60 && rising:
+		return PressureRisingQuickly
+	case tenths <= 60:
+		return PressureFallingQuickly
+	case rising:
+		return PressureRisingVeryRapidly
+	default:
+		return PressureFallingVeryRapidly
+	}
+}
+
+// PressureChange returns the three hour pressure change in hPa and the WMO
+// characteristic (code table 0200) reported in the 5appp remark group
+func (r Result) PressureChange() (change float64, characteristic int, ok bool) {
+	for _, g := range r.Remarks() {
+		m := pressureTendencyRegex.FindStringSubmatch(g)
+		if m == nil {
+			continue
+		}
+
+		characteristic, _ = strconv.Atoi(m[1])
+		ppp, _ := strconv.Atoi(m[2])
+		change = float64(ppp) / 10
+		switch {
+		case characteristic == 4:
+			change = 0
+		case characteristic > 4:
+			change = -change
+		}
+		return change, characteristic, true
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,41 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PressureTendency", func() {
+
+	table.DescribeTable("classification",
+		func(change float64, expected PressureTendency) {
+			Expect(ClassifyPressureTendency(change)).To(Equal(expected))
+		},
+		table.Entry("steady", 0.04, PressureSteady),
+		table.Entry("rising slowly", 1.5, PressureRisingSlowly),
+		table.Entry("rising", 1.6, PressureRising),
+		table.Entry("rising quickly", 4.2, PressureRisingQuickly),
+		table.Entry("rising very rapidly", 6.1, PressureRisingVeryRapidly),
+		table.Entry("falling slowly", -0.1, PressureFallingSlowly),
+		table.Entry("falling", -2.4, PressureFalling),
+		table.Entry("falling quickly", -6.0, PressureFallingQuickly),
+		table.Entry("falling very rapidly", -8.3, PressureFallingVeryRapidly),
+	)
+
+	table.DescribeTable("remark group",
+		func(raw string, change float64, characteristic int, found bool) {
+			c, a, ok := Result{RawText: raw}.PressureChange()
+			Expect(ok).To(Equal(found))
+			Expect(c).To(Equal(change))
+			Expect(a).To(Equal(characteristic))
+		},
+		table.Entry("rising", "KBOS 210954Z 27015KT 10SM BKN030 10/09 A2994 RMK AO2 SLP138 52018", 1.8, 2, true),
+		table.Entry("falling", "KBOS 210954Z 27015KT 10SM BKN030 10/09 A2994 RMK AO2 SLP138 57024", -2.4, 7, true),
+		table.Entry("steady", "KBOS 210954Z 27015KT 10SM BKN030 10/09 A2994 RMK AO2 54000", 0.0, 4, true),
+		table.Entry("missing", "KBOS 210954Z 27015KT 10SM BKN030 10/09 A2994 RMK AO2", 0.0, 0, false),
+	)
+
+})
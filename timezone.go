@@ -0,0 +1,96 @@
+package metar
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// TimezoneLookup resolves the time zone of a station position. It
+// defaults to NearestTimezone and can be replaced by a lookup using time
+// zone boundaries for exact results near borders.
+var TimezoneLookup = NearestTimezone
+
+// maxTimezoneDistanceKm is the distance to the nearest reference point
+// above which NearestTimezone falls back to the nautical time zone
+const maxTimezoneDistanceKm = 1500
+
+type timezoneReference struct {
+	lat, lon float64
+	name     string
+}
+
+var (
+	locationCache   = make(map[string]*time.Location)
+	locationCacheMu sync.Mutex
+)
+
+// NearestTimezone returns the IANA time zone of the reference city
+// nearest to the position. Positions far off any reference (e.g. on
+// oceans) or zones unknown to the time zone database of the system get
+// the nautical time zone derived from the longitude as a fixed zone named
+// "UTC±N" (the zone Etc/GMT∓N with its inverted sign). Import time/tzdata
+// on systems without time zone database.
+func NearestTimezone(lat, lon float64) *time.Location {
+	var (
+		best     string
+		bestDist = math.Inf(1)
+	)
+	for _, ref := range timezoneReferences {
+		if d := greatCircleKm(lat, lon, ref.lat, ref.lon); d < bestDist {
+			best, bestDist = ref.name, d
+		}
+	}
+
+	if bestDist <= maxTimezoneDistanceKm {
+		if loc := loadLocation(best); loc != nil {
+			return loc
+		}
+	}
+
+	offset := int(math.Round(lon / 15))
+	return time.FixedZone(fmt.Sprintf("UTC%+d", offset), offset*3600)
+}
+
+func loadLocation(name string) *time.Location {
+	locationCacheMu.Lock()
+	defer locationCacheMu.Unlock()
+
+	if loc, ok := locationCache[name]; ok {
+		return loc
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = nil
+	}
+	locationCache[name] = loc
+	return loc
+}
+
+// greatCircleKm returns the distance between two positions in kilometers
+func greatCircleKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0088
+
+	rad := math.Pi / 180
+	dLat, dLon := (lat2-lat1)*rad, (lon2-lon1)*rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Location returns the time zone of the station or UTC if the station
+// position is not reported
+func (r Result) Location() *time.Location {
+	if !r.hasPosition() {
+		return time.UTC
+	}
+	return TimezoneLookup(r.Latitude, r.Longitude)
+}
+
+// ObservationTimeLocal returns the observation time in the time zone of
+// the station
+func (r Result) ObservationTimeLocal() time.Time {
+	return r.ObservationTime.In(r.Location())
+}
@@ -0,0 +1,306 @@
+package metar
+
+// timezoneReferences lists a representative position for the time zones
+// used by NearestTimezone. Countries spanning multiple zones have multiple
+// entries along their zone boundaries.
+var timezoneReferences = []timezoneReference{
+	// Europe
+	{51.51, -0.13, "Europe/London"},
+	{53.35, -6.26, "Europe/Dublin"},
+	{38.72, -9.14, "Europe/Lisbon"},
+	{40.42, -3.70, "Europe/Madrid"},
+	{48.86, 2.35, "Europe/Paris"},
+	{50.85, 4.35, "Europe/Brussels"},
+	{52.37, 4.90, "Europe/Amsterdam"},
+	{49.61, 6.13, "Europe/Luxembourg"},
+	{52.52, 13.40, "Europe/Berlin"},
+	{53.55, 9.99, "Europe/Berlin"},
+	{48.14, 11.58, "Europe/Berlin"},
+	{47.37, 8.54, "Europe/Zurich"},
+	{48.21, 16.37, "Europe/Vienna"},
+	{41.90, 12.50, "Europe/Rome"},
+	{35.90, 14.51, "Europe/Malta"},
+	{55.68, 12.57, "Europe/Copenhagen"},
+	{59.91, 10.75, "Europe/Oslo"},
+	{69.65, 18.96, "Europe/Oslo"},
+	{59.33, 18.07, "Europe/Stockholm"},
+	{60.17, 24.94, "Europe/Helsinki"},
+	{59.44, 24.75, "Europe/Tallinn"},
+	{56.95, 24.11, "Europe/Riga"},
+	{54.69, 25.28, "Europe/Vilnius"},
+	{52.23, 21.01, "Europe/Warsaw"},
+	{50.08, 14.44, "Europe/Prague"},
+	{48.15, 17.11, "Europe/Bratislava"},
+	{47.50, 19.04, "Europe/Budapest"},
+	{46.06, 14.51, "Europe/Ljubljana"},
+	{45.81, 15.98, "Europe/Zagreb"},
+	{44.79, 20.45, "Europe/Belgrade"},
+	{43.86, 18.41, "Europe/Sarajevo"},
+	{41.33, 19.82, "Europe/Tirane"},
+	{41.99, 21.43, "Europe/Skopje"},
+	{42.70, 23.32, "Europe/Sofia"},
+	{44.43, 26.10, "Europe/Bucharest"},
+	{47.01, 28.86, "Europe/Chisinau"},
+	{50.45, 30.52, "Europe/Kyiv"},
+	{53.90, 27.57, "Europe/Minsk"},
+	{37.98, 23.73, "Europe/Athens"},
+	{41.01, 28.98, "Europe/Istanbul"},
+	{39.93, 32.86, "Europe/Istanbul"},
+	{35.17, 33.36, "Asia/Nicosia"},
+	{64.15, -21.94, "Atlantic/Reykjavik"},
+	{28.12, -15.44, "Atlantic/Canary"},
+	{37.74, -25.68, "Atlantic/Azores"},
+	{55.76, 37.62, "Europe/Moscow"},
+	{59.94, 30.31, "Europe/Moscow"},
+	{54.71, 20.51, "Europe/Kaliningrad"},
+	{53.20, 50.15, "Europe/Samara"},
+
+	// Russia and Central Asia
+	{56.84, 60.61, "Asia/Yekaterinburg"},
+	{54.99, 73.37, "Asia/Omsk"},
+	{55.03, 82.92, "Asia/Novosibirsk"},
+	{56.01, 92.87, "Asia/Krasnoyarsk"},
+	{52.29, 104.28, "Asia/Irkutsk"},
+	{62.03, 129.73, "Asia/Yakutsk"},
+	{43.12, 131.89, "Asia/Vladivostok"},
+	{59.57, 150.80, "Asia/Magadan"},
+	{53.02, 158.65, "Asia/Kamchatka"},
+	{43.24, 76.95, "Asia/Almaty"},
+	{41.30, 69.24, "Asia/Tashkent"},
+	{37.95, 58.38, "Asia/Ashgabat"},
+	{38.56, 68.77, "Asia/Dushanbe"},
+	{42.87, 74.59, "Asia/Bishkek"},
+	{47.89, 106.91, "Asia/Ulaanbaatar"},
+
+	// Middle East and South Asia
+	{41.72, 44.79, "Asia/Tbilisi"},
+	{40.18, 44.51, "Asia/Yerevan"},
+	{40.41, 49.87, "Asia/Baku"},
+	{35.69, 51.39, "Asia/Tehran"},
+	{33.31, 44.36, "Asia/Baghdad"},
+	{33.51, 36.28, "Asia/Damascus"},
+	{33.89, 35.50, "Asia/Beirut"},
+	{31.77, 35.22, "Asia/Jerusalem"},
+	{31.95, 35.93, "Asia/Amman"},
+	{24.71, 46.68, "Asia/Riyadh"},
+	{21.49, 39.19, "Asia/Riyadh"},
+	{29.38, 47.98, "Asia/Kuwait"},
+	{26.23, 50.59, "Asia/Bahrain"},
+	{25.29, 51.53, "Asia/Qatar"},
+	{25.20, 55.27, "Asia/Dubai"},
+	{23.59, 58.41, "Asia/Muscat"},
+	{15.37, 44.19, "Asia/Aden"},
+	{34.53, 69.17, "Asia/Kabul"},
+	{24.86, 67.01, "Asia/Karachi"},
+	{33.68, 73.05, "Asia/Karachi"},
+	{28.61, 77.21, "Asia/Kolkata"},
+	{19.08, 72.88, "Asia/Kolkata"},
+	{13.08, 80.27, "Asia/Kolkata"},
+	{22.57, 88.36, "Asia/Kolkata"},
+	{27.72, 85.32, "Asia/Kathmandu"},
+	{23.81, 90.41, "Asia/Dhaka"},
+	{6.93, 79.85, "Asia/Colombo"},
+	{4.18, 73.51, "Indian/Maldives"},
+
+	// East and Southeast Asia
+	{39.90, 116.41, "Asia/Shanghai"},
+	{31.23, 121.47, "Asia/Shanghai"},
+	{30.57, 104.07, "Asia/Shanghai"},
+	{43.83, 87.62, "Asia/Urumqi"},
+	{22.32, 114.17, "Asia/Hong_Kong"},
+	{25.03, 121.57, "Asia/Taipei"},
+	{37.57, 126.98, "Asia/Seoul"},
+	{39.04, 125.76, "Asia/Pyongyang"},
+	{35.68, 139.69, "Asia/Tokyo"},
+	{43.06, 141.35, "Asia/Tokyo"},
+	{26.21, 127.68, "Asia/Tokyo"},
+	{14.60, 120.98, "Asia/Manila"},
+	{13.76, 100.50, "Asia/Bangkok"},
+	{16.87, 96.20, "Asia/Yangon"},
+	{21.03, 105.85, "Asia/Ho_Chi_Minh"},
+	{10.82, 106.63, "Asia/Ho_Chi_Minh"},
+	{11.56, 104.92, "Asia/Phnom_Penh"},
+	{17.98, 102.63, "Asia/Vientiane"},
+	{3.14, 101.69, "Asia/Kuala_Lumpur"},
+	{1.35, 103.82, "Asia/Singapore"},
+	{-6.21, 106.85, "Asia/Jakarta"},
+	{3.59, 98.67, "Asia/Jakarta"},
+	{-8.65, 115.22, "Asia/Makassar"},
+	{-5.15, 119.43, "Asia/Makassar"},
+	{-2.53, 140.72, "Asia/Jayapura"},
+	{4.89, 114.94, "Asia/Brunei"},
+
+	// Oceania
+	{-33.87, 151.21, "Australia/Sydney"},
+	{-37.81, 144.96, "Australia/Melbourne"},
+	{-27.47, 153.03, "Australia/Brisbane"},
+	{-16.92, 145.77, "Australia/Brisbane"},
+	{-34.93, 138.60, "Australia/Adelaide"},
+	{-12.46, 130.84, "Australia/Darwin"},
+	{-23.70, 133.88, "Australia/Darwin"},
+	{-31.95, 115.86, "Australia/Perth"},
+	{-42.88, 147.33, "Australia/Hobart"},
+	{-36.85, 174.76, "Pacific/Auckland"},
+	{-43.53, 172.64, "Pacific/Auckland"},
+	{-9.44, 147.18, "Pacific/Port_Moresby"},
+	{-18.14, 178.44, "Pacific/Fiji"},
+	{-22.27, 166.46, "Pacific/Noumea"},
+	{-17.73, 168.32, "Pacific/Efate"},
+	{-21.14, -175.20, "Pacific/Tongatapu"},
+	{-13.83, -171.76, "Pacific/Apia"},
+	{-17.54, -149.56, "Pacific/Tahiti"},
+	{13.44, 144.79, "Pacific/Guam"},
+	{21.31, -157.86, "Pacific/Honolulu"},
+
+	// North America
+	{61.22, -149.90, "America/Anchorage"},
+	{64.84, -147.72, "America/Anchorage"},
+	{58.30, -134.42, "America/Juneau"},
+	{49.28, -123.12, "America/Vancouver"},
+	{47.61, -122.33, "America/Los_Angeles"},
+	{37.77, -122.42, "America/Los_Angeles"},
+	{34.05, -118.24, "America/Los_Angeles"},
+	{36.17, -115.14, "America/Los_Angeles"},
+	{33.45, -112.07, "America/Phoenix"},
+	{39.74, -104.99, "America/Denver"},
+	{40.76, -111.89, "America/Denver"},
+	{43.62, -116.20, "America/Boise"},
+	{51.05, -114.07, "America/Edmonton"},
+	{53.55, -113.49, "America/Edmonton"},
+	{50.45, -104.61, "America/Regina"},
+	{49.90, -97.14, "America/Winnipeg"},
+	{41.88, -87.63, "America/Chicago"},
+	{32.78, -96.80, "America/Chicago"},
+	{29.76, -95.37, "America/Chicago"},
+	{44.98, -93.27, "America/Chicago"},
+	{39.10, -94.58, "America/Chicago"},
+	{29.95, -90.07, "America/Chicago"},
+	{35.15, -90.05, "America/Chicago"},
+	{46.81, -100.78, "America/Chicago"},
+	{40.71, -74.01, "America/New_York"},
+	{42.36, -71.06, "America/New_York"},
+	{38.91, -77.04, "America/New_York"},
+	{33.75, -84.39, "America/New_York"},
+	{25.76, -80.19, "America/New_York"},
+	{28.54, -81.38, "America/New_York"},
+	{41.50, -81.69, "America/New_York"},
+	{42.33, -83.05, "America/Detroit"},
+	{39.77, -86.16, "America/Indiana/Indianapolis"},
+	{38.25, -85.76, "America/Kentucky/Louisville"},
+	{43.65, -79.38, "America/Toronto"},
+	{45.50, -73.57, "America/Toronto"},
+	{46.81, -71.21, "America/Toronto"},
+	{44.65, -63.57, "America/Halifax"},
+	{47.56, -52.71, "America/St_Johns"},
+	{62.45, -114.37, "America/Yellowknife"},
+	{60.72, -135.06, "America/Whitehorse"},
+	{63.75, -68.52, "America/Iqaluit"},
+	{64.18, -51.72, "America/Nuuk"},
+	{19.43, -99.13, "America/Mexico_City"},
+	{20.67, -103.35, "America/Mexico_City"},
+	{25.69, -100.32, "America/Monterrey"},
+	{21.16, -86.85, "America/Cancun"},
+	{32.51, -117.04, "America/Tijuana"},
+	{29.07, -110.96, "America/Hermosillo"},
+	{28.64, -106.09, "America/Chihuahua"},
+
+	// Central America and Caribbean
+	{14.63, -90.51, "America/Guatemala"},
+	{13.69, -89.22, "America/El_Salvador"},
+	{14.07, -87.19, "America/Tegucigalpa"},
+	{12.11, -86.24, "America/Managua"},
+	{9.93, -84.08, "America/Costa_Rica"},
+	{8.98, -79.52, "America/Panama"},
+	{23.11, -82.37, "America/Havana"},
+	{18.02, -76.81, "America/Jamaica"},
+	{18.54, -72.34, "America/Port-au-Prince"},
+	{18.49, -69.93, "America/Santo_Domingo"},
+	{18.47, -66.11, "America/Puerto_Rico"},
+	{25.05, -77.35, "America/Nassau"},
+	{13.10, -59.61, "America/Barbados"},
+	{10.65, -61.51, "America/Port_of_Spain"},
+	{14.61, -61.07, "America/Martinique"},
+	{12.52, -70.03, "America/Aruba"},
+	{32.29, -64.78, "Atlantic/Bermuda"},
+
+	// South America
+	{4.71, -74.07, "America/Bogota"},
+	{10.48, -66.90, "America/Caracas"},
+	{-0.18, -78.47, "America/Guayaquil"},
+	{-12.05, -77.04, "America/Lima"},
+	{-16.50, -68.15, "America/La_Paz"},
+	{-33.45, -70.67, "America/Santiago"},
+	{-53.16, -70.91, "America/Punta_Arenas"},
+	{-34.60, -58.38, "America/Argentina/Buenos_Aires"},
+	{-31.42, -64.18, "America/Argentina/Cordoba"},
+	{-54.80, -68.30, "America/Argentina/Ushuaia"},
+	{-34.90, -56.16, "America/Montevideo"},
+	{-25.26, -57.58, "America/Asuncion"},
+	{-23.55, -46.63, "America/Sao_Paulo"},
+	{-22.91, -43.17, "America/Sao_Paulo"},
+	{-15.79, -47.88, "America/Sao_Paulo"},
+	{-8.05, -34.88, "America/Recife"},
+	{-3.73, -38.53, "America/Fortaleza"},
+	{-1.46, -48.50, "America/Belem"},
+	{-3.12, -60.02, "America/Manaus"},
+	{-15.60, -56.10, "America/Cuiaba"},
+	{-8.76, -63.90, "America/Porto_Velho"},
+	{-9.97, -67.81, "America/Rio_Branco"},
+	{6.80, -58.16, "America/Guyana"},
+	{5.85, -55.20, "America/Paramaribo"},
+	{4.94, -52.33, "America/Cayenne"},
+	{-51.70, -57.85, "Atlantic/Stanley"},
+
+	// Africa
+	{30.04, 31.24, "Africa/Cairo"},
+	{32.89, 13.19, "Africa/Tripoli"},
+	{36.81, 10.18, "Africa/Tunis"},
+	{36.75, 3.06, "Africa/Algiers"},
+	{33.57, -7.59, "Africa/Casablanca"},
+	{27.15, -13.20, "Africa/El_Aaiun"},
+	{14.69, -17.44, "Africa/Dakar"},
+	{13.45, -16.58, "Africa/Banjul"},
+	{9.64, -13.58, "Africa/Conakry"},
+	{8.48, -13.23, "Africa/Freetown"},
+	{6.30, -10.80, "Africa/Monrovia"},
+	{5.36, -4.01, "Africa/Abidjan"},
+	{5.60, -0.19, "Africa/Accra"},
+	{6.13, 1.22, "Africa/Lome"},
+	{12.37, -1.52, "Africa/Ouagadougou"},
+	{12.64, -8.00, "Africa/Bamako"},
+	{18.08, -15.98, "Africa/Nouakchott"},
+	{13.51, 2.11, "Africa/Niamey"},
+	{6.52, 3.38, "Africa/Lagos"},
+	{9.08, 7.40, "Africa/Lagos"},
+	{12.13, 15.06, "Africa/Ndjamena"},
+	{3.87, 11.52, "Africa/Douala"},
+	{0.42, 9.47, "Africa/Libreville"},
+	{-4.27, 15.27, "Africa/Brazzaville"},
+	{-4.44, 15.27, "Africa/Kinshasa"},
+	{-11.66, 27.48, "Africa/Lubumbashi"},
+	{-8.84, 13.23, "Africa/Luanda"},
+	{15.50, 32.56, "Africa/Khartoum"},
+	{4.85, 31.58, "Africa/Juba"},
+	{9.03, 38.74, "Africa/Addis_Ababa"},
+	{15.32, 38.93, "Africa/Asmara"},
+	{11.59, 43.15, "Africa/Djibouti"},
+	{2.05, 45.32, "Africa/Mogadishu"},
+	{-1.29, 36.82, "Africa/Nairobi"},
+	{0.35, 32.58, "Africa/Kampala"},
+	{-1.95, 30.06, "Africa/Kigali"},
+	{-6.79, 39.21, "Africa/Dar_es_Salaam"},
+	{-15.39, 28.32, "Africa/Lusaka"},
+	{-13.96, 33.79, "Africa/Blantyre"},
+	{-17.83, 31.05, "Africa/Harare"},
+	{-25.97, 32.57, "Africa/Maputo"},
+	{-22.56, 17.08, "Africa/Windhoek"},
+	{-24.65, 25.91, "Africa/Gaborone"},
+	{-26.20, 28.05, "Africa/Johannesburg"},
+	{-33.92, 18.42, "Africa/Johannesburg"},
+	{-29.86, 31.03, "Africa/Johannesburg"},
+	{-18.88, 47.51, "Indian/Antananarivo"},
+	{-20.16, 57.50, "Indian/Mauritius"},
+	{-20.88, 55.45, "Indian/Reunion"},
+	{-4.62, 55.45, "Indian/Mahe"},
+	{14.93, -23.51, "Atlantic/Cape_Verde"},
+}
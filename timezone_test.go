@@ -0,0 +1,37 @@
+package metar_test
+
+import (
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timezone", func() {
+
+	table.DescribeTable("nearest time zone",
+		func(lat, lon float64, expected string) {
+			Expect(NearestTimezone(lat, lon).String()).To(Equal(expected))
+		},
+		table.Entry("Hamburg (EDDH)", 53.63, 9.99, "Europe/Berlin"),
+		table.Entry("Boston (KBOS)", 42.36, -71.01, "America/New_York"),
+		table.Entry("Denver (KDEN)", 39.86, -104.67, "America/Denver"),
+		table.Entry("Sydney (YSSY)", -33.95, 151.18, "Australia/Sydney"),
+		table.Entry("mid Atlantic", 30.0, -45.0, "UTC-3"),
+	)
+
+	It("should convert the observation time", func() {
+		r := Result{Latitude: 53.63, Longitude: 9.99, ObservationTime: time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)}
+		Expect(r.ObservationTimeLocal().Location()).To(Equal(time.UTC))
+
+		r.Present.Add(FieldLatitude)
+		r.Present.Add(FieldLongitude)
+		local := r.ObservationTimeLocal()
+		Expect(local.Hour()).To(Equal(12))
+		Expect(local.Equal(r.ObservationTime)).To(BeTrue())
+	})
+
+})
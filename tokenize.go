@@ -0,0 +1,229 @@
+package metar
+
+import "strings"
+
+// TokenKind classifies a group of a raw METAR
+type TokenKind int
+
+// Kinds of groups found in raw METARs
+const (
+	TokenUnknown               TokenKind = iota // Group not recognized by the tokenizer
+	TokenType                                   // METAR or SPECI
+	TokenModifier                               // COR (or CCA, CCB, ...), AUTO or NIL
+	TokenStation                                // Station identifier (EDDH)
+	TokenTime                                   // Observation time (211020Z)
+	TokenWind                                   // Wind (27008G18KT)
+	TokenWindVariation                          // Variable wind direction (240V300)
+	TokenVisibility                             // Prevailing visibility (9999, 1 1/2SM, CAVOK)
+	TokenDirectionalVisibility                  // Minimum visibility with direction (1500SW)
+	TokenRVR                                    // Runway visual range (R23/1200U)
+	TokenWeather                                // Present weather (-SHRA)
+	TokenCloud                                  // Cloud layer, vertical visibility or clear sky (BKN030CB, VV002, NSC)
+	TokenTemperature                            // Temperature and dewpoint (17/09)
+	TokenPressure                               // Altimeter or QNH (A2992, Q1018)
+	TokenTrend                                  // Trend forecast including all its groups (BECMG 3000 BR)
+	TokenRemarks                                // All groups following RMK
+	TokenWindShear                              // Wind shear in the take-off or approach path (WS R27, WS ALL RWY)
+	TokenRunwayState                            // Runway state for winter operations (88290592, R24/CLRD62, R/SNOCLO)
+	TokenSeaState                               // Sea surface temperature and state of the sea (W15/S4)
+	TokenColourState                            // Military colour state (BLU, BLACKWHT)
+	TokenRecentWeather                          // Recent weather since the last report (RERA, RETS)
+)
+
+var tokenKindNames = []string{
+	"unknown", "type", "modifier", "station", "time", "wind", "wind variation", "visibility",
+	"directional visibility", "RVR", "weather", "cloud", "temperature", "pressure", "trend", "remarks",
+	"wind shear", "runway state", "sea state", "colour state", "recent weather",
+}
+
+func (k TokenKind) String() string {
+	if k < 0 || int(k) >= len(tokenKindNames) {
+		return "unknown"
+	}
+	return tokenKindNames[k]
+}
+
+// Token is a classified group of a raw METAR. Trends and remarks are
+// returned as a single token spanning all their groups.
+type Token struct {
+	Kind   TokenKind
+	Text   string
+	Offset int // Byte offset of the token within the tokenized string
+}
+
+type group struct {
+	text   string
+	offset int
+}
+
+// splitGroups splits the report at whitespace remembering the offsets
+func splitGroups(raw string) []group {
+	var (
+		groups = make([]group, 0, strings.Count(raw, " ")+1)
+		start  = -1
+	)
+	// Whitespace is ASCII, so bytes can be checked without decoding runes
+	for i := 0; i <= len(raw); i++ {
+		switch {
+		case i == len(raw) || raw[i] == ' ' || raw[i] == '\t' || raw[i] == '\n' || raw[i] == '\r':
+			if start >= 0 {
+				groups = append(groups, group{raw[start:i], start})
+				start = -1
+			}
+		case start < 0:
+			start = i
+		}
+	}
+
+	if n := len(groups); n > 0 {
+		// The end of report marker is not part of the last group
+		groups[n-1].text = strings.TrimSuffix(groups[n-1].text, "=")
+		if groups[n-1].text == "" {
+			groups = groups[:n-1]
+		}
+	}
+	return groups
+}
+
+// Tokenize splits a raw METAR into classified groups without decoding
+// their values. It never fails: groups it does not recognize are returned
+// as TokenUnknown so custom decoders can handle national peculiarities.
+func Tokenize(raw string) []Token {
+	var (
+		groups = splitGroups(raw)
+		tokens = make([]Token, 0, len(groups))
+		i      int
+	)
+
+	emit := func(k TokenKind, from, to int) {
+		tokens = append(tokens, Token{
+			Kind:   k,
+			Text:   raw[groups[from].offset : groups[to].offset+len(groups[to].text)],
+			Offset: groups[from].offset,
+		})
+	}
+
+	// Header: type, station and time with optional modifiers in between
+	for ; i < len(groups); i++ {
+		g := groups[i].text
+		switch {
+		case len(tokens) == 0 && (g == "METAR" || g == "SPECI"):
+			emit(TokenType, i, i)
+		case g == "AUTO" || g == "NIL" || correctionRegex.MatchString(g):
+			emit(TokenModifier, i, i)
+		case !hasToken(tokens, TokenStation) && stationRegex.MatchString(g):
+			emit(TokenStation, i, i)
+		case hasToken(tokens, TokenStation) && !hasToken(tokens, TokenTime) && timeRegex.MatchString(g):
+			emit(TokenTime, i, i)
+		default:
+			goto body
+		}
+	}
+
+body:
+	for ; i < len(groups); i++ {
+		g := groups[i].text
+
+		switch {
+		case g == "RMK":
+			if i+1 < len(groups) {
+				emit(TokenRemarks, i+1, len(groups)-1)
+			}
+			i = len(groups)
+
+		case g == "NOSIG" || g == "BECMG" || g == "TEMPO":
+			end := i
+			for end < len(groups)-1 && groups[end+1].text != "RMK" {
+				end++
+			}
+			emit(TokenTrend, i, end)
+			i = end
+
+		case g == "WS" && i+1 < len(groups) && windShearRunwayRegex.MatchString(groups[i+1].text):
+			emit(TokenWindShear, i, i+1)
+			i++
+
+		case g == "WS" && i+2 < len(groups) && groups[i+1].text == "ALL" && groups[i+2].text == "RWY":
+			emit(TokenWindShear, i, i+2)
+			i += 2
+
+		case hasWindUnit(g) && windRegex.MatchString(g):
+			emit(TokenWind, i, i)
+
+		case len(g) == 7 && g[3] == 'V' && windVarRegex.MatchString(g):
+			emit(TokenWindVariation, i, i)
+
+		case i+1 < len(groups) && len(g) == 1 && g[0] >= '1' && g[0] <= '9' && strings.HasSuffix(groups[i+1].text, "SM"):
+			if _, err := ParseVisibility(g + " " + groups[i+1].text); err == nil {
+				emit(TokenVisibility, i, i+1)
+				i++
+			} else {
+				emit(TokenUnknown, i, i)
+			}
+
+		case dirVisRegex.MatchString(g):
+			emit(TokenDirectionalVisibility, i, i)
+
+		case isRunwayStateGroup(g):
+			emit(TokenRunwayState, i, i)
+
+		case seaStateRegex.MatchString(g):
+			emit(TokenSeaState, i, i)
+
+		case colourStateRegex.MatchString(g):
+			emit(TokenColourState, i, i)
+
+		case rvrRegex.MatchString(g):
+			emit(TokenRVR, i, i)
+
+		case isVisibilityGroup(g):
+			emit(TokenVisibility, i, i)
+
+		case g == "SKC" || g == "CLR" || g == "NSC" || g == "NCD" || (isCloudCover(g) && cloudRegex.MatchString(g)):
+			emit(TokenCloud, i, i)
+
+		case strings.IndexByte(g, '/') > 0 && tempRegex.MatchString(g):
+			emit(TokenTemperature, i, i)
+
+		case len(g) == 5 && (g[0] == 'A' || g[0] == 'Q') && altimeterRegex.MatchString(g):
+			emit(TokenPressure, i, i)
+
+		case isRecentWeatherGroup(g):
+			emit(TokenRecentWeather, i, i)
+
+		case weatherRegex.MatchString(g) && g != "+" && g != "-" && g != "VC":
+			emit(TokenWeather, i, i)
+
+		default:
+			emit(TokenUnknown, i, i)
+		}
+	}
+
+	return tokens
+}
+
+// hasWindUnit checks the group for the unit suffix of wind groups
+func hasWindUnit(g string) bool {
+	return strings.HasSuffix(g, "KT") || strings.HasSuffix(g, "MPS") || strings.HasSuffix(g, "KMH")
+}
+
+// isCloudCover checks the group for the prefix of cloud layers
+func isCloudCover(g string) bool {
+	if len(g) < 5 {
+		return false
+	}
+	switch g[:2] {
+	case "FE", "SC", "BK", "OV", "VV":
+		return true
+	}
+	return false
+}
+
+func hasToken(tokens []Token, k TokenKind) bool {
+	for _, t := range tokens {
+		if t.Kind == k {
+			return true
+		}
+	}
+	return false
+}
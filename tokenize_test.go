@@ -0,0 +1,64 @@
+package metar_test
+
+import (
+	"testing"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tokenize", func() {
+
+	It("should classify all groups of a report", func() {
+		raw := "METAR KBOS 210754Z AUTO 19012G22KT 160V220 1 1/2SM R04R/2200FT -RA BR BKN040 OVC070 12/10 A2999 TEMPO 3SM RMK AO2 SLP155="
+		tokens := Tokenize(raw)
+
+		var kinds []TokenKind
+		var texts []string
+		for _, t := range tokens {
+			kinds = append(kinds, t.Kind)
+			texts = append(texts, t.Text)
+		}
+
+		Expect(kinds).To(Equal([]TokenKind{
+			TokenType, TokenStation, TokenTime, TokenModifier, TokenWind, TokenWindVariation,
+			TokenVisibility, TokenRVR, TokenWeather, TokenWeather, TokenCloud, TokenCloud,
+			TokenTemperature, TokenPressure, TokenTrend, TokenRemarks,
+		}))
+		Expect(texts).To(Equal([]string{
+			"METAR", "KBOS", "210754Z", "AUTO", "19012G22KT", "160V220",
+			"1 1/2SM", "R04R/2200FT", "-RA", "BR", "BKN040", "OVC070",
+			"12/10", "A2999", "TEMPO 3SM", "AO2 SLP155",
+		}))
+		Expect(tokens[6].Offset).To(Equal(43))
+	})
+
+	It("should keep unrecognized groups", func() {
+		tokens := Tokenize("EDDH 211020Z 27008KT 9999 4000NE XYZ123 FEW030 17/09 Q1018")
+		Expect(tokens[4].Kind).To(Equal(TokenDirectionalVisibility))
+		Expect(tokens[5].Kind).To(Equal(TokenUnknown))
+		Expect(tokens[5].Text).To(Equal("XYZ123"))
+		Expect(tokens[5].Kind.String()).To(Equal("unknown"))
+	})
+
+	It("should handle NIL reports and CAVOK", func() {
+		Expect(Tokenize("EDDH 200720Z NIL")[2]).To(Equal(Token{Kind: TokenModifier, Text: "NIL", Offset: 13}))
+		Expect(Tokenize("EDDH 211020Z 27008KT CAVOK 17/09 Q1018")[3].Kind).To(Equal(TokenVisibility))
+	})
+
+	It("should group wind shear reports", func() {
+		tokens := Tokenize("LGAV 211020Z 33025G38KT 9999 17/09 Q1012 WS ALL RWY")
+		Expect(tokens[6]).To(Equal(Token{Kind: TokenWindShear, Text: "WS ALL RWY", Offset: 41}))
+		Expect(tokens[6].Kind.String()).To(Equal("wind shear"))
+	})
+
+})
+
+func BenchmarkTokenize(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Tokenize(benchReports[i%len(benchReports)])
+	}
+}
@@ -0,0 +1,91 @@
+package metar
+
+import "math"
+
+// UASLimits are the operating limits of small unmanned aircraft, zero
+// wind limits are not checked
+type UASLimits struct {
+	MaxAltitudeFt         int64   // Highest permitted altitude above ground, 0 for no limit
+	MinVisibilitySM       float64 // Lowest permitted flight visibility (statute miles)
+	CloudClearanceBelowFt int64   // Required vertical distance below clouds
+	MaxWindKt             int64   // Highest sustained wind of the aircraft
+	MaxGustKt             int64   // Highest gust speed of the aircraft
+}
+
+// Part107Limits are the weather limits of 14 CFR Part 107 (400 ft above
+// ground, 3 SM visibility, 500 ft below clouds). Part 107 does not limit
+// the wind, set the limits of the aircraft in use.
+var Part107Limits = UASLimits{
+	MaxAltitudeFt:         400,
+	MinVisibilitySM:       3,
+	CloudClearanceBelowFt: 500,
+}
+
+// UASVerdict is the result of checking a report against UASLimits
+type UASVerdict struct {
+	Permitted     bool
+	MaxAltitudeFt int64 // Highest altitude above ground keeping the cloud clearance, -1 if not limited
+	Violations    []Violation
+	Warnings      []string // Conditions not decidable from the report
+}
+
+// EvaluateUAS checks whether the reported conditions permit operating a
+// small unmanned aircraft. The cloud clearance is approximated from the
+// cloud bases of the report: the maximum altitude is lowered to stay the
+// required distance below the lowest layer. The 2000 ft horizontal
+// clearance cannot be derived from a METAR, a warning is added for
+// scattered layers limiting the altitude and for thunderstorms.
+func (r Result) EvaluateUAS(l UASLimits) UASVerdict {
+	v := UASVerdict{MaxAltitudeFt: l.MaxAltitudeFt}
+	if l.MaxAltitudeFt <= 0 {
+		v.MaxAltitudeFt = -1
+	}
+
+	vis := r.Visibility()
+	switch {
+	case !r.Present.Has(FieldVisibilityStatute):
+		v.Violations = append(v.Violations, Violation{Kind: ViolationVisibility, Limit: l.MinVisibilitySM, Missing: true})
+	case vis.StatuteMiles() < l.MinVisibilitySM && vis.Modifier != VisibilityGreaterThan:
+		v.Violations = append(v.Violations, Violation{Kind: ViolationVisibility, Limit: l.MinVisibilitySM, Actual: math.Round(vis.StatuteMiles()*100) / 100})
+	}
+
+	lowest := int64(-1)
+	for _, c := range r.CloudLayers() {
+		if c.BaseFt < 0 {
+			continue
+		}
+
+		if lowest < 0 || c.BaseFt < lowest {
+			lowest = c.BaseFt
+		}
+		if alt := c.BaseFt - l.CloudClearanceBelowFt; v.MaxAltitudeFt < 0 || alt < v.MaxAltitudeFt {
+			v.MaxAltitudeFt = alt
+			if c.Cover == SkyCoverFEW || c.Cover == SkyCoverSCT {
+				v.Warnings = append(v.Warnings, "horizontal cloud clearance not verifiable for "+c.String())
+			}
+		}
+	}
+	if lowest >= 0 && v.MaxAltitudeFt <= 0 {
+		v.MaxAltitudeFt = 0
+		v.Violations = append(v.Violations, Violation{Kind: ViolationCloudClearance, Limit: float64(l.CloudClearanceBelowFt), Actual: float64(lowest)})
+	}
+
+	if l.MaxWindKt > 0 || l.MaxGustKt > 0 {
+		switch {
+		case !r.Present.Has(FieldWindSpeed):
+			v.Violations = append(v.Violations, Violation{Kind: ViolationWind, Limit: float64(l.MaxWindKt), Missing: true})
+		case l.MaxWindKt > 0 && r.WindSpeed > l.MaxWindKt:
+			v.Violations = append(v.Violations, Violation{Kind: ViolationWind, Limit: float64(l.MaxWindKt), Actual: float64(r.WindSpeed)})
+		}
+		if l.MaxGustKt > 0 && r.WindGust > l.MaxGustKt {
+			v.Violations = append(v.Violations, Violation{Kind: ViolationGust, Limit: float64(l.MaxGustKt), Actual: float64(r.WindGust)})
+		}
+	}
+
+	if r.HasThunderstorm() {
+		v.Warnings = append(v.Warnings, "thunderstorm reported")
+	}
+
+	v.Permitted = len(v.Violations) == 0
+	return v
+}
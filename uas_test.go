@@ -0,0 +1,64 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UAS evaluation", func() {
+
+	limits := Part107Limits
+	limits.MaxWindKt = 20
+	limits.MaxGustKt = 25
+
+	evaluate := func(raw string) UASVerdict {
+		r, err := ParseRaw(raw)
+		Expect(err).NotTo(HaveOccurred())
+		return r.EvaluateUAS(limits)
+	}
+
+	It("should permit operations in good conditions", func() {
+		v := evaluate("KJFK 211051Z 27008KT 10SM FEW030 22/12 A2992")
+		Expect(v.Permitted).To(BeTrue())
+		Expect(v.MaxAltitudeFt).To(Equal(int64(400)))
+		Expect(v.Violations).To(BeEmpty())
+		Expect(v.Warnings).To(BeEmpty())
+	})
+
+	It("should lower the altitude below low clouds", func() {
+		v := evaluate("KJFK 211051Z 27008KT 10SM SCT008 OVC020 22/12 A2992")
+		Expect(v.Permitted).To(BeTrue())
+		Expect(v.MaxAltitudeFt).To(Equal(int64(300)))
+		Expect(v.Warnings).To(Equal([]string{"horizontal cloud clearance not verifiable for SCT008"}))
+	})
+
+	It("should deny operations violating the limits", func() {
+		v := evaluate("KJFK 211051Z 27022G30KT 2SM BR OVC004 22/12 A2992")
+		Expect(v.Permitted).To(BeFalse())
+		Expect(v.MaxAltitudeFt).To(Equal(int64(0)))
+		Expect(v.Violations).To(Equal([]Violation{
+			{Kind: ViolationVisibility, Limit: 3, Actual: 2},
+			{Kind: ViolationCloudClearance, Limit: 500, Actual: 400},
+			{Kind: ViolationWind, Limit: 20, Actual: 22},
+			{Kind: ViolationGust, Limit: 25, Actual: 30},
+		}))
+		Expect(v.Violations[1].String()).To(Equal("cloud clearance 400 below minimum 500"))
+	})
+
+	It("should not limit the altitude without a maximum altitude", func() {
+		r, err := ParseRaw("KJFK 211051Z 27008KT 10SM SKC 22/12 A2992")
+		Expect(err).NotTo(HaveOccurred())
+
+		v := r.EvaluateUAS(UASLimits{MinVisibilitySM: 3, CloudClearanceBelowFt: 500})
+		Expect(v.Permitted).To(BeTrue())
+		Expect(v.MaxAltitudeFt).To(Equal(int64(-1)))
+		Expect(v.Violations).To(BeEmpty())
+
+		r, err = ParseRaw("KJFK 211051Z 27008KT 10SM BKN030 22/12 A2992")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.EvaluateUAS(UASLimits{MinVisibilitySM: 3, CloudClearanceBelowFt: 500}).MaxAltitudeFt).To(Equal(int64(2500)))
+	})
+
+})
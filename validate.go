@@ -0,0 +1,94 @@
+package metar
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// IssueSeverity classifies the problems found by Validate
+type IssueSeverity string
+
+// Severities of validation issues
+const (
+	SeverityError   IssueSeverity = "error"   // The report is malformed or contradicts itself
+	SeverityWarning IssueSeverity = "warning" // The report is unusual but may be valid
+)
+
+// Issue is a problem found in a raw METAR
+type Issue struct {
+	Severity IssueSeverity
+	Group    string // Offending group, empty for problems of the whole report
+	Offset   int    // Byte offset of the group within the raw report
+	Message  string
+}
+
+// Validate checks a raw METAR for syntax problems (missing or unknown
+// groups) and semantic problems (dewpoint above temperature, gusts not
+// exceeding the wind speed, impossible times and directions). A valid
+// report returns no issues.
+func Validate(raw string) []Issue {
+	raw = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(raw), "="))
+
+	r, warnings, err := Parser{}.ParseWithWarnings(raw)
+	if err != nil {
+		var pe ParseError
+		if errors.As(err, &pe) {
+			return []Issue{{Severity: SeverityError, Group: pe.Token, Offset: pe.Pos, Message: pe.Err.Error()}}
+		}
+		return []Issue{{Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var issues []Issue
+	for _, w := range warnings {
+		issues = append(issues, Issue{Severity: SeverityWarning, Group: w.Group, Offset: w.Offset, Message: w.Message})
+	}
+
+	for _, t := range Tokenize(raw) {
+		issue := func(sev IssueSeverity, msg string) {
+			issues = append(issues, Issue{Severity: sev, Group: t.Text, Offset: t.Offset, Message: msg})
+		}
+
+		switch t.Kind {
+		case TokenTime:
+			m := timeRegex.FindStringSubmatch(t.Text)
+			day, _ := strconv.Atoi(m[1])
+			hour, _ := strconv.Atoi(m[2])
+			min, _ := strconv.Atoi(m[3])
+			if day < 1 || day > 31 || hour > 23 || min > 59 {
+				issue(SeverityError, "Observation time out of range")
+			}
+
+		case TokenWind:
+			m := windRegex.FindStringSubmatch(t.Text)
+			if dir, err := strconv.Atoi(m[1]); err == nil {
+				switch {
+				case dir > 360:
+					issue(SeverityError, "Wind direction above 360 degrees")
+				case dir%10 != 0:
+					issue(SeverityWarning, "Wind direction not rounded to ten degrees")
+				}
+			}
+			if r.Present.Has(FieldWindGust) && r.WindGust <= r.WindSpeed {
+				issue(SeverityError, "Gust does not exceed the sustained wind speed")
+			}
+
+		case TokenTemperature:
+			if r.Present.Has(FieldDewpoint) && r.Dewpoint > r.Temperature {
+				issue(SeverityError, "Dewpoint above temperature")
+			}
+		}
+	}
+
+	if !r.Present.Has(FieldWindSpeed) {
+		issues = append(issues, Issue{Severity: SeverityWarning, Message: "No wind group reported"})
+	}
+	if !r.Present.Has(FieldTemperature) {
+		issues = append(issues, Issue{Severity: SeverityWarning, Message: "No temperature group reported"})
+	}
+	if !r.Present.Has(FieldAltimeter) {
+		issues = append(issues, Issue{Severity: SeverityWarning, Message: "No pressure group reported"})
+	}
+
+	return issues
+}
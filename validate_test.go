@@ -0,0 +1,39 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+
+	It("should accept a valid report", func() {
+		Expect(Validate("METAR EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG=")).To(BeEmpty())
+	})
+
+	It("should report unparseable reports", func() {
+		Expect(Validate("EDDH 27008KT")).To(Equal([]Issue{
+			{Severity: SeverityError, Group: "27008KT", Offset: 5, Message: ErrMissingTime.Error()},
+		}))
+	})
+
+	It("should report semantic problems", func() {
+		issues := Validate("EDDH 211075Z 27508G06KT 9999 XYZ FEW030 09/11 Q1018")
+		Expect(issues).To(Equal([]Issue{
+			{Severity: SeverityWarning, Group: "XYZ", Offset: 29, Message: "Unrecognized group"},
+			{Severity: SeverityError, Group: "211075Z", Offset: 5, Message: "Observation time out of range"},
+			{Severity: SeverityWarning, Group: "27508G06KT", Offset: 13, Message: "Wind direction not rounded to ten degrees"},
+			{Severity: SeverityError, Group: "27508G06KT", Offset: 13, Message: "Gust does not exceed the sustained wind speed"},
+			{Severity: SeverityError, Group: "09/11", Offset: 40, Message: "Dewpoint above temperature"},
+		}))
+	})
+
+	It("should report missing mandatory groups", func() {
+		issues := Validate("EDDH 211020Z 9999 FEW030")
+		Expect(issues).To(HaveLen(3))
+		Expect(issues[0].Message).To(Equal("No wind group reported"))
+	})
+
+})
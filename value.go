@@ -0,0 +1,138 @@
+package metar
+
+import (
+	"math"
+	"strconv"
+)
+
+// Unit is the unit of a Value
+type Unit string
+
+// Units of speeds and pressures
+const (
+	UnitKnots             Unit = "kt"
+	UnitMetersPerSecond   Unit = "m/s"
+	UnitKilometersPerHour Unit = "km/h"
+	UnitMilesPerHour      Unit = "mph"
+	UnitHectopascal       Unit = "hPa"
+	UnitInchesOfMercury   Unit = "inHg"
+)
+
+// unitFactors converts a unit into the base unit of its dimension (knots
+// for speeds, hectopascal for pressures)
+var unitFactors = map[Unit]struct {
+	dimension int
+	factor    float64
+}{
+	UnitKnots:             {1, 1},
+	UnitMetersPerSecond:   {1, 1 / KtsToMs(1)},
+	UnitKilometersPerHour: {1, KmhToKts(1)},
+	UnitMilesPerHour:      {1, 1 / KtsToMph(1)},
+	UnitHectopascal:       {2, 1},
+	UnitInchesOfMercury:   {2, InHgTohPa(1)},
+}
+
+// Value is a speed or pressure stored as fixed-point number with three
+// decimals, so repeated conversions and comparisons do not accumulate
+// float rounding errors. Values are compared using ==.
+type Value struct {
+	Milli int64 // Value in thousandths of the unit
+	Unit  Unit
+}
+
+// NewValue creates a Value from a float, rounded to three decimals
+func NewValue(v float64, u Unit) Value {
+	return Value{Milli: int64(math.Round(v * 1000)), Unit: u}
+}
+
+// Float returns the value as float
+func (v Value) Float() float64 {
+	return float64(v.Milli) / 1000
+}
+
+// In converts the value into the unit. The boolean is false if the units
+// measure different dimensions (speed and pressure) or are unknown.
+func (v Value) In(u Unit) (Value, bool) {
+	if v.Unit == u {
+		return v, true
+	}
+
+	from, ok1 := unitFactors[v.Unit]
+	to, ok2 := unitFactors[u]
+	if !ok1 || !ok2 || from.dimension != to.dimension {
+		return Value{}, false
+	}
+	return NewValue(v.Float()*from.factor/to.factor, u), true
+}
+
+// RoundTo rounds the value to a multiple of step (e.g. 0.1 or 5), halves
+// are rounded away from zero. Steps below the precision of 0.001 return
+// the value unchanged.
+func (v Value) RoundTo(step float64) Value {
+	s := int64(math.Round(math.Abs(step) * 1000))
+	if s <= 1 {
+		return v
+	}
+
+	q := v.Milli / s
+	if rem := v.Milli % s; rem*2 >= s {
+		q++
+	} else if rem*2 <= -s {
+		q--
+	}
+	return Value{Milli: q * s, Unit: v.Unit}
+}
+
+// String formats the value with up to three decimals and its unit
+// ("8 kt", "1013.2 hPa")
+func (v Value) String() string {
+	return strconv.FormatFloat(v.Float(), 'f', -1, 64) + " " + string(v.Unit)
+}
+
+// Kmh returns the speed in kilometers per hour, false if the value is no
+// speed
+func (v Value) Kmh() (float64, bool) {
+	kmh, ok := v.In(UnitKilometersPerHour)
+	return kmh.Float(), ok
+}
+
+// Mph returns the speed in miles per hour, false if the value is no speed
+func (v Value) Mph() (float64, bool) {
+	mph, ok := v.In(UnitMilesPerHour)
+	return mph.Float(), ok
+}
+
+// WindSpeedValue returns the wind speed in knots
+func (r Result) WindSpeedValue() (Value, bool) {
+	return Value{Milli: r.WindSpeed * 1000, Unit: UnitKnots}, r.Present.Has(FieldWindSpeed)
+}
+
+// WindGustValue returns the wind gust in knots
+func (r Result) WindGustValue() (Value, bool) {
+	return Value{Milli: r.WindGust * 1000, Unit: UnitKnots}, r.Present.Has(FieldWindGust)
+}
+
+// AltimeterValue returns the altimeter setting in inches of mercury
+func (r Result) AltimeterValue() (Value, bool) {
+	return NewValue(r.Altimeter, UnitInchesOfMercury), r.Present.Has(FieldAltimeter)
+}
+
+// WindSpeedKmh returns the wind speed in kilometers per hour
+func (r Result) WindSpeedKmh() (float64, bool) {
+	return KtsToKmh(float64(r.WindSpeed)), r.Present.Has(FieldWindSpeed)
+}
+
+// WindSpeedMph returns the wind speed in miles per hour
+func (r Result) WindSpeedMph() (float64, bool) {
+	return KtsToMph(float64(r.WindSpeed)), r.Present.Has(FieldWindSpeed)
+}
+
+// WindGustKmh returns the wind gust in kilometers per hour
+func (r Result) WindGustKmh() (float64, bool) {
+	return KtsToKmh(float64(r.WindGust)), r.Present.Has(FieldWindGust)
+}
+
+// WindGustMph returns the wind gust in miles per hour
+func (r Result) WindGustMph() (float64, bool) {
+	return KtsToMph(float64(r.WindGust)), r.Present.Has(FieldWindGust)
+}
@@ -0,0 +1,108 @@
+package metar_test
+
+import (
+	"testing"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Value", func() {
+
+	It("should store values with three decimals", func() {
+		v := NewValue(0.1+0.2, UnitHectopascal)
+		Expect(v).To(Equal(Value{Milli: 300, Unit: UnitHectopascal}))
+		Expect(v == NewValue(0.3, UnitHectopascal)).To(BeTrue())
+		Expect(v.Float()).To(Equal(0.3))
+		Expect(v.String()).To(Equal("0.3 hPa"))
+	})
+
+	It("should convert between units of the same dimension", func() {
+		v, ok := NewValue(10, UnitKnots).In(UnitMetersPerSecond)
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal(Value{Milli: 5144, Unit: UnitMetersPerSecond}))
+
+		v, ok = NewValue(100, UnitKilometersPerHour).In(UnitKnots)
+		Expect(ok).To(BeTrue())
+		Expect(v.String()).To(Equal("53.996 kt"))
+
+		v, ok = NewValue(29.92, UnitInchesOfMercury).In(UnitHectopascal)
+		Expect(ok).To(BeTrue())
+		Expect(v.RoundTo(0.1).String()).To(Equal("1013.2 hPa"))
+
+		_, ok = NewValue(10, UnitKnots).In(UnitHectopascal)
+		Expect(ok).To(BeFalse())
+		_, ok = NewValue(10, UnitKnots).In(Unit("furlong/fortnight"))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should round to steps", func() {
+		Expect(NewValue(1.25, UnitKnots).RoundTo(0.1).Float()).To(Equal(1.3))
+		Expect(NewValue(-1.25, UnitKnots).RoundTo(0.1).Float()).To(Equal(-1.3))
+		Expect(NewValue(1.24, UnitKnots).RoundTo(0.1).Float()).To(Equal(1.2))
+		Expect(NewValue(17, UnitKnots).RoundTo(5).Float()).To(Equal(15.0))
+		Expect(NewValue(17.5, UnitKnots).RoundTo(5).Float()).To(Equal(20.0))
+		Expect(NewValue(1.2345, UnitKnots).RoundTo(0)).To(Equal(NewValue(1.2345, UnitKnots)))
+	})
+
+	It("should provide the values of a result", func() {
+		r, err := ParseRaw("EDDH 211020Z 27008G18KT 9999 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+
+		speed, ok := r.WindSpeedValue()
+		Expect(ok).To(BeTrue())
+		Expect(speed.String()).To(Equal("8 kt"))
+
+		gust, ok := r.WindGustValue()
+		Expect(ok).To(BeTrue())
+		Expect(gust.Float()).To(Equal(18.0))
+
+		altimeter, ok := r.AltimeterValue()
+		Expect(ok).To(BeTrue())
+		qnh, _ := altimeter.In(UnitHectopascal)
+		Expect(qnh.RoundTo(1).String()).To(Equal("1018 hPa"))
+
+		_, ok = (Result{}).WindSpeedValue()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should provide speeds in km/h and mph", func() {
+		kmh, ok := NewValue(10, UnitKnots).Kmh()
+		Expect(ok).To(BeTrue())
+		Expect(kmh).To(Equal(18.52))
+
+		mph, ok := NewValue(10, UnitKnots).Mph()
+		Expect(ok).To(BeTrue())
+		Expect(mph).To(Equal(11.508))
+
+		_, ok = NewValue(1013, UnitHectopascal).Kmh()
+		Expect(ok).To(BeFalse())
+
+		r, err := ParseRaw("EDDH 211020Z 27010G20KT 9999 FEW030 17/09 Q1018")
+		Expect(err).NotTo(HaveOccurred())
+
+		speed, ok := r.WindSpeedKmh()
+		Expect(ok).To(BeTrue())
+		Expect(speed).To(BeNumerically("~", 18.52, 0.001))
+
+		gust, ok := r.WindGustMph()
+		Expect(ok).To(BeTrue())
+		Expect(gust).To(BeNumerically("~", 23.016, 0.001))
+
+		_, ok = (Result{}).WindGustKmh()
+		Expect(ok).To(BeFalse())
+		_, ok = (Result{}).WindSpeedMph()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should convert without allocations", func() {
+		v := NewValue(10, UnitKnots)
+		Expect(testing.AllocsPerRun(100, func() {
+			c, _ := v.In(UnitKilometersPerHour)
+			c.RoundTo(0.1)
+		})).To(BeZero())
+	})
+
+})
@@ -0,0 +1,149 @@
+package metar
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// VisibilityUnit is the unit a visibility was reported in
+type VisibilityUnit string
+
+// Units used to report visibilities
+const (
+	VisibilityUnitMeters       VisibilityUnit = "m"  // International METARs report in meters
+	VisibilityUnitStatuteMiles VisibilityUnit = "SM" // North American METARs report in statute miles
+)
+
+// VisibilityModifier qualifies a reported visibility value
+type VisibilityModifier string
+
+// Possible modifiers of a reported visibility
+const (
+	VisibilityExact       VisibilityModifier = ""  // Value is the reported visibility
+	VisibilityGreaterThan VisibilityModifier = "P" // Visibility is more than Value (P6SM, 9999)
+	VisibilityLessThan    VisibilityModifier = "M" // Visibility is less than Value (M1/4SM, 0000)
+)
+
+// Visibility is a horizontal visibility in the unit it was reported in
+type Visibility struct {
+	Value    float64
+	Unit     VisibilityUnit
+	Modifier VisibilityModifier
+}
+
+var (
+	visibilityMetersRegex = regexp.MustCompile(`^(\d{4})(NDV)?$`)
+	visibilityMilesRegex  = regexp.MustCompile(`^([PM<>])?(?:(\d+) )?(\d+(?:/\d+)?|\d*\.\d+)SM$`)
+)
+
+// ParseVisibility parses a visibility group of a raw METAR like "9999",
+// "0400", "P6SM", ">6SM", "M1/4SM" or "1 1/2SM"
+func ParseVisibility(group string) (Visibility, error) {
+	if group == "CAVOK" {
+		return Visibility{Value: 10000, Unit: VisibilityUnitMeters, Modifier: VisibilityGreaterThan}, nil
+	}
+
+	if m := visibilityMetersRegex.FindStringSubmatch(group); m != nil {
+		v, _ := strconv.ParseFloat(m[1], 64)
+		switch v {
+		case 9999:
+			return Visibility{Value: 10000, Unit: VisibilityUnitMeters, Modifier: VisibilityGreaterThan}, nil
+		case 0:
+			return Visibility{Value: 50, Unit: VisibilityUnitMeters, Modifier: VisibilityLessThan}, nil
+		}
+		return Visibility{Value: v, Unit: VisibilityUnitMeters}, nil
+	}
+
+	if m := visibilityMilesRegex.FindStringSubmatch(group); m != nil {
+		v, err := parseFraction(m[3])
+		if err != nil {
+			return Visibility{}, err
+		}
+
+		if m[2] != "" {
+			whole, _ := strconv.ParseFloat(m[2], 64)
+			v += whole
+		}
+
+		vis := Visibility{Value: v, Unit: VisibilityUnitStatuteMiles}
+		switch m[1] {
+		case "P", ">":
+			vis.Modifier = VisibilityGreaterThan
+		case "M", "<":
+			vis.Modifier = VisibilityLessThan
+		}
+		return vis, nil
+	}
+
+	return Visibility{}, fmt.Errorf("Invalid visibility group %q", group)
+}
+
+func parseFraction(s string) (float64, error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) == 1 {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	num, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	den, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || den == 0 {
+		return 0, fmt.Errorf("Invalid fraction %q", s)
+	}
+	return num / den, nil
+}
+
+// Meters returns the visibility converted to meters
+func (v Visibility) Meters() float64 {
+	if v.Unit == VisibilityUnitStatuteMiles {
+		return StatMileToKm(v.Value) * 1000
+	}
+	return v.Value
+}
+
+// StatuteMiles returns the visibility converted to statute miles
+func (v Visibility) StatuteMiles() float64 {
+	if v.Unit == VisibilityUnitMeters {
+		return v.Value / 1000 / 1.60934
+	}
+	return v.Value
+}
+
+// String formats the visibility like "> 10000 m" or "1.5 SM"
+func (v Visibility) String() string {
+	prefix := ""
+	switch v.Modifier {
+	case VisibilityGreaterThan:
+		prefix = "> "
+	case VisibilityLessThan:
+		prefix = "< "
+	}
+	return prefix + strconv.FormatFloat(v.Value, 'f', -1, 64) + " " + string(v.Unit)
+}
+
+// Visibility returns the prevailing visibility as reported in the raw
+// METAR. If the raw text contains no visibility group VisibilityStatute is
+// used.
+func (r Result) Visibility() Visibility {
+	fields := strings.Fields(r.RawText)
+	for i, f := range fields {
+		if f == "RMK" {
+			break
+		}
+
+		group := f
+		if i+1 < len(fields) && len(f) == 1 && f[0] >= '1' && f[0] <= '9' && strings.HasSuffix(fields[i+1], "SM") {
+			group = f + " " + fields[i+1]
+		}
+
+		if v, err := ParseVisibility(group); err == nil {
+			return v
+		}
+	}
+
+	return Visibility{Value: r.VisibilityStatute, Unit: VisibilityUnitStatuteMiles}
+}
@@ -0,0 +1,56 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Visibility", func() {
+
+	DescribeTable("parsing visibility groups",
+		func(group string, expected Visibility) {
+			v, err := ParseVisibility(group)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(expected))
+		},
+		Entry("meters", "0400", Visibility{Value: 400, Unit: VisibilityUnitMeters}),
+		Entry("10 km or more", "9999", Visibility{Value: 10000, Unit: VisibilityUnitMeters, Modifier: VisibilityGreaterThan}),
+		Entry("less than 50 m", "0000", Visibility{Value: 50, Unit: VisibilityUnitMeters, Modifier: VisibilityLessThan}),
+		Entry("no directional variation", "4500NDV", Visibility{Value: 4500, Unit: VisibilityUnitMeters}),
+		Entry("CAVOK", "CAVOK", Visibility{Value: 10000, Unit: VisibilityUnitMeters, Modifier: VisibilityGreaterThan}),
+		Entry("statute miles", "10SM", Visibility{Value: 10, Unit: VisibilityUnitStatuteMiles}),
+		Entry("more than", "P6SM", Visibility{Value: 6, Unit: VisibilityUnitStatuteMiles, Modifier: VisibilityGreaterThan}),
+		Entry("more than (alternative)", ">6SM", Visibility{Value: 6, Unit: VisibilityUnitStatuteMiles, Modifier: VisibilityGreaterThan}),
+		Entry("less than", "M1/4SM", Visibility{Value: 0.25, Unit: VisibilityUnitStatuteMiles, Modifier: VisibilityLessThan}),
+		Entry("mixed fraction", "1 1/2SM", Visibility{Value: 1.5, Unit: VisibilityUnitStatuteMiles}),
+	)
+
+	It("should reject invalid groups", func() {
+		_, err := ParseVisibility("Q1018")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should convert between units", func() {
+		Expect(Visibility{Value: 1, Unit: VisibilityUnitStatuteMiles}.Meters()).To(BeNumerically("~", 1609.34, 0.01))
+		Expect(Visibility{Value: 1609.34, Unit: VisibilityUnitMeters}.StatuteMiles()).To(BeNumerically("~", 1, 0.001))
+		Expect(Visibility{Value: 10000, Unit: VisibilityUnitMeters, Modifier: VisibilityGreaterThan}.String()).To(Equal("> 10000 m"))
+	})
+
+	DescribeTable("extracting the visibility from a result",
+		func(raw string, statute float64, expected Visibility) {
+			Expect(Result{RawText: raw, VisibilityStatute: statute}.Visibility()).To(Equal(expected))
+		},
+		Entry("international", "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG", 6.21,
+			Visibility{Value: 10000, Unit: VisibilityUnitMeters, Modifier: VisibilityGreaterThan}),
+		Entry("variable wind", "EGLL 020520Z 24004KT 200V270 0800 FG 06/06 Q1025", 0.5,
+			Visibility{Value: 800, Unit: VisibilityUnitMeters}),
+		Entry("US mixed fraction", "KJFK 011251Z 31012KT 1 3/4SM BR OVC008 04/03 A3020", 1.75,
+			Visibility{Value: 1.75, Unit: VisibilityUnitStatuteMiles}),
+		Entry("no visibility group", "LFPG 151230Z AUTO 27010KT //// NCD 18/12 Q1016", 0.0,
+			Visibility{Value: 0, Unit: VisibilityUnitStatuteMiles}),
+	)
+
+})
@@ -0,0 +1,129 @@
+package metar
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	remarkVisibilityRegex = regexp.MustCompile(`^[PM]?\d+(?:/\d+)?$`)
+	wholeNumberRegex      = regexp.MustCompile(`^\d+$`)
+	fractionRegex         = regexp.MustCompile(`^\d+/\d+$`)
+	sectorDirectionRegex  = regexp.MustCompile(`^(?:N|NE|E|SE|S|SW|W|NW)$`)
+)
+
+// VariableVisibility is the range of a variable prevailing visibility
+// reported in the remarks (VIS 1/2V2)
+type VariableVisibility struct {
+	Min Visibility
+	Max Visibility
+}
+
+// SectorVisibility is the visibility in a sector differing from the
+// prevailing visibility reported in the remarks (VIS NE 2 1/2)
+type SectorVisibility struct {
+	Direction  string // 8-point compass direction of the sector
+	Visibility Visibility
+}
+
+// VariableVisibility returns the range of the prevailing visibility
+// reported in the remarks
+func (r Result) VariableVisibility() (VariableVisibility, bool) {
+	rmk := r.Remarks()
+	for i := range rmk {
+		if !isVisibilityRemark(rmk, i) || i+1 >= len(rmk) {
+			continue
+		}
+
+		groups := rmk[i+1:]
+		minText, maxText, found := strings.Cut(groups[0], "V")
+		if !found && len(groups) > 1 && wholeNumberRegex.MatchString(groups[0]) {
+			groups = groups[1:]
+			minText, maxText, found = strings.Cut(groups[0], "V")
+			minText = rmk[i+1] + " " + minText
+		}
+		if !found {
+			continue
+		}
+
+		if len(groups) > 1 && wholeNumberRegex.MatchString(maxText) && fractionRegex.MatchString(groups[1]) {
+			maxText += " " + groups[1]
+		}
+
+		minVis, okMin := parseRemarkVisibility(minText)
+		maxVis, okMax := parseRemarkVisibility(maxText)
+		if okMin && okMax {
+			return VariableVisibility{Min: minVis, Max: maxVis}, true
+		}
+	}
+	return VariableVisibility{}, false
+}
+
+// SectorVisibilities returns the sector visibilities reported in the
+// remarks
+func (r Result) SectorVisibilities() []SectorVisibility {
+	var (
+		rmk     = r.Remarks()
+		sectors []SectorVisibility
+	)
+
+	for i := range rmk {
+		if !isVisibilityRemark(rmk, i) || i+2 >= len(rmk) || !sectorDirectionRegex.MatchString(rmk[i+1]) {
+			continue
+		}
+
+		if v, ok := remarkVisibilityValue(rmk[i+2:]); ok {
+			sectors = append(sectors, SectorVisibility{Direction: rmk[i+1], Visibility: v})
+		}
+	}
+	return sectors
+}
+
+// TowerVisibility returns the visibility observed from the control tower
+// (TWR VIS 1 1/2) if it differs from the prevailing visibility
+func (r Result) TowerVisibility() (Visibility, bool) {
+	return r.locationVisibility("TWR")
+}
+
+// SurfaceVisibility returns the visibility observed at the surface
+// (SFC VIS 1/4) if the prevailing visibility was observed from the tower
+func (r Result) SurfaceVisibility() (Visibility, bool) {
+	return r.locationVisibility("SFC")
+}
+
+func (r Result) locationVisibility(location string) (Visibility, bool) {
+	rmk := r.Remarks()
+	for i := 0; i+2 < len(rmk); i++ {
+		if rmk[i] == location && rmk[i+1] == "VIS" {
+			return remarkVisibilityValue(rmk[i+2:])
+		}
+	}
+	return Visibility{}, false
+}
+
+// isVisibilityRemark reports whether the remark group at i starts a
+// visibility remark of the prevailing visibility (not TWR VIS / SFC VIS)
+func isVisibilityRemark(rmk []string, i int) bool {
+	return rmk[i] == "VIS" && (i == 0 || (rmk[i-1] != "TWR" && rmk[i-1] != "SFC"))
+}
+
+// remarkVisibilityValue decodes the visibility at the start of the groups
+// which might span two groups (1 1/2)
+func remarkVisibilityValue(groups []string) (Visibility, bool) {
+	if len(groups) > 1 && wholeNumberRegex.MatchString(groups[0]) && fractionRegex.MatchString(groups[1]) {
+		return parseRemarkVisibility(groups[0] + " " + groups[1])
+	}
+	return parseRemarkVisibility(groups[0])
+}
+
+// parseRemarkVisibility parses a visibility in statute miles given
+// without unit as used in remarks (M1/4, 1 1/2, 2)
+func parseRemarkVisibility(s string) (Visibility, bool) {
+	last := s[strings.LastIndex(s, " ")+1:]
+	if !remarkVisibilityRegex.MatchString(last) {
+		return Visibility{}, false
+	}
+
+	v, err := ParseVisibility(s + "SM")
+	return v, err == nil
+}
@@ -0,0 +1,74 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Visibility remarks", func() {
+
+	DescribeTable("variable visibility",
+		func(remark string, min, max float64) {
+			r := Result{RawText: "KBOS 151853Z 09015KT 1SM BR OVC005 14/13 A2990 RMK AO2 " + remark + " SLP125"}
+
+			v, ok := r.VariableVisibility()
+			Expect(ok).To(BeTrue())
+			Expect(v.Min).To(Equal(Visibility{Value: min, Unit: VisibilityUnitStatuteMiles}))
+			Expect(v.Max).To(Equal(Visibility{Value: max, Unit: VisibilityUnitStatuteMiles}))
+		},
+		Entry("fraction to whole", "VIS 1/2V2", 0.5, 2.0),
+		Entry("mixed minimum", "VIS 1 1/2V3", 1.5, 3.0),
+		Entry("mixed maximum", "VIS 3/4V1 1/2", 0.75, 1.5),
+	)
+
+	It("should report a missing variable visibility", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 1SM BR OVC005 14/13 A2990 RMK AO2 VIS NE 2 SLP125"}
+
+		_, ok := r.VariableVisibility()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should decode sector visibilities", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 3SM BR OVC005 14/13 A2990 RMK AO2 VIS NE 2 1/2 VIS SW M1/4 SLP125"}
+
+		Expect(r.SectorVisibilities()).To(Equal([]SectorVisibility{
+			{Direction: "NE", Visibility: Visibility{Value: 2.5, Unit: VisibilityUnitStatuteMiles}},
+			{Direction: "SW", Visibility: Visibility{Value: 0.25, Unit: VisibilityUnitStatuteMiles, Modifier: VisibilityLessThan}},
+		}))
+	})
+
+	It("should not mistake tower visibility for sector visibility", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 3SM BR OVC005 14/13 A2990 RMK AO2 TWR VIS 1 1/2"}
+
+		Expect(r.SectorVisibilities()).To(BeEmpty())
+		_, ok := r.VariableVisibility()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should decode tower and surface visibility", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 1SM BR OVC005 14/13 A2990 RMK AO2 TWR VIS 1 1/2 SFC VIS 1/4 SLP125"}
+
+		v, ok := r.TowerVisibility()
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal(Visibility{Value: 1.5, Unit: VisibilityUnitStatuteMiles}))
+
+		v, ok = r.SurfaceVisibility()
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal(Visibility{Value: 0.25, Unit: VisibilityUnitStatuteMiles}))
+
+		Expect(r.Visibility()).To(Equal(Visibility{Value: 1, Unit: VisibilityUnitStatuteMiles}))
+	})
+
+	It("should report missing tower and surface visibility", func() {
+		r := Result{RawText: "KBOS 151853Z 09015KT 1SM BR OVC005 14/13 A2990 RMK AO2 VIS NE 2"}
+
+		_, ok := r.TowerVisibility()
+		Expect(ok).To(BeFalse())
+		_, ok = r.SurfaceVisibility()
+		Expect(ok).To(BeFalse())
+	})
+
+})
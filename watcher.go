@@ -0,0 +1,160 @@
+package metar
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ObservationHandler is called by the Watcher for every new observation
+// of a station. The previous observation is nil for the first observation
+// seen by the Watcher.
+type ObservationHandler func(prev *Result, cur Result)
+
+// Watcher polls a set of stations and notifies its handlers about new
+// observations and about rules being tripped or cleared by them
+type Watcher struct {
+	client   *Client
+	stations []string
+	interval time.Duration
+
+	mu           sync.Mutex
+	last         map[string]Result
+	handlers     []ObservationHandler
+	rules        []Rule
+	ruleState    map[ruleKey]bool
+	ruleHandlers []RuleHandler
+}
+
+// NewWatcher creates a Watcher polling the stations using the client
+// every interval. A nil client uses the DefaultClient.
+func NewWatcher(c *Client, stations []string, interval time.Duration) *Watcher {
+	if c == nil {
+		c = DefaultClient
+	}
+
+	return &Watcher{
+		client:    c,
+		stations:  stations,
+		interval:  interval,
+		last:      make(map[string]Result),
+		ruleState: make(map[ruleKey]bool),
+	}
+}
+
+// OnObservation registers a handler for new observations
+func (w *Watcher) OnObservation(h ObservationHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.handlers = append(w.handlers, h)
+}
+
+// Run polls the stations until the context is cancelled. Failed polls are
+// logged and retried on the next interval.
+func (w *Watcher) Run(ctx context.Context) error {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		if err := w.Poll(ctx); err != nil && ctx.Err() == nil {
+			w.client.logger.Warn("polling watched stations failed", "stations", w.stations, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Poll fetches the current observations of the stations once and
+// dispatches those not seen before
+func (w *Watcher) Poll(ctx context.Context) error {
+	results, err := w.client.FetchStationsWeather(ctx, w.stations)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		w.observe(r)
+	}
+	return nil
+}
+
+// Write dispatches the results not seen before, making the Watcher usable
+// as a Collector Sink instead of polling on its own
+func (w *Watcher) Write(ctx context.Context, results []Result) error {
+	for _, r := range results {
+		w.observe(r)
+	}
+	return nil
+}
+
+// observe dispatches the result if it is newer than the last observation
+// of its station
+func (w *Watcher) observe(r Result) {
+	key := strings.ToUpper(r.StationID)
+
+	w.mu.Lock()
+	var prev *Result
+	if p, ok := w.last[key]; ok {
+		if !r.ObservationTime.After(p.ObservationTime) {
+			w.mu.Unlock()
+			return
+		}
+		prev = &p
+	}
+	w.last[key] = r
+
+	handlers := append([]ObservationHandler(nil), w.handlers...)
+	events := w.evaluateRules(key, r)
+	ruleHandlers := append([]RuleHandler(nil), w.ruleHandlers...)
+	w.mu.Unlock()
+
+	for _, h := range handlers {
+		h(prev, r)
+	}
+	for _, ev := range events {
+		for _, h := range ruleHandlers {
+			h(ev)
+		}
+	}
+}
+
+// FlightCategoryChange describes a transition of the flight category of a station
+type FlightCategoryChange struct {
+	Station  string
+	Previous FlightCategory
+	Current  FlightCategory
+	Result   Result // Observation reporting the new category
+}
+
+// OnFlightCategoryChange registers a handler called only when the flight
+// category of a station differs from its previous observation. Observations
+// without a flight category are ignored.
+func (w *Watcher) OnFlightCategoryChange(h func(FlightCategoryChange)) {
+	var (
+		mu   sync.Mutex
+		last = make(map[string]FlightCategory)
+	)
+
+	w.OnObservation(func(prev *Result, cur Result) {
+		if cur.FlightCategory == "" {
+			return
+		}
+
+		key := strings.ToUpper(cur.StationID)
+		mu.Lock()
+		previous, ok := last[key]
+		last[key] = cur.FlightCategory
+		mu.Unlock()
+
+		if !ok || previous == cur.FlightCategory {
+			return
+		}
+		h(FlightCategoryChange{Station: key, Previous: previous, Current: cur.FlightCategory, Result: cur})
+	})
+}
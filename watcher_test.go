@@ -0,0 +1,84 @@
+package metar_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watcher", func() {
+	var (
+		src     *staticSource
+		watcher *Watcher
+		base    = time.Date(2016, 5, 21, 10, 20, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		src = &staticSource{name: "static", results: []Result{{StationID: "EDDH", ObservationTime: base}}}
+		watcher = NewWatcher(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour)
+	})
+
+	It("should dispatch only new observations", func() {
+		var seen []*Result
+		watcher.OnObservation(func(prev *Result, cur Result) {
+			seen = append(seen, prev)
+		})
+
+		Expect(watcher.Poll(context.Background())).To(Succeed())
+		Expect(watcher.Poll(context.Background())).To(Succeed())
+		Expect(seen).To(HaveLen(1))
+		Expect(seen[0]).To(BeNil())
+
+		src.results[0].ObservationTime = base.Add(30 * time.Minute)
+		Expect(watcher.Poll(context.Background())).To(Succeed())
+		Expect(seen).To(HaveLen(2))
+		Expect(seen[1].ObservationTime).To(Equal(base))
+	})
+
+	It("should notify about flight category transitions", func() {
+		var changes []FlightCategoryChange
+		watcher.OnFlightCategoryChange(func(c FlightCategoryChange) {
+			changes = append(changes, c)
+		})
+
+		for i, cat := range []FlightCategory{FlightCategoryVFR, FlightCategoryVFR, "", FlightCategoryMVFR, FlightCategoryIFR} {
+			src.results[0].FlightCategory = cat
+			src.results[0].ObservationTime = base.Add(time.Duration(i) * time.Hour)
+			Expect(watcher.Poll(context.Background())).To(Succeed())
+		}
+
+		Expect(changes).To(HaveLen(2))
+		Expect(changes[0].Station).To(Equal("EDDH"))
+		Expect(changes[0].Previous).To(Equal(FlightCategoryVFR))
+		Expect(changes[0].Current).To(Equal(FlightCategoryMVFR))
+		Expect(changes[1].Previous).To(Equal(FlightCategoryMVFR))
+		Expect(changes[1].Current).To(Equal(FlightCategoryIFR))
+	})
+
+	It("should accept observations written by a Collector", func() {
+		var seen int
+		watcher.OnObservation(func(prev *Result, cur Result) { seen++ })
+
+		c := NewCollector(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour, watcher)
+		Expect(c.Collect(context.Background())).To(Succeed())
+		Expect(seen).To(Equal(1))
+	})
+
+	It("should stop running when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		polled := make(chan struct{})
+		watcher.OnObservation(func(prev *Result, cur Result) { close(polled) })
+
+		done := make(chan error)
+		go func() { done <- watcher.Run(ctx) }()
+
+		Eventually(polled).Should(BeClosed())
+		cancel()
+		Eventually(done).Should(Receive(Equal(context.Canceled)))
+	})
+
+})
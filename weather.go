@@ -0,0 +1,124 @@
+package metar
+
+import (
+	"regexp"
+	"strings"
+)
+
+var weatherGroupRegex = regexp.MustCompile(`^(\+|-|VC)?(MI|PR|BC|DR|BL|SH|TS|FZ)?((?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*)$`)
+
+// WeatherPhenomenon is a decoded present weather group like -SHRA
+type WeatherPhenomenon struct {
+	Intensity  string   // "-" (light), "+" (heavy) or empty (moderate)
+	Vicinity   bool     // Observed in the vicinity (VC) but not at the station
+	Descriptor string   // MI, PR, BC, DR, BL, SH, TS or FZ
+	Phenomena  []string // Precipitation, obscuration and other phenomena codes
+}
+
+// ParseWeather decodes a present weather group
+func ParseWeather(group string) (WeatherPhenomenon, bool) {
+	m := weatherGroupRegex.FindStringSubmatch(group)
+	if m == nil || (m[2] == "" && m[3] == "") {
+		return WeatherPhenomenon{}, false
+	}
+
+	w := WeatherPhenomenon{Descriptor: m[2]}
+	switch m[1] {
+	case "VC":
+		w.Vicinity = true
+	default:
+		w.Intensity = m[1]
+	}
+	for i := 0; i+2 <= len(m[3]); i += 2 {
+		w.Phenomena = append(w.Phenomena, m[3][i:i+2])
+	}
+	return w, true
+}
+
+// Has reports whether the group contains the phenomenon code
+func (w WeatherPhenomenon) Has(code string) bool {
+	for _, p := range w.Phenomena {
+		if p == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Weather decodes the present weather groups of WXString
+func (r Result) Weather() []WeatherPhenomenon {
+	var out []WeatherPhenomenon
+	for _, g := range strings.Fields(r.WXString) {
+		if w, ok := ParseWeather(g); ok {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// RecentWeather decodes the recent weather groups (RERA, RETS) of the raw
+// report: Weather which ended since the last routine report.
+func (r Result) RecentWeather() []WeatherPhenomenon {
+	var out []WeatherPhenomenon
+	for _, t := range Tokenize(r.RawText) {
+		if t.Kind != TokenRecentWeather {
+			continue
+		}
+		if w, ok := ParseWeather(t.Text[2:]); ok {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// isRecentWeatherGroup checks for a weather group prefixed by RE which
+// carries neither intensity nor vicinity
+func isRecentWeatherGroup(g string) bool {
+	if len(g) < 4 || !strings.HasPrefix(g, "RE") {
+		return false
+	}
+	w, ok := ParseWeather(g[2:])
+	return ok && w.Intensity == "" && !w.Vicinity
+}
+
+// anyWeather reports whether a group at the station matches the predicate
+func (r Result) anyWeather(fn func(WeatherPhenomenon) bool) bool {
+	for _, w := range r.Weather() {
+		if !w.Vicinity && fn(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasThunderstorm reports a thunderstorm at the station or in its vicinity (VCTS)
+func (r Result) HasThunderstorm() bool {
+	for _, w := range r.Weather() {
+		if w.Descriptor == "TS" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFreezingPrecipitation reports freezing rain, drizzle or unknown
+// precipitation at the station
+func (r Result) HasFreezingPrecipitation() bool {
+	return r.anyWeather(func(w WeatherPhenomenon) bool {
+		return w.Descriptor == "FZ" && (w.Has("RA") || w.Has("DZ") || w.Has("UP"))
+	})
+}
+
+// HasSnow reports snow or snow grains falling at the station
+func (r Result) HasSnow() bool {
+	return r.anyWeather(func(w WeatherPhenomenon) bool {
+		return (w.Has("SN") && w.Descriptor != "BL" && w.Descriptor != "DR") || w.Has("SG")
+	})
+}
+
+// HasHail reports hail or small hail at the station
+func (r Result) HasHail() bool {
+	return r.anyWeather(func(w WeatherPhenomenon) bool {
+		return w.Has("GR") || w.Has("GS")
+	})
+}
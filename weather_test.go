@@ -0,0 +1,66 @@
+package metar_test
+
+import (
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Weather", func() {
+
+	It("should decode weather groups", func() {
+		w, ok := ParseWeather("-SHRASN")
+		Expect(ok).To(BeTrue())
+		Expect(w).To(Equal(WeatherPhenomenon{Intensity: "-", Descriptor: "SH", Phenomena: []string{"RA", "SN"}}))
+
+		w, ok = ParseWeather("VCTS")
+		Expect(ok).To(BeTrue())
+		Expect(w.Vicinity).To(BeTrue())
+		Expect(w.Descriptor).To(Equal("TS"))
+
+		_, ok = ParseWeather("+")
+		Expect(ok).To(BeFalse())
+		_, ok = ParseWeather("XYZ")
+		Expect(ok).To(BeFalse())
+	})
+
+	table.DescribeTable("predicates",
+		func(wx string, thunderstorm, freezing, snow, hail bool) {
+			r := Result{WXString: wx}
+			Expect(r.HasThunderstorm()).To(Equal(thunderstorm))
+			Expect(r.HasFreezingPrecipitation()).To(Equal(freezing))
+			Expect(r.HasSnow()).To(Equal(snow))
+			Expect(r.HasHail()).To(Equal(hail))
+		},
+		table.Entry("thunderstorm with hail", "+TSRAGR", true, false, false, true),
+		table.Entry("thunderstorm in vicinity", "VCTS -RA", true, false, false, false),
+		table.Entry("freezing rain", "-FZRA BR", false, true, false, false),
+		table.Entry("freezing fog", "FZFG", false, false, false, false),
+		table.Entry("snow showers", "SHSN", false, false, true, false),
+		table.Entry("blowing snow", "BLSN", false, false, false, false),
+		table.Entry("snow in vicinity", "VCSN", false, false, false, false),
+		table.Entry("nothing", "", false, false, false, false),
+	)
+
+	It("should decode recent weather groups", func() {
+		r, err := Parser{Strict: true}.Parse("EDDH 211020Z 27008KT 9999 FEW030CB 17/09 Q1018 RETSRA RESHSN NOSIG")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(r.RecentWeather()).To(Equal([]WeatherPhenomenon{
+			{Descriptor: "TS", Phenomena: []string{"RA"}},
+			{Descriptor: "SH", Phenomena: []string{"SN"}},
+		}))
+		Expect(r.WXString).To(BeEmpty())
+		Expect(r.HasThunderstorm()).To(BeFalse())
+	})
+
+	It("should not decode recent weather with intensity", func() {
+		tokens := Tokenize("EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 RE-RA RERA")
+		Expect(tokens[7].Kind).To(Equal(TokenUnknown))
+		Expect(tokens[8].Kind).To(Equal(TokenRecentWeather))
+		Expect(tokens[8].Kind.String()).To(Equal("recent weather"))
+	})
+
+})
@@ -0,0 +1,189 @@
+package metar
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Webhook event types sent in the X-Metar-Event header and the event field
+// of the payload
+const (
+	WebhookEventObservation = "observation"
+	WebhookEventRule        = "rule"
+)
+
+// Webhook is a receiver of dispatched events
+type Webhook struct {
+	URL string
+	// Secret used to sign the body with HMAC-SHA256, the signature is sent
+	// as "sha256=<hex>" in the X-Metar-Signature header. No signature is
+	// sent without a secret.
+	Secret string
+}
+
+// WebhookPayload is the JSON body POSTed to the webhooks
+type WebhookPayload struct {
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// WebhookDispatcher POSTs observations and rule events as JSON to a set
+// of webhooks, retrying failed deliveries
+type WebhookDispatcher struct {
+	hooks      []Webhook
+	httpClient *http.Client
+	attempts   int
+	backoff    time.Duration
+	logger     *slog.Logger
+}
+
+// WebhookOption configures a WebhookDispatcher
+type WebhookOption func(*WebhookDispatcher)
+
+// NewWebhookDispatcher creates a dispatcher delivering to the given hooks.
+// By default every delivery is attempted three times with an exponential
+// backoff starting at one second.
+func NewWebhookDispatcher(hooks []Webhook, opts ...WebhookOption) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		hooks:    hooks,
+		attempts: 3,
+		backoff:  time.Second,
+		logger:   discardLogger,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// WithWebhookHTTPClient sets the http.Client used for deliveries. If not
+// set the package level HTTPClient is used.
+func WithWebhookHTTPClient(hc *http.Client) WebhookOption {
+	return func(d *WebhookDispatcher) { d.httpClient = hc }
+}
+
+// WithWebhookRetries sets the number of delivery attempts and the delay
+// before the first retry, which doubles with every further retry
+func WithWebhookRetries(attempts int, backoff time.Duration) WebhookOption {
+	return func(d *WebhookDispatcher) { d.attempts, d.backoff = attempts, backoff }
+}
+
+// WithWebhookLogger logs failed deliveries to the given logger
+func WithWebhookLogger(l *slog.Logger) WebhookOption {
+	return func(d *WebhookDispatcher) { d.logger = l }
+}
+
+// Attach registers the dispatcher on the watcher to deliver new
+// observations and rule events. Deliveries happen in the background,
+// failures are logged.
+func (d *WebhookDispatcher) Attach(w *Watcher) {
+	w.OnObservation(func(prev *Result, cur Result) {
+		go d.dispatchLogged(WebhookEventObservation, cur)
+	})
+	w.OnRuleEvent(func(ev RuleEvent) {
+		go d.dispatchLogged(WebhookEventRule, ev)
+	})
+}
+
+func (d *WebhookDispatcher) dispatchLogged(event string, data interface{}) {
+	if err := d.Dispatch(context.Background(), event, data); err != nil {
+		d.logger.Warn("webhook delivery failed", "event", event, "error", err)
+	}
+}
+
+// Write delivers every result as an observation event, making the
+// dispatcher usable as a Collector Sink
+func (d *WebhookDispatcher) Write(ctx context.Context, results []Result) error {
+	var firstErr error
+	for _, r := range results {
+		if err := d.Dispatch(ctx, WebhookEventObservation, r); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Dispatch delivers the event to all webhooks and returns the first
+// error of a delivery failing after all attempts
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, event string, data interface{}) error {
+	body, err := json.Marshal(WebhookPayload{Event: event, Data: data})
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, h := range d.hooks {
+		if err := d.deliver(ctx, h, event, body); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, h Webhook, event string, body []byte) error {
+	var err error
+	for attempt := 0; attempt < d.attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(d.backoff << uint(attempt-1)):
+			}
+		}
+
+		var retry bool
+		if retry, err = d.post(ctx, h, event, body); err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+// post sends the body once and reports whether a failure may be retried
+func (d *WebhookDispatcher) post(ctx context.Context, h Webhook, event string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Metar-Event", event)
+	if h.Secret != "" {
+		req.Header.Set("X-Metar-Signature", SignWebhookBody(h.Secret, body))
+	}
+
+	hc := d.httpClient
+	if hc == nil {
+		hc = HTTPClient
+	}
+
+	res, err := hc.Do(req)
+	if err != nil {
+		return true, err
+	}
+	res.Body.Close()
+
+	switch {
+	case res.StatusCode < 300:
+		return false, nil
+	case res.StatusCode == http.StatusTooManyRequests, res.StatusCode >= 500:
+		return true, fmt.Errorf("Webhook %s returned status %d", h.URL, res.StatusCode)
+	default:
+		return false, fmt.Errorf("Webhook %s returned status %d", h.URL, res.StatusCode)
+	}
+}
+
+// SignWebhookBody returns the value of the X-Metar-Signature header for
+// the body, receivers use it to verify deliveries
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
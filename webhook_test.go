@@ -0,0 +1,84 @@
+package metar_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookDispatcher", func() {
+	var (
+		server    *httptest.Server
+		requests  int32
+		failFirst int32
+		body      []byte
+		header    http.Header
+	)
+
+	BeforeEach(func() {
+		atomic.StoreInt32(&requests, 0)
+		atomic.StoreInt32(&failFirst, 0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&requests, 1)
+			if n <= atomic.LoadInt32(&failFirst) {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			body, _ = ioutil.ReadAll(r.Body)
+			header = r.Header
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should post signed JSON payloads", func() {
+		d := NewWebhookDispatcher([]Webhook{{URL: server.URL, Secret: "s3cr3t"}})
+		Expect(d.Dispatch(context.Background(), WebhookEventObservation, Result{StationID: "EDDH"})).To(Succeed())
+
+		payload := struct {
+			Event string
+			Data  Result
+		}{}
+		Expect(json.Unmarshal(body, &payload)).To(Succeed())
+		Expect(payload.Event).To(Equal(WebhookEventObservation))
+		Expect(payload.Data.StationID).To(Equal("EDDH"))
+		Expect(header.Get("X-Metar-Event")).To(Equal(WebhookEventObservation))
+		Expect(header.Get("X-Metar-Signature")).To(Equal(SignWebhookBody("s3cr3t", body)))
+	})
+
+	It("should retry failed deliveries", func() {
+		atomic.StoreInt32(&failFirst, 2)
+		d := NewWebhookDispatcher([]Webhook{{URL: server.URL}}, WithWebhookRetries(3, time.Millisecond))
+		Expect(d.Dispatch(context.Background(), WebhookEventRule, RuleEvent{Rule: "gusts"})).To(Succeed())
+		Expect(atomic.LoadInt32(&requests)).To(Equal(int32(3)))
+		Expect(header.Get("X-Metar-Signature")).To(BeEmpty())
+	})
+
+	It("should give up after the configured attempts", func() {
+		atomic.StoreInt32(&failFirst, 5)
+		d := NewWebhookDispatcher([]Webhook{{URL: server.URL}}, WithWebhookRetries(2, time.Millisecond))
+		Expect(d.Dispatch(context.Background(), WebhookEventRule, RuleEvent{})).To(MatchError(ContainSubstring("status 502")))
+		Expect(atomic.LoadInt32(&requests)).To(Equal(int32(2)))
+	})
+
+	It("should deliver observations of an attached watcher", func() {
+		src := &staticSource{name: "static", results: []Result{{StationID: "EDDH"}}}
+		w := NewWatcher(NewClient(WithSource(src)), []string{"EDDH"}, time.Hour)
+		NewWebhookDispatcher([]Webhook{{URL: server.URL}}).Attach(w)
+
+		Expect(w.Poll(context.Background())).To(Succeed())
+		Eventually(func() int32 { return atomic.LoadInt32(&requests) }).Should(Equal(int32(1)))
+	})
+
+})
@@ -0,0 +1,91 @@
+package metar
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var (
+	peakWindRegex   = regexp.MustCompile(`^(\d{3})(\d{2,3})/(\d{2}|\d{4})$`)
+	remarkTimeRegex = regexp.MustCompile(`^(\d{2}|\d{4})$`)
+)
+
+// PeakWind is the peak wind since the last routine report decoded from
+// the PK WND remark (PK WND 28045/1955)
+type PeakWind struct {
+	DirDegrees int64     // Direction the peak wind was blowing from
+	Speed      int64     // Speed of the peak wind (kts)
+	At         time.Time // Time the peak wind occurred
+}
+
+// WindShiftRemark is a wind shift reported in the WSHFT remark
+// (WSHFT 1715 FROPA)
+type WindShiftRemark struct {
+	At             time.Time // Time the wind shift began
+	FrontalPassage bool      // Wind shift is associated with a frontal passage (FROPA)
+}
+
+// PeakWind returns the peak wind reported in the remarks
+func (r Result) PeakWind() (PeakWind, bool) {
+	rmk := r.Remarks()
+	for i := 0; i+2 < len(rmk); i++ {
+		if rmk[i] != "PK" || rmk[i+1] != "WND" {
+			continue
+		}
+
+		m := peakWindRegex.FindStringSubmatch(rmk[i+2])
+		if m == nil {
+			return PeakWind{}, false
+		}
+
+		dir, _ := strconv.ParseInt(m[1], 10, 64)
+		speed, _ := strconv.ParseInt(m[2], 10, 64)
+		return PeakWind{
+			DirDegrees: dir,
+			Speed:      speed,
+			At:         r.remarkTime(m[3]),
+		}, true
+	}
+	return PeakWind{}, false
+}
+
+// WindShiftRemark returns the wind shift reported in the remarks
+func (r Result) WindShiftRemark() (WindShiftRemark, bool) {
+	rmk := r.Remarks()
+	for i := 0; i+1 < len(rmk); i++ {
+		if rmk[i] != "WSHFT" || !remarkTimeRegex.MatchString(rmk[i+1]) {
+			continue
+		}
+
+		return WindShiftRemark{
+			At:             r.remarkTime(rmk[i+1]),
+			FrontalPassage: i+2 < len(rmk) && rmk[i+2] == "FROPA",
+		}, true
+	}
+	return WindShiftRemark{}, false
+}
+
+// remarkTime resolves the minutes (mm) or hours and minutes (hhmm) of a
+// remark relative to the observation time. Remarks refer to the time
+// before the observation so later times belong to the previous hour or day.
+func (r Result) remarkTime(s string) time.Time {
+	obs := r.ObservationTime.UTC()
+
+	if len(s) == 2 {
+		minute, _ := strconv.Atoi(s)
+		t := time.Date(obs.Year(), obs.Month(), obs.Day(), obs.Hour(), minute, 0, 0, time.UTC)
+		if t.After(obs) {
+			t = t.Add(-time.Hour)
+		}
+		return t
+	}
+
+	hour, _ := strconv.Atoi(s[:2])
+	minute, _ := strconv.Atoi(s[2:])
+	t := time.Date(obs.Year(), obs.Month(), obs.Day(), hour, minute, 0, 0, time.UTC)
+	if t.After(obs) {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
@@ -0,0 +1,74 @@
+package metar_test
+
+import (
+	"time"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Wind remarks", func() {
+
+	It("should decode the peak wind with hours and minutes", func() {
+		r, err := Parser{Reference: time.Date(2016, 7, 16, 0, 0, 0, 0, time.UTC)}.Parse("KMIA 151853Z 09015G28KT 2SM +TSRA BR BKN025CB 24/22 A2990 RMK AO2 PK WND 10032/1840 SLP125")
+		Expect(err).NotTo(HaveOccurred())
+
+		pk, ok := r.PeakWind()
+		Expect(ok).To(BeTrue())
+		Expect(pk).To(Equal(PeakWind{
+			DirDegrees: 100,
+			Speed:      32,
+			At:         time.Date(2016, 7, 15, 18, 40, 0, 0, time.UTC),
+		}))
+	})
+
+	It("should resolve minutes only and three digit speeds", func() {
+		r := Result{
+			RawText:         "KJFK 020051Z 28065G105KT 10SM FEW030 17/01 A2992 RMK AO2 PK WND 280105/35",
+			ObservationTime: time.Date(2016, 7, 2, 0, 51, 0, 0, time.UTC),
+		}
+
+		pk, ok := r.PeakWind()
+		Expect(ok).To(BeTrue())
+		Expect(pk.Speed).To(Equal(int64(105)))
+		Expect(pk.At).To(Equal(time.Date(2016, 7, 2, 0, 35, 0, 0, time.UTC)))
+	})
+
+	It("should place times after the observation on the previous day", func() {
+		r := Result{
+			RawText:         "KBOS 020005Z 30012KT 10SM FEW030 17/01 A2992 RMK AO2 WSHFT 2350 FROPA",
+			ObservationTime: time.Date(2016, 7, 2, 0, 5, 0, 0, time.UTC),
+		}
+
+		ws, ok := r.WindShiftRemark()
+		Expect(ok).To(BeTrue())
+		Expect(ws).To(Equal(WindShiftRemark{
+			At:             time.Date(2016, 7, 1, 23, 50, 0, 0, time.UTC),
+			FrontalPassage: true,
+		}))
+	})
+
+	It("should decode wind shifts without frontal passage", func() {
+		r := Result{
+			RawText:         "KBOS 021254Z 30012KT 10SM FEW030 17/01 A2992 RMK AO2 WSHFT 30 SLP132",
+			ObservationTime: time.Date(2016, 7, 2, 12, 54, 0, 0, time.UTC),
+		}
+
+		ws, ok := r.WindShiftRemark()
+		Expect(ok).To(BeTrue())
+		Expect(ws.At).To(Equal(time.Date(2016, 7, 2, 12, 30, 0, 0, time.UTC)))
+		Expect(ws.FrontalPassage).To(BeFalse())
+	})
+
+	It("should report missing wind remarks", func() {
+		r := Result{RawText: "EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 NOSIG"}
+
+		_, ok := r.PeakWind()
+		Expect(ok).To(BeFalse())
+		_, ok = r.WindShiftRemark()
+		Expect(ok).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,108 @@
+package metar
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// WithLenientXML makes the client tolerate slightly malformed dataserver
+// XML as delivered by some mirrors: unclosed elements and HTML entities
+// are accepted and fields with values not matching their type are left
+// out of the result (see Present) instead of failing the whole response.
+func WithLenientXML() ClientOption {
+	return func(c *Client) { c.lenientXML = true }
+}
+
+// DecodeXMLLenient reads a dataserver XML response like DecodeXML but
+// tolerates malformed documents like a client created with WithLenientXML
+func DecodeXMLLenient(r io.Reader) ([]Result, error) {
+	it := NewResultIterator(r)
+	it.setLenient()
+	return it.all()
+}
+
+// lenientXML reports whether the client making the request decodes XML
+// leniently
+func lenientXML(ctx context.Context) bool {
+	if rc, ok := ctx.Value(requestContextKey{}).(requestContext); ok {
+		return rc.client.lenientXML
+	}
+	return false
+}
+
+// newXMLDecoder creates a decoder understanding the charsets used by
+// dataserver mirrors
+func newXMLDecoder(r io.Reader) *xml.Decoder {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+	return dec
+}
+
+// newLenientDecoder creates a non-strict decoder
+func newLenientDecoder(r io.Reader) *xml.Decoder {
+	dec := newXMLDecoder(r)
+	makeLenient(dec)
+	return dec
+}
+
+func makeLenient(dec *xml.Decoder) {
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+}
+
+// charsetReader converts ISO-8859-1 and Windows-1252 documents to UTF-8.
+// Other charsets than these and UTF-8 are rejected.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "l1":
+		return &singleByteReader{r: bufio.NewReader(input)}, nil
+	case "windows-1252", "cp1252":
+		return &singleByteReader{r: bufio.NewReader(input), table: &windows1252}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported charset %q", charset)
+	}
+}
+
+// windows1252 maps the bytes 0x80-0x9F of Windows-1252 which differ from
+// ISO-8859-1, unassigned bytes keep their ISO-8859-1 meaning
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// singleByteReader decodes a single byte charset into UTF-8
+type singleByteReader struct {
+	r     *bufio.Reader
+	table *[32]rune
+	buf   []byte
+}
+
+func (s *singleByteReader) Read(p []byte) (int, error) {
+	for len(s.buf) < len(p) {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			if len(s.buf) > 0 {
+				break
+			}
+			return 0, err
+		}
+
+		r := rune(b)
+		if s.table != nil && b >= 0x80 && b < 0xA0 {
+			r = s.table[b-0x80]
+		}
+		s.buf = utf8.AppendRune(s.buf, r)
+	}
+
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,93 @@
+package metar_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	. "github.com/Luzifer/go-metar"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("XML decoding", func() {
+
+	It("should decode ISO-8859-1 documents", func() {
+		doc := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+			"<response><data num_results=\"1\"><METAR><station_id>EDDH</station_id>" +
+			"<raw_text>EDDH 211020Z 27008KT 9999 FEW030 17/09 Q1018 RMK M\xfcller</raw_text></METAR></data></response>"
+
+		results, err := DecodeXML(strings.NewReader(doc))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].RawText).To(HaveSuffix("RMK Müller"))
+	})
+
+	It("should decode Windows-1252 documents", func() {
+		doc := "<?xml version=\"1.0\" encoding=\"windows-1252\"?>\n" +
+			"<response><data num_results=\"1\"><METAR><station_id>EDDH</station_id>" +
+			"<raw_text>\x80 \xe4</raw_text></METAR></data></response>"
+
+		results, err := DecodeXML(strings.NewReader(doc))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].RawText).To(Equal("€ ä"))
+	})
+
+	It("should reject unknown charsets", func() {
+		_, err := DecodeXML(strings.NewReader(`<?xml version="1.0" encoding="EBCDIC"?><response/>`))
+		Expect(err).To(MatchError(ContainSubstring(`Unsupported charset "EBCDIC"`)))
+	})
+
+	malformed := `<response><data num_results="2">
+		<METAR><station_id>EDDH</station_id><temp_c>N/A</temp_c><wind_speed_kt>8</wind_speed_kt>
+			<quality_control_flags><auto>maybe</auto><corrected>TRUE</corrected></quality_control_flags>
+			<remark>&nbsp;</remark><unexpected/></METAR>
+		<METAR><station_id>EDDW</station_id><temp_c>12</temp_c></METAR>
+	</data></response>`
+
+	It("should fail on malformed documents by default", func() {
+		_, err := DecodeXML(strings.NewReader(malformed))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should tolerate malformed documents when lenient", func() {
+		results, err := DecodeXMLLenient(strings.NewReader(malformed))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+
+		Expect(results[0].StationID).To(Equal("EDDH"))
+		Expect(results[0].Present.Has(FieldTemperature)).To(BeFalse())
+		Expect(results[0].Present.Has(FieldQualityControlFlags)).To(BeFalse())
+		Expect(results[0].WindSpeed).To(Equal(int64(8)))
+
+		Expect(results[1].StationID).To(Equal("EDDW"))
+		Expect(results[1].Temperature).To(Equal(12.0))
+	})
+
+	It("should keep attributes of elements when lenient", func() {
+		doc := `<response><data num_results="1">
+			<METAR><station_id>EDDH</station_id><temp_c>N/A</temp_c>
+				<sky_condition sky_cover="FEW" cloud_base_ft_agl="3000"/></METAR>
+		</data></response>`
+
+		results, err := DecodeXMLLenient(strings.NewReader(doc))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Present.Has(FieldSkyCondition)).To(BeTrue())
+		Expect(results[0].SkyCondition.SkyCover).To(Equal(SkyCoverFEW))
+	})
+
+	It("should decode leniently with WithLenientXML", func() {
+		client := NewClient(
+			WithLenientXML(),
+			WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return xmlResponse(req, malformed)
+			})}),
+		)
+
+		results, err := client.FetchStationsWeather(context.Background(), []string{"EDDH", "EDDW"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results).To(HaveLen(2))
+	})
+
+})